@@ -0,0 +1,92 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:validation:XValidation:rule="(has(self.__namespace__) ? self.__namespace__ : '') == (has(oldSelf.__namespace__) ? oldSelf.__namespace__ : '')",message="namespace is immutable"
+
+// BlackboxExporterPoolSpec defines a dedicated blackbox exporter for the monitors selecting the pool
+type BlackboxExporterPoolSpec struct {
+	// Namespace the exporter of the pool runs in, the namespace of the default blackbox exporter when empty.
+	// Every pool runs its own exporter, so pools can share a namespace
+	// +kubebuilder:validation:Optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Replicas of the exporter of the pool, the number of replicas of the default blackbox exporter when unset
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// BlackboxExporterConfig configures the placement, the network and the security of the exporter pods of the pool.
+	// The configuration of the default blackbox exporter in the RouteMonitorOperatorConfig does not apply to pools
+	BlackboxExporterConfig `json:",inline"`
+}
+
+// BlackboxExporterPoolStatus reports the exporter of the pool
+type BlackboxExporterPoolStatus struct {
+	// Exporter is the Service of the exporter of the pool, the ServiceMonitors of the monitors selecting the pool select it
+	Exporter NamespacedName `json:"exporter,omitempty"`
+	// Conditions report whether the exporter of the pool is in place
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// ObservedGeneration is the generation of the spec the exporter was last applied for
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// GetConditions returns the conditions of the status
+func (p *BlackboxExporterPool) GetConditions() []metav1.Condition {
+	return p.Status.Conditions
+}
+
+// SetConditions replaces the conditions of the status
+func (p *BlackboxExporterPool) SetConditions(conditions []metav1.Condition) {
+	p.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:validation:XValidation:rule="self.metadata.name.matches('^[a-z]([-a-z0-9]*[a-z0-9])?$') && size(self.metadata.name) <= 45",message="the name has to be a DNS label of at most 45 characters, as it is part of the name of the exporter Service"
+// +kubebuilder:printcolumn:name="Namespace",type=string,JSONPath=`.status.exporter.namespace`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// BlackboxExporterPool is the Schema for the blackboxexporterpools API.
+// It runs a dedicated blackbox exporter probing the RouteMonitors and ClusterUrlMonitors selecting the pool,
+// so their probes do not share the concurrency of the default exporter and run from the nodes and network of the pool
+type BlackboxExporterPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BlackboxExporterPoolSpec   `json:"spec,omitempty"`
+	Status BlackboxExporterPoolStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BlackboxExporterPoolList contains a list of BlackboxExporterPool
+type BlackboxExporterPoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BlackboxExporterPool `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BlackboxExporterPool{}, &BlackboxExporterPoolList{})
+}
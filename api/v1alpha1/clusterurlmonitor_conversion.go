@@ -0,0 +1,98 @@
+package v1alpha1
+
+import (
+	"strconv"
+
+	"github.com/openshift/route-monitor-operator/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+var _ conversion.Convertible = &ClusterUrlMonitor{}
+
+// ConvertTo converts the ClusterUrlMonitor to the v1beta1 storage version
+func (src *ClusterUrlMonitor) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.ClusterUrlMonitor)
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+
+	// an empty port is kept as zero, anything else which is not converted back to the same string,
+	// e.g. a name or a zero padded number, is kept for the way back only
+	port, err := strconv.ParseInt(src.Spec.Port, 10, 32)
+	lost := err != nil || formatPort(int32(port)) != src.Spec.Port
+	if lost {
+		port = 0
+	}
+	dst.Annotations = setPortAnnotation(dst.Annotations, src.Spec.Port, lost)
+
+	dst.Spec = v1beta1.ClusterUrlMonitorSpec{
+		Prefix:               src.Spec.Prefix,
+		Suffix:               src.Spec.Suffix,
+		Port:                 int32(port),
+		Slo:                  convertSloSpecTo(src.Spec.Slo),
+		DomainRef:            v1beta1.ClusterDomainRef(src.Spec.DomainRef),
+		CreatePrometheusRule: !src.Spec.SkipPrometheusRule,
+		Probe:                convertProbeSpecTo(src.Spec.Probe),
+		CertificateExpiry:    (*v1beta1.CertificateExpirySpec)(src.Spec.CertificateExpiry),
+		ProbeLocations:       convertProbeLocationsSpecTo(src.Spec.ProbeLocations),
+		MaintenanceWindows:   convertMaintenanceWindowsTo(src.Spec.MaintenanceWindows),
+		BlackboxExporterPool: src.Spec.BlackboxExporterPool,
+	}
+	dst.Status = v1beta1.ClusterUrlMonitorStatus{
+		ServiceMonitorRef:       v1beta1.NamespacedName(src.Status.ServiceMonitorRef),
+		PrometheusRuleRef:       v1beta1.NamespacedName(src.Status.PrometheusRuleRef),
+		ErrorStatus:             src.Status.ErrorStatus,
+		URL:                     src.Status.URL,
+		ProbeStatus:             (*v1beta1.ProbeStatus)(src.Status.ProbeStatus),
+		ActiveMaintenanceWindow: (*v1beta1.ActiveMaintenanceWindow)(src.Status.ActiveMaintenanceWindow),
+		ObservedGeneration:      src.Status.ObservedGeneration,
+		Conditions:              src.Status.Conditions,
+	}
+	return nil
+}
+
+// ConvertFrom converts the ClusterUrlMonitor from the v1beta1 storage version
+func (dst *ClusterUrlMonitor) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.ClusterUrlMonitor)
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+
+	port := formatPort(src.Spec.Port)
+	var alphaPort string
+	var lost bool
+	dst.Annotations, alphaPort, lost = popPortAnnotation(dst.Annotations)
+	// the recorded port is only restored as long as the port was not set through v1beta1
+	if lost && src.Spec.Port == 0 {
+		port = alphaPort
+	}
+
+	dst.Spec = ClusterUrlMonitorSpec{
+		Prefix:               src.Spec.Prefix,
+		Suffix:               src.Spec.Suffix,
+		Port:                 port,
+		Slo:                  convertSloSpecFrom(src.Spec.Slo),
+		DomainRef:            ClusterDomainRef(src.Spec.DomainRef),
+		SkipPrometheusRule:   !src.Spec.CreatePrometheusRule,
+		Probe:                convertProbeSpecFrom(src.Spec.Probe),
+		CertificateExpiry:    (*CertificateExpirySpec)(src.Spec.CertificateExpiry),
+		ProbeLocations:       convertProbeLocationsSpecFrom(src.Spec.ProbeLocations),
+		MaintenanceWindows:   convertMaintenanceWindowsFrom(src.Spec.MaintenanceWindows),
+		BlackboxExporterPool: src.Spec.BlackboxExporterPool,
+	}
+	dst.Status = ClusterUrlMonitorStatus{
+		ServiceMonitorRef:       NamespacedName(src.Status.ServiceMonitorRef),
+		PrometheusRuleRef:       NamespacedName(src.Status.PrometheusRuleRef),
+		ErrorStatus:             src.Status.ErrorStatus,
+		URL:                     src.Status.URL,
+		ProbeStatus:             (*ProbeStatus)(src.Status.ProbeStatus),
+		ActiveMaintenanceWindow: (*ActiveMaintenanceWindow)(src.Status.ActiveMaintenanceWindow),
+		ObservedGeneration:      src.Status.ObservedGeneration,
+		Conditions:              src.Status.Conditions,
+	}
+	return nil
+}
+
+// formatPort returns the v1alpha1 port of a v1beta1 port, zero is the unset port
+func formatPort(port int32) string {
+	if port == 0 {
+		return ""
+	}
+	return strconv.FormatInt(int64(port), 10)
+}
@@ -44,6 +44,36 @@ type ClusterUrlMonitorSpec struct {
 	// SkipPrometheusRule instructs the controller to skip the creation of PrometheusRule CRs.
 	// One common use-case for is for alerts that are defined separately, such as for hosted clusters.
 	SkipPrometheusRule bool `json:"skipPrometheusRule"`
+
+	// +kubebuilder:validation:Optional
+
+	// Probe customizes how the blackbox exporter probes the URL
+	Probe ProbeSpec `json:"probe,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// CertificateExpiry adds alerts firing before the certificate presented by the URL expires
+	CertificateExpiry *CertificateExpirySpec `json:"certificateExpiry,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// ProbeLocations probes the URL from several vantage points, the alerts only fire once the quorum of
+	// locations observes the failure. The blackbox exporter of the monitor is the only location when absent
+	ProbeLocations *ProbeLocationsSpec `json:"probeLocations,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+
+	// MaintenanceWindows suppress the burn rate alerts of the URL, e.g. during planned upgrades.
+	// The probes keep running, so the probe status covers the windows as well
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// BlackboxExporterPool is the name of the BlackboxExporterPool whose exporter probes the URL,
+	// the default blackbox exporter probes it when empty
+	BlackboxExporterPool string `json:"blackboxExporterPool,omitempty"`
 }
 
 // ClusterDomainRef defines the object used determine the cluster's domain
@@ -65,10 +95,29 @@ type ClusterUrlMonitorStatus struct {
 	ServiceMonitorRef NamespacedName `json:"serviceMonitorRef,omitempty"`
 	PrometheusRuleRef NamespacedName `json:"prometheusRuleRef,omitempty"`
 	ErrorStatus       string         `json:"errorStatus,omitempty"`
+	// URL is the probed URL, built from the prefix, the cluster domain, the port and the suffix
+	URL string `json:"url,omitempty"`
+	// +optional
+	// ProbeStatus is the health of the probes queried from Prometheus, it is only set when the operator is
+	// configured with a Prometheus or Thanos Querier endpoint
+	ProbeStatus *ProbeStatus `json:"probeStatus,omitempty"`
+	// +optional
+	// ActiveMaintenanceWindow is the maintenance window the alerts are currently suppressed by
+	ActiveMaintenanceWindow *ActiveMaintenanceWindow `json:"activeMaintenanceWindow,omitempty"`
+	// ObservedGeneration is the generation of the spec the conditions were last set for
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// Conditions report the progress of the reconciliation, Ready is true once the probes and alerts are in place
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Maintenance",type=string,JSONPath=`.status.activeMaintenanceWindow.name`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // ClusterUrlMonitor is the Schema for the clusterurlmonitors API
 type ClusterUrlMonitor struct {
@@ -79,6 +128,17 @@ type ClusterUrlMonitor struct {
 	Status ClusterUrlMonitorStatus `json:"status,omitempty"`
 }
 
+// GetConditions returns the conditions of the ClusterUrlMonitor
+func (m *ClusterUrlMonitor) GetConditions() []metav1.Condition {
+	return m.Status.Conditions
+}
+
+// SetConditions sets the conditions of the ClusterUrlMonitor, observed for its current generation
+func (m *ClusterUrlMonitor) SetConditions(conditions []metav1.Condition) {
+	m.Status.Conditions = conditions
+	m.Status.ObservedGeneration = m.Generation
+}
+
 // +kubebuilder:object:root=true
 
 // ClusterUrlMonitorList contains a list of ClusterUrlMonitor
@@ -0,0 +1,146 @@
+package v1alpha1
+
+import (
+	"github.com/openshift/route-monitor-operator/api/v1beta1"
+)
+
+// PortAnnotation preserves a v1alpha1 port which cannot be represented by the port number of v1beta1,
+// so it survives the round trip through the storage version
+const PortAnnotation = "monitoring.openshift.io/v1alpha1-port"
+
+// The structs without nested types of this package have the same fields in both versions and are converted
+// as a whole, the remaining ones are converted field by field
+
+func convertSloSpecTo(src SloSpec) v1beta1.SloSpec {
+	dst := v1beta1.SloSpec{
+		TargetAvailabilityPercent: v1beta1.Percent(src.TargetAvailabilityPercent),
+		Window:                    src.Window,
+	}
+	if src.Alerting != nil {
+		dst.Alerting = &v1beta1.SloAlertingSpec{}
+		for _, window := range src.Alerting.BurnRateWindows {
+			dst.Alerting.BurnRateWindows = append(dst.Alerting.BurnRateWindows, v1beta1.BurnRateWindow(window))
+		}
+	}
+	if src.Latency != nil {
+		dst.Latency = &v1beta1.LatencySloSpec{
+			TargetPercent: v1beta1.Percent(src.Latency.TargetPercent),
+			Threshold:     src.Latency.Threshold,
+			Phase:         src.Latency.Phase,
+		}
+	}
+	return dst
+}
+
+func convertSloSpecFrom(src v1beta1.SloSpec) SloSpec {
+	dst := SloSpec{
+		TargetAvailabilityPercent: string(src.TargetAvailabilityPercent),
+		Window:                    src.Window,
+	}
+	if src.Alerting != nil {
+		dst.Alerting = &SloAlertingSpec{}
+		for _, window := range src.Alerting.BurnRateWindows {
+			dst.Alerting.BurnRateWindows = append(dst.Alerting.BurnRateWindows, BurnRateWindow(window))
+		}
+	}
+	if src.Latency != nil {
+		dst.Latency = &LatencySloSpec{
+			TargetPercent: string(src.Latency.TargetPercent),
+			Threshold:     src.Latency.Threshold,
+			Phase:         src.Latency.Phase,
+		}
+	}
+	return dst
+}
+
+func convertProbeSpecTo(src ProbeSpec) v1beta1.ProbeSpec {
+	return v1beta1.ProbeSpec{
+		Type:   src.Type,
+		Target: src.Target,
+		DNS:    (*v1beta1.DNSProbeSpec)(src.DNS),
+		GRPC:   (*v1beta1.GRPCProbeSpec)(src.GRPC),
+		HTTP:   (*v1beta1.HTTPProbeSpec)(src.HTTP),
+		Auth:   (*v1beta1.ProbeAuthSpec)(src.Auth),
+	}
+}
+
+func convertProbeSpecFrom(src v1beta1.ProbeSpec) ProbeSpec {
+	return ProbeSpec{
+		Type:   src.Type,
+		Target: src.Target,
+		DNS:    (*DNSProbeSpec)(src.DNS),
+		GRPC:   (*GRPCProbeSpec)(src.GRPC),
+		HTTP:   (*HTTPProbeSpec)(src.HTTP),
+		Auth:   (*ProbeAuthSpec)(src.Auth),
+	}
+}
+
+func convertProbeLocationsSpecTo(src *ProbeLocationsSpec) *v1beta1.ProbeLocationsSpec {
+	if src == nil {
+		return nil
+	}
+	dst := &v1beta1.ProbeLocationsSpec{Quorum: src.Quorum}
+	for _, location := range src.Locations {
+		dst.Locations = append(dst.Locations, v1beta1.ProbeLocation(location))
+	}
+	return dst
+}
+
+func convertProbeLocationsSpecFrom(src *v1beta1.ProbeLocationsSpec) *ProbeLocationsSpec {
+	if src == nil {
+		return nil
+	}
+	dst := &ProbeLocationsSpec{Quorum: src.Quorum}
+	for _, location := range src.Locations {
+		dst.Locations = append(dst.Locations, ProbeLocation(location))
+	}
+	return dst
+}
+
+func convertMaintenanceWindowsTo(src []MaintenanceWindow) []v1beta1.MaintenanceWindow {
+	var dst []v1beta1.MaintenanceWindow
+	for _, window := range src {
+		dst = append(dst, v1beta1.MaintenanceWindow(window))
+	}
+	return dst
+}
+
+func convertMaintenanceWindowsFrom(src []v1beta1.MaintenanceWindow) []MaintenanceWindow {
+	var dst []MaintenanceWindow
+	for _, window := range src {
+		dst = append(dst, MaintenanceWindow(window))
+	}
+	return dst
+}
+
+// setPortAnnotation records the v1alpha1 port on the converted object if it was lost in the conversion.
+// The annotations are modified in place, so they have to be copied from the source object
+func setPortAnnotation(annotations map[string]string, port string, lost bool) map[string]string {
+	if !lost {
+		if _, ok := annotations[PortAnnotation]; ok {
+			delete(annotations, PortAnnotation)
+			if len(annotations) == 0 {
+				return nil
+			}
+		}
+		return annotations
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[PortAnnotation] = port
+	return annotations
+}
+
+// popPortAnnotation returns the v1alpha1 port recorded on the converted object and removes the annotation
+func popPortAnnotation(annotations map[string]string) (map[string]string, string, bool) {
+	port, ok := annotations[PortAnnotation]
+	if !ok {
+		return annotations, "", false
+	}
+	delete(annotations, PortAnnotation)
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	return annotations, port, true
+}
@@ -0,0 +1,149 @@
+package v1alpha1_test
+
+import (
+	fuzz "github.com/google/gofuzz"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	"github.com/openshift/route-monitor-operator/api/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Conversion", func() {
+	f := fuzz.New().NilChance(0.2).NumElements(0, 3)
+
+	Describe("RouteMonitor", func() {
+		It("converts the typed fields", func() {
+			routeMonitor := v1alpha1.RouteMonitor{
+				ObjectMeta: metav1.ObjectMeta{Name: "monitor", Namespace: "namespace"},
+				Spec: v1alpha1.RouteMonitorSpec{
+					Route:              v1alpha1.RouteMonitorRouteSpec{Name: "route", Namespace: "namespace", Port: 8443},
+					Slo:                v1alpha1.SloSpec{TargetAvailabilityPercent: "99.5"},
+					SkipPrometheusRule: true,
+					ServiceMonitorType: v1alpha1.ServiceMonitorTypeRHOBS,
+				},
+			}
+			hub := v1beta1.RouteMonitor{}
+			Expect(routeMonitor.ConvertTo(&hub)).To(Succeed())
+			Expect(hub.Spec.Route.Port).To(Equal(int32(8443)))
+			Expect(hub.Spec.Slo.TargetAvailabilityPercent).To(Equal(v1beta1.Percent("99.5")))
+			Expect(hub.Spec.CreatePrometheusRule).To(BeFalse())
+			Expect(hub.Spec.ServiceMonitorType).To(Equal(v1beta1.ServiceMonitorTypeRHOBS))
+			Expect(hub.Annotations).NotTo(HaveKey(v1alpha1.PortAnnotation))
+		})
+
+		It("keeps a port beyond the range of v1beta1 in an annotation", func() {
+			routeMonitor := v1alpha1.RouteMonitor{Spec: v1alpha1.RouteMonitorSpec{Route: v1alpha1.RouteMonitorRouteSpec{Port: 1 << 40}}}
+			hub := v1beta1.RouteMonitor{}
+			Expect(routeMonitor.ConvertTo(&hub)).To(Succeed())
+			Expect(hub.Spec.Route.Port).To(BeZero())
+			Expect(hub.Annotations).To(HaveKeyWithValue(v1alpha1.PortAnnotation, "1099511627776"))
+			Expect(routeMonitor.Annotations).To(BeNil())
+
+			converted := v1alpha1.RouteMonitor{}
+			Expect(converted.ConvertFrom(&hub)).To(Succeed())
+			Expect(converted.Spec.Route.Port).To(Equal(int64(1 << 40)))
+			Expect(converted.Annotations).NotTo(HaveKey(v1alpha1.PortAnnotation))
+		})
+
+		It("round trips v1alpha1 objects", func() {
+			for i := 0; i < 200; i++ {
+				routeMonitor := v1alpha1.RouteMonitor{}
+				f.Fuzz(&routeMonitor)
+				// the conversion webhook sets the TypeMeta of the converted object
+				routeMonitor.TypeMeta = metav1.TypeMeta{}
+				delete(routeMonitor.Annotations, v1alpha1.PortAnnotation)
+
+				hub := v1beta1.RouteMonitor{}
+				Expect(routeMonitor.DeepCopy().ConvertTo(&hub)).To(Succeed())
+				converted := v1alpha1.RouteMonitor{}
+				Expect(converted.ConvertFrom(&hub)).To(Succeed())
+				Expect(equality.Semantic.DeepEqual(converted, routeMonitor)).To(BeTrue(), "%+v != %+v", converted, routeMonitor)
+			}
+		})
+
+		It("round trips v1beta1 objects", func() {
+			for i := 0; i < 200; i++ {
+				hub := v1beta1.RouteMonitor{}
+				f.Fuzz(&hub)
+				// the conversion webhook sets the TypeMeta of the converted object
+				hub.TypeMeta = metav1.TypeMeta{}
+				delete(hub.Annotations, v1alpha1.PortAnnotation)
+
+				routeMonitor := v1alpha1.RouteMonitor{}
+				Expect(routeMonitor.ConvertFrom(hub.DeepCopy())).To(Succeed())
+				converted := v1beta1.RouteMonitor{}
+				Expect(routeMonitor.ConvertTo(&converted)).To(Succeed())
+				Expect(equality.Semantic.DeepEqual(converted, hub)).To(BeTrue(), "%+v != %+v", converted, hub)
+			}
+		})
+	})
+
+	Describe("ClusterUrlMonitor", func() {
+		It("converts the port to a number", func() {
+			clusterUrlMonitor := v1alpha1.ClusterUrlMonitor{Spec: v1alpha1.ClusterUrlMonitorSpec{Port: "6443", DomainRef: v1alpha1.ClusterDomainRefHCP}}
+			hub := v1beta1.ClusterUrlMonitor{}
+			Expect(clusterUrlMonitor.ConvertTo(&hub)).To(Succeed())
+			Expect(hub.Spec.Port).To(Equal(int32(6443)))
+			Expect(hub.Spec.DomainRef).To(Equal(v1beta1.ClusterDomainRefHCP))
+			Expect(hub.Spec.CreatePrometheusRule).To(BeTrue())
+		})
+
+		It("keeps a port which is not a number in an annotation", func() {
+			clusterUrlMonitor := v1alpha1.ClusterUrlMonitor{Spec: v1alpha1.ClusterUrlMonitorSpec{Port: "https"}}
+			hub := v1beta1.ClusterUrlMonitor{}
+			Expect(clusterUrlMonitor.ConvertTo(&hub)).To(Succeed())
+			Expect(hub.Spec.Port).To(BeZero())
+			Expect(hub.Annotations).To(HaveKeyWithValue(v1alpha1.PortAnnotation, "https"))
+
+			converted := v1alpha1.ClusterUrlMonitor{}
+			Expect(converted.ConvertFrom(&hub)).To(Succeed())
+			Expect(converted.Spec.Port).To(Equal("https"))
+			Expect(converted.Annotations).To(BeNil())
+		})
+
+		It("prefers a port set through v1beta1 over the annotation", func() {
+			hub := v1beta1.ClusterUrlMonitor{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{v1alpha1.PortAnnotation: "https"}},
+				Spec:       v1beta1.ClusterUrlMonitorSpec{Port: 443},
+			}
+			converted := v1alpha1.ClusterUrlMonitor{}
+			Expect(converted.ConvertFrom(&hub)).To(Succeed())
+			Expect(converted.Spec.Port).To(Equal("443"))
+		})
+
+		It("round trips v1alpha1 objects", func() {
+			for i := 0; i < 200; i++ {
+				clusterUrlMonitor := v1alpha1.ClusterUrlMonitor{}
+				f.Fuzz(&clusterUrlMonitor)
+				// the conversion webhook sets the TypeMeta of the converted object
+				clusterUrlMonitor.TypeMeta = metav1.TypeMeta{}
+				delete(clusterUrlMonitor.Annotations, v1alpha1.PortAnnotation)
+
+				hub := v1beta1.ClusterUrlMonitor{}
+				Expect(clusterUrlMonitor.DeepCopy().ConvertTo(&hub)).To(Succeed())
+				converted := v1alpha1.ClusterUrlMonitor{}
+				Expect(converted.ConvertFrom(&hub)).To(Succeed())
+				Expect(equality.Semantic.DeepEqual(converted, clusterUrlMonitor)).To(BeTrue(), "%+v != %+v", converted, clusterUrlMonitor)
+			}
+		})
+
+		It("round trips v1beta1 objects", func() {
+			for i := 0; i < 200; i++ {
+				hub := v1beta1.ClusterUrlMonitor{}
+				f.Fuzz(&hub)
+				// the conversion webhook sets the TypeMeta of the converted object
+				hub.TypeMeta = metav1.TypeMeta{}
+				delete(hub.Annotations, v1alpha1.PortAnnotation)
+
+				clusterUrlMonitor := v1alpha1.ClusterUrlMonitor{}
+				Expect(clusterUrlMonitor.ConvertFrom(hub.DeepCopy())).To(Succeed())
+				converted := v1beta1.ClusterUrlMonitor{}
+				Expect(clusterUrlMonitor.ConvertTo(&converted)).To(Succeed())
+				Expect(equality.Semantic.DeepEqual(converted, hub)).To(BeTrue(), "%+v != %+v", converted, hub)
+			}
+		})
+	})
+})
@@ -0,0 +1,118 @@
+package v1alpha1
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/openshift/route-monitor-operator/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+var _ conversion.Convertible = &RouteMonitor{}
+
+// ConvertTo converts the RouteMonitor to the v1beta1 storage version
+func (src *RouteMonitor) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.RouteMonitor)
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+
+	// ports beyond the range of v1beta1 were never valid, they are kept for the way back only
+	lost := src.Spec.Route.Port < math.MinInt32 || src.Spec.Route.Port > math.MaxInt32
+	dst.Annotations = setPortAnnotation(dst.Annotations, strconv.FormatInt(src.Spec.Route.Port, 10), lost)
+	port := int32(0)
+	if !lost {
+		port = int32(src.Spec.Route.Port)
+	}
+
+	dst.Spec = v1beta1.RouteMonitorSpec{
+		Route: v1beta1.RouteMonitorRouteSpec{
+			Name:      src.Spec.Route.Name,
+			Namespace: src.Spec.Route.Namespace,
+			Port:      port,
+			Suffix:    src.Spec.Route.Suffix,
+		},
+		Slo:                   convertSloSpecTo(src.Spec.Slo),
+		Probe:                 convertProbeSpecTo(src.Spec.Probe),
+		CertificateExpiry:     (*v1beta1.CertificateExpirySpec)(src.Spec.CertificateExpiry),
+		ProbeLocations:        convertProbeLocationsSpecTo(src.Spec.ProbeLocations),
+		MaintenanceWindows:    convertMaintenanceWindowsTo(src.Spec.MaintenanceWindows),
+		CreatePrometheusRule:  !src.Spec.SkipPrometheusRule,
+		InsecureSkipTLSVerify: src.Spec.InsecureSkipTLSVerify,
+		ServiceMonitorType:    v1beta1.ServiceMonitorType(src.Spec.ServiceMonitorType),
+		BlackboxExporterPool:  src.Spec.BlackboxExporterPool,
+	}
+	if src.Spec.Route.IngressSelector != nil {
+		dst.Spec.Route.IngressSelector = &v1beta1.RouteIngressSelector{
+			Mode:       v1beta1.IngressSelectorMode(src.Spec.Route.IngressSelector.Mode),
+			RouterName: src.Spec.Route.IngressSelector.RouterName,
+		}
+	}
+
+	dst.Status = v1beta1.RouteMonitorStatus{
+		RouteURL:                src.Status.RouteURL,
+		ServiceMonitorRef:       v1beta1.NamespacedName(src.Status.ServiceMonitorRef),
+		PrometheusRuleRef:       v1beta1.NamespacedName(src.Status.PrometheusRuleRef),
+		ErrorStatus:             src.Status.ErrorStatus,
+		ProbeStatus:             (*v1beta1.ProbeStatus)(src.Status.ProbeStatus),
+		ActiveMaintenanceWindow: (*v1beta1.ActiveMaintenanceWindow)(src.Status.ActiveMaintenanceWindow),
+		ObservedGeneration:      src.Status.ObservedGeneration,
+		Conditions:              src.Status.Conditions,
+	}
+	for _, ingressURL := range src.Status.IngressURLs {
+		dst.Status.IngressURLs = append(dst.Status.IngressURLs, v1beta1.IngressURL(ingressURL))
+	}
+	return nil
+}
+
+// ConvertFrom converts the RouteMonitor from the v1beta1 storage version
+func (dst *RouteMonitor) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.RouteMonitor)
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+
+	var alphaPort string
+	var lost bool
+	dst.Annotations, alphaPort, lost = popPortAnnotation(dst.Annotations)
+	port := int64(src.Spec.Route.Port)
+	// the recorded port is only restored as long as the port was not set through v1beta1
+	if lost && port == 0 {
+		port, _ = strconv.ParseInt(alphaPort, 10, 64)
+	}
+
+	dst.Spec = RouteMonitorSpec{
+		Route: RouteMonitorRouteSpec{
+			Name:      src.Spec.Route.Name,
+			Namespace: src.Spec.Route.Namespace,
+			Port:      port,
+			Suffix:    src.Spec.Route.Suffix,
+		},
+		Slo:                   convertSloSpecFrom(src.Spec.Slo),
+		Probe:                 convertProbeSpecFrom(src.Spec.Probe),
+		CertificateExpiry:     (*CertificateExpirySpec)(src.Spec.CertificateExpiry),
+		ProbeLocations:        convertProbeLocationsSpecFrom(src.Spec.ProbeLocations),
+		MaintenanceWindows:    convertMaintenanceWindowsFrom(src.Spec.MaintenanceWindows),
+		SkipPrometheusRule:    !src.Spec.CreatePrometheusRule,
+		InsecureSkipTLSVerify: src.Spec.InsecureSkipTLSVerify,
+		ServiceMonitorType:    string(src.Spec.ServiceMonitorType),
+		BlackboxExporterPool:  src.Spec.BlackboxExporterPool,
+	}
+	if src.Spec.Route.IngressSelector != nil {
+		dst.Spec.Route.IngressSelector = &RouteIngressSelector{
+			Mode:       string(src.Spec.Route.IngressSelector.Mode),
+			RouterName: src.Spec.Route.IngressSelector.RouterName,
+		}
+	}
+
+	dst.Status = RouteMonitorStatus{
+		RouteURL:                src.Status.RouteURL,
+		ServiceMonitorRef:       NamespacedName(src.Status.ServiceMonitorRef),
+		PrometheusRuleRef:       NamespacedName(src.Status.PrometheusRuleRef),
+		ErrorStatus:             src.Status.ErrorStatus,
+		ProbeStatus:             (*ProbeStatus)(src.Status.ProbeStatus),
+		ActiveMaintenanceWindow: (*ActiveMaintenanceWindow)(src.Status.ActiveMaintenanceWindow),
+		ObservedGeneration:      src.Status.ObservedGeneration,
+		Conditions:              src.Status.Conditions,
+	}
+	for _, ingressURL := range src.Status.IngressURLs {
+		dst.Status.IngressURLs = append(dst.Status.IngressURLs, IngressURL(ingressURL))
+	}
+	return nil
+}
@@ -25,6 +25,30 @@ type RouteMonitorSpec struct {
 	Route RouteMonitorRouteSpec `json:"route,omitempty"`
 	Slo   SloSpec               `json:"slo,omitempty"`
 
+	// +kubebuilder:validation:Optional
+
+	// Probe customizes how the blackbox exporter probes the route
+	Probe ProbeSpec `json:"probe,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// CertificateExpiry adds alerts firing before the certificate presented by the route expires
+	CertificateExpiry *CertificateExpirySpec `json:"certificateExpiry,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// ProbeLocations probes the route from several vantage points, the alerts only fire once the quorum of
+	// locations observes the failure. The blackbox exporter of the monitor is the only location when absent
+	ProbeLocations *ProbeLocationsSpec `json:"probeLocations,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+
+	// MaintenanceWindows suppress the burn rate alerts of the route, e.g. during planned upgrades.
+	// The probes keep running, so the probe status covers the windows as well
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
 	// +kubebuilder:default:false
 	// +kubebuilder:validation:Optional
 
@@ -45,6 +69,12 @@ type RouteMonitorSpec struct {
 
 	// ServiceMonitorType dictates the type of ServiceMonitor the RouteMonitor should create
 	ServiceMonitorType string `json:"serviceMonitorType,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// BlackboxExporterPool is the name of the BlackboxExporterPool whose exporter probes the route,
+	// the default blackbox exporter probes it when empty
+	BlackboxExporterPool string `json:"blackboxExporterPool,omitempty"`
 }
 
 const (
@@ -70,19 +100,79 @@ type RouteMonitorRouteSpec struct {
 
 	// Suffix optionally defines the path we should probe (/livez /readyz etc)
 	Suffix string `json:"suffix,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// IngressSelector selects which ingresses of the Route are probed, by default the first one
+	IngressSelector *RouteIngressSelector `json:"ingressSelector,omitempty"`
+}
+
+const (
+	// IngressSelectorModeFirst probes the first ingress of the Route
+	IngressSelectorModeFirst = "first"
+	// IngressSelectorModeByRouterName probes the ingress admitted by the router named in the selector
+	IngressSelectorModeByRouterName = "byRouterName"
+	// IngressSelectorModeAll probes every admitted ingress of the Route as a separate target
+	IngressSelectorModeAll = "all"
+)
+
+// +kubebuilder:validation:XValidation:rule="self.mode != 'byRouterName' || has(self.routerName)",message="routerName is required for the byRouterName mode"
+
+// RouteIngressSelector selects the ingresses of a Route admitted by several IngressControllers
+type RouteIngressSelector struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=first;byRouterName;all
+	// +kubebuilder:default=first
+
+	// Mode is first to probe the first ingress, byRouterName to probe the ingress admitted by RouterName
+	// and all to probe every admitted ingress as a separate target labeled with its router
+	Mode string `json:"mode,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// RouterName is the name of the router, i.e. the IngressController, whose ingress is probed in the byRouterName mode
+	RouterName string `json:"routerName,omitempty"`
+}
+
+// IngressURL is the url extracted from an admitted ingress of the Route
+type IngressURL struct {
+	// RouterName is the name of the router which admitted the ingress
+	RouterName string `json:"routerName"`
+	// URL is the url extracted from the ingress
+	URL string `json:"url"`
 }
 
 // RouteMonitorStatus defines the observed state of RouteMonitor
 type RouteMonitorStatus struct {
 	// RouteURL is the url extracted from the Route resource
-	RouteURL          string         `json:"routeURL,omitempty"`
+	RouteURL string `json:"routeURL,omitempty"`
+	// IngressURLs are the urls of every admitted ingress of the Route, they are only set in the all ingressSelector mode.
+	// RouteURL is the url of the first one then
+	IngressURLs       []IngressURL   `json:"ingressURLs,omitempty"`
 	ServiceMonitorRef NamespacedName `json:"serviceMonitorRef,omitempty"`
 	PrometheusRuleRef NamespacedName `json:"prometheusRuleRef,omitempty"`
 	ErrorStatus       string         `json:"errorStatus,omitempty"`
+	// +optional
+	// ProbeStatus is the health of the probes queried from Prometheus, it is only set when the operator is
+	// configured with a Prometheus or Thanos Querier endpoint
+	ProbeStatus *ProbeStatus `json:"probeStatus,omitempty"`
+	// +optional
+	// ActiveMaintenanceWindow is the maintenance window the alerts are currently suppressed by
+	ActiveMaintenanceWindow *ActiveMaintenanceWindow `json:"activeMaintenanceWindow,omitempty"`
+	// ObservedGeneration is the generation of the spec the conditions were last set for
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// Conditions report the progress of the reconciliation, Ready is true once the probes and alerts are in place
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Maintenance",type=string,JSONPath=`.status.activeMaintenanceWindow.name`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // RouteMonitor is the Schema for the routemonitors API
 type RouteMonitor struct {
@@ -93,6 +183,17 @@ type RouteMonitor struct {
 	Status RouteMonitorStatus `json:"status,omitempty"`
 }
 
+// GetConditions returns the conditions of the RouteMonitor
+func (m *RouteMonitor) GetConditions() []metav1.Condition {
+	return m.Status.Conditions
+}
+
+// SetConditions sets the conditions of the RouteMonitor, observed for its current generation
+func (m *RouteMonitor) SetConditions(conditions []metav1.Condition) {
+	m.Status.Conditions = conditions
+	m.Status.ObservedGeneration = m.Generation
+}
+
 // +kubebuilder:object:root=true
 
 // RouteMonitorList contains a list of RouteMonitor
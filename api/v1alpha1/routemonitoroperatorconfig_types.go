@@ -0,0 +1,218 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RouteMonitorOperatorConfigName is the name of the RouteMonitorOperatorConfig read by the operator
+const RouteMonitorOperatorConfigName = "cluster"
+
+// ConfigSource is where an effective configuration value was taken from
+type ConfigSource string
+
+const (
+	// ConfigSourceFlag is used for values of command-line flags, including their defaults
+	ConfigSourceFlag ConfigSource = "Flag"
+	// ConfigSourceConfigMap is used for values of the deprecated route-monitor-operator-config ConfigMap
+	ConfigSourceConfigMap ConfigSource = "ConfigMap"
+	// ConfigSourceRouteMonitorOperatorConfig is used for values of the spec of the RouteMonitorOperatorConfig
+	ConfigSourceRouteMonitorOperatorConfig ConfigSource = "RouteMonitorOperatorConfig"
+)
+
+// SecretKeyReference references a key of a Secret in the namespace of the operator
+type SecretKeyReference struct {
+	// Name of the Secret
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Key of the Secret holding the value
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+}
+
+// RouteMonitorOperatorConfigSpec defines the operator-wide configuration.
+// Every field overrides the corresponding command-line flag of the operator when set
+type RouteMonitorOperatorConfigSpec struct {
+	// ProbeAPIURL is the RHOBS synthetics API URL the probes of HostedControlPlanes are managed at
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^https?://[^/]+`
+	ProbeAPIURL string `json:"probeAPIURL,omitempty"`
+
+	// ProbeTenant is the RHOBS tenant of the probes
+	// +kubebuilder:validation:Optional
+	ProbeTenant string `json:"probeTenant,omitempty"`
+
+	// OIDCClientID is the client ID authenticating against the RHOBS API
+	// +kubebuilder:validation:Optional
+	OIDCClientID string `json:"oidcClientID,omitempty"`
+
+	// OIDCClientSecretRef references the client secret authenticating against the RHOBS API
+	// +kubebuilder:validation:Optional
+	OIDCClientSecretRef *SecretKeyReference `json:"oidcClientSecretRef,omitempty"`
+
+	// OIDCIssuerURL is the issuer of the tokens authenticating against the RHOBS API
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^https?://[^/]+`
+	OIDCIssuerURL string `json:"oidcIssuerURL,omitempty"`
+
+	// OnlyPublicClusters only creates RHOBS probes for public HostedClusters
+	// +kubebuilder:validation:Optional
+	OnlyPublicClusters *bool `json:"onlyPublicClusters,omitempty"`
+
+	// SkipInfrastructureHealthCheck skips the HostedControlPlane and VPC endpoint health checks, e.g. in test environments
+	// +kubebuilder:validation:Optional
+	SkipInfrastructureHealthCheck *bool `json:"skipInfrastructureHealthCheck,omitempty"`
+
+	// ReconcileInterval is how often every HostedControlPlane is reconciled, at least 10s
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('10s')",message="must be at least 10s"
+	ReconcileInterval *metav1.Duration `json:"reconcileInterval,omitempty"`
+
+	// DynatraceEnabled enables the Dynatrace synthetic monitoring of HostedControlPlanes
+	// +kubebuilder:validation:Optional
+	DynatraceEnabled *bool `json:"dynatraceEnabled,omitempty"`
+
+	// PrometheusURL is the Prometheus or Thanos Querier the probe status of monitors using monitoring.coreos.com
+	// ServiceMonitors is queried from. Changes are picked up on the next reconcile of the monitors
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^https?://[^/]+`
+	PrometheusURL string `json:"prometheusURL,omitempty"`
+
+	// RHOBSPrometheusURL is the Prometheus or Thanos Querier the probe status of monitors using monitoring.rhobs
+	// ServiceMonitors is queried from. Changes are picked up on the next reconcile of the monitors
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^https?://[^/]+`
+	RHOBSPrometheusURL string `json:"rhobsPrometheusURL,omitempty"`
+
+	// BlackboxExporter configures the pods of the blackbox exporter probing the RouteMonitors and ClusterUrlMonitors.
+	// Changes are rolled out to the running exporter
+	// +kubebuilder:validation:Optional
+	BlackboxExporter *BlackboxExporterConfig `json:"blackboxExporter,omitempty"`
+}
+
+// BlackboxExporterConfig configures the scheduling, the network and the security of the blackbox exporter pods.
+// Unset fields keep the defaults of the operator
+type BlackboxExporterConfig struct {
+	// Resources of the blackbox exporter container, by default only requests are set
+	// +kubebuilder:validation:Optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// NodeSelector places the pods on the matching nodes. It replaces the default preference for infra nodes,
+	// or control plane nodes on clusters with a private network load balancer, along with its toleration
+	// +kubebuilder:validation:Optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations are added to the tolerations of the pods
+	// +kubebuilder:validation:Optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// PriorityClassName of the pods
+	// +kubebuilder:validation:Optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// SecurityContext of the pods. The net.ipv4.ping_group_range sysctl the icmp prober relies on is added unless it is set
+	// +kubebuilder:validation:Optional
+	SecurityContext *corev1.PodSecurityContext `json:"securityContext,omitempty"`
+
+	// ContainerSecurityContext of the blackbox exporter container, by default privilege escalation is disallowed,
+	// all capabilities are dropped and the root filesystem is read-only
+	// +kubebuilder:validation:Optional
+	ContainerSecurityContext *corev1.SecurityContext `json:"containerSecurityContext,omitempty"`
+
+	// ImagePullSecrets in the blackbox exporter namespace used to pull the blackbox exporter image
+	// +kubebuilder:validation:Optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// ServiceAccountName the pods run as, route-monitor-operator-system by default
+	// +kubebuilder:validation:Optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// HostNetwork runs the pods in the network of their nodes, so the probes originate from the nodes.
+	// The port of the exporter has to be free on the nodes
+	// +kubebuilder:validation:Optional
+	HostNetwork bool `json:"hostNetwork,omitempty"`
+
+	// DNSPolicy of the pods, ClusterFirstWithHostNet for pods in the network of their nodes by default
+	// +kubebuilder:validation:Optional
+	DNSPolicy corev1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// DNSConfig of the pods, e.g. the nameservers resolving private zones
+	// +kubebuilder:validation:Optional
+	DNSConfig *corev1.PodDNSConfig `json:"dnsConfig,omitempty"`
+}
+
+// EffectiveConfigValue is the value of a setting in use and where it was taken from
+type EffectiveConfigValue struct {
+	// Name of the setting, the field of the spec
+	Name string `json:"name"`
+	// Value of the setting, omitted for secrets
+	Value string `json:"value,omitempty"`
+	// Source the value was taken from
+	Source ConfigSource `json:"source"`
+}
+
+// RouteMonitorOperatorConfigStatus reports the configuration in use
+type RouteMonitorOperatorConfigStatus struct {
+	// EffectiveValues are the values of all settings in use and their sources
+	EffectiveValues []EffectiveConfigValue `json:"effectiveValues,omitempty"`
+	// Conditions report whether values were rejected, the Degraded condition lists them
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// ObservedGeneration is the generation of the spec the status was last reported for
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// GetConditions returns the conditions of the status
+func (c *RouteMonitorOperatorConfig) GetConditions() []metav1.Condition {
+	return c.Status.Conditions
+}
+
+// SetConditions replaces the conditions of the status
+func (c *RouteMonitorOperatorConfig) SetConditions(conditions []metav1.Condition) {
+	c.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:validation:XValidation:rule="self.metadata.name == 'cluster'",message="the RouteMonitorOperatorConfig has to be named cluster"
+// +kubebuilder:printcolumn:name="Degraded",type=string,JSONPath=`.status.conditions[?(@.type=="Degraded")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// RouteMonitorOperatorConfig is the Schema for the routemonitoroperatorconfigs API.
+// The operator reads the one named cluster, its values are reloaded without restarting the operator
+type RouteMonitorOperatorConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RouteMonitorOperatorConfigSpec   `json:"spec,omitempty"`
+	Status RouteMonitorOperatorConfigStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RouteMonitorOperatorConfigList contains a list of RouteMonitorOperatorConfig
+type RouteMonitorOperatorConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RouteMonitorOperatorConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RouteMonitorOperatorConfig{}, &RouteMonitorOperatorConfigList{})
+}
@@ -0,0 +1,73 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RouteMonitorSetSpec defines the desired state of RouteMonitorSet
+type RouteMonitorSetSpec struct {
+	// +kubebuilder:validation:Optional
+
+	// NamespaceSelector selects the namespaces whose Routes are monitored, all namespaces when empty
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// RouteSelector selects the monitored Routes
+	RouteSelector metav1.LabelSelector `json:"routeSelector"`
+
+	// +kubebuilder:validation:Optional
+
+	// Slo is shared by the RouteMonitors created for the matching Routes
+	Slo SloSpec `json:"slo,omitempty"`
+}
+
+// RouteMonitorSetStatus defines the observed state of RouteMonitorSet
+type RouteMonitorSetStatus struct {
+	// RouteMonitors are the RouteMonitors created for the matching Routes
+	RouteMonitors []NamespacedName `json:"routeMonitors,omitempty"`
+	// ObservedGeneration is the generation of the spec the RouteMonitors were last reconciled for
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// RouteMonitorSet is the Schema for the routemonitorsets API.
+// It creates a RouteMonitor for every Route matching its selectors and deletes it once the Route stops matching
+type RouteMonitorSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RouteMonitorSetSpec   `json:"spec,omitempty"`
+	Status RouteMonitorSetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RouteMonitorSetList contains a list of RouteMonitorSet
+type RouteMonitorSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RouteMonitorSet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RouteMonitorSet{}, &RouteMonitorSetList{})
+}
@@ -21,16 +21,222 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActiveMaintenanceWindow) DeepCopyInto(out *ActiveMaintenanceWindow) {
+	*out = *in
+	in.Start.DeepCopyInto(&out.Start)
+	in.End.DeepCopyInto(&out.End)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActiveMaintenanceWindow.
+func (in *ActiveMaintenanceWindow) DeepCopy() *ActiveMaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(ActiveMaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlackboxExporterConfig) DeepCopyInto(out *BlackboxExporterConfig) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(corev1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ContainerSecurityContext != nil {
+		in, out := &in.ContainerSecurityContext, &out.ContainerSecurityContext
+		*out = new(corev1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlackboxExporterConfig.
+func (in *BlackboxExporterConfig) DeepCopy() *BlackboxExporterConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BlackboxExporterConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlackboxExporterPool) DeepCopyInto(out *BlackboxExporterPool) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlackboxExporterPool.
+func (in *BlackboxExporterPool) DeepCopy() *BlackboxExporterPool {
+	if in == nil {
+		return nil
+	}
+	out := new(BlackboxExporterPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BlackboxExporterPool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlackboxExporterPoolList) DeepCopyInto(out *BlackboxExporterPoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BlackboxExporterPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlackboxExporterPoolList.
+func (in *BlackboxExporterPoolList) DeepCopy() *BlackboxExporterPoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(BlackboxExporterPoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BlackboxExporterPoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlackboxExporterPoolSpec) DeepCopyInto(out *BlackboxExporterPoolSpec) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	in.BlackboxExporterConfig.DeepCopyInto(&out.BlackboxExporterConfig)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlackboxExporterPoolSpec.
+func (in *BlackboxExporterPoolSpec) DeepCopy() *BlackboxExporterPoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BlackboxExporterPoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlackboxExporterPoolStatus) DeepCopyInto(out *BlackboxExporterPoolStatus) {
+	*out = *in
+	out.Exporter = in.Exporter
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlackboxExporterPoolStatus.
+func (in *BlackboxExporterPoolStatus) DeepCopy() *BlackboxExporterPoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BlackboxExporterPoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BurnRateWindow) DeepCopyInto(out *BurnRateWindow) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BurnRateWindow.
+func (in *BurnRateWindow) DeepCopy() *BurnRateWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(BurnRateWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateExpirySpec) DeepCopyInto(out *CertificateExpirySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateExpirySpec.
+func (in *CertificateExpirySpec) DeepCopy() *CertificateExpirySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateExpirySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterUrlMonitor) DeepCopyInto(out *ClusterUrlMonitor) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUrlMonitor.
@@ -86,7 +292,25 @@ func (in *ClusterUrlMonitorList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterUrlMonitorSpec) DeepCopyInto(out *ClusterUrlMonitorSpec) {
 	*out = *in
-	out.Slo = in.Slo
+	in.Slo.DeepCopyInto(&out.Slo)
+	in.Probe.DeepCopyInto(&out.Probe)
+	if in.CertificateExpiry != nil {
+		in, out := &in.CertificateExpiry, &out.CertificateExpiry
+		*out = new(CertificateExpirySpec)
+		**out = **in
+	}
+	if in.ProbeLocations != nil {
+		in, out := &in.ProbeLocations, &out.ProbeLocations
+		*out = new(ProbeLocationsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUrlMonitorSpec.
@@ -104,6 +328,23 @@ func (in *ClusterUrlMonitorStatus) DeepCopyInto(out *ClusterUrlMonitorStatus) {
 	*out = *in
 	out.ServiceMonitorRef = in.ServiceMonitorRef
 	out.PrometheusRuleRef = in.PrometheusRuleRef
+	if in.ProbeStatus != nil {
+		in, out := &in.ProbeStatus, &out.ProbeStatus
+		*out = new(ProbeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ActiveMaintenanceWindow != nil {
+		in, out := &in.ActiveMaintenanceWindow, &out.ActiveMaintenanceWindow
+		*out = new(ActiveMaintenanceWindow)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUrlMonitorStatus.
@@ -116,6 +357,146 @@ func (in *ClusterUrlMonitorStatus) DeepCopy() *ClusterUrlMonitorStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSProbeSpec) DeepCopyInto(out *DNSProbeSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSProbeSpec.
+func (in *DNSProbeSpec) DeepCopy() *DNSProbeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSProbeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EffectiveConfigValue) DeepCopyInto(out *EffectiveConfigValue) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EffectiveConfigValue.
+func (in *EffectiveConfigValue) DeepCopy() *EffectiveConfigValue {
+	if in == nil {
+		return nil
+	}
+	out := new(EffectiveConfigValue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GRPCProbeSpec) DeepCopyInto(out *GRPCProbeSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GRPCProbeSpec.
+func (in *GRPCProbeSpec) DeepCopy() *GRPCProbeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GRPCProbeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPProbeSpec) DeepCopyInto(out *HTTPProbeSpec) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ValidStatusCodes != nil {
+		in, out := &in.ValidStatusCodes, &out.ValidStatusCodes
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailIfBodyMatchesRegexp != nil {
+		in, out := &in.FailIfBodyMatchesRegexp, &out.FailIfBodyMatchesRegexp
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailIfBodyNotMatchesRegexp != nil {
+		in, out := &in.FailIfBodyNotMatchesRegexp, &out.FailIfBodyNotMatchesRegexp
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProbeSpec.
+func (in *HTTPProbeSpec) DeepCopy() *HTTPProbeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPProbeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressURL) DeepCopyInto(out *IngressURL) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressURL.
+func (in *IngressURL) DeepCopy() *IngressURL {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressURL)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LatencySloSpec) DeepCopyInto(out *LatencySloSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LatencySloSpec.
+func (in *LatencySloSpec) DeepCopy() *LatencySloSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LatencySloSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	if in.Start != nil {
+		in, out := &in.Start, &out.Start
+		*out = (*in).DeepCopy()
+	}
+	if in.End != nil {
+		in, out := &in.End, &out.End
+		*out = (*in).DeepCopy()
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NamespacedName) DeepCopyInto(out *NamespacedName) {
 	*out = *in
@@ -131,13 +512,143 @@ func (in *NamespacedName) DeepCopy() *NamespacedName {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeAuthSpec) DeepCopyInto(out *ProbeAuthSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeAuthSpec.
+func (in *ProbeAuthSpec) DeepCopy() *ProbeAuthSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeAuthSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeLocation) DeepCopyInto(out *ProbeLocation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeLocation.
+func (in *ProbeLocation) DeepCopy() *ProbeLocation {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeLocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeLocationsSpec) DeepCopyInto(out *ProbeLocationsSpec) {
+	*out = *in
+	if in.Locations != nil {
+		in, out := &in.Locations, &out.Locations
+		*out = make([]ProbeLocation, len(*in))
+		copy(*out, *in)
+	}
+	if in.Quorum != nil {
+		in, out := &in.Quorum, &out.Quorum
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeLocationsSpec.
+func (in *ProbeLocationsSpec) DeepCopy() *ProbeLocationsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeLocationsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeSpec) DeepCopyInto(out *ProbeSpec) {
+	*out = *in
+	if in.DNS != nil {
+		in, out := &in.DNS, &out.DNS
+		*out = new(DNSProbeSpec)
+		**out = **in
+	}
+	if in.GRPC != nil {
+		in, out := &in.GRPC, &out.GRPC
+		*out = new(GRPCProbeSpec)
+		**out = **in
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(ProbeAuthSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeSpec.
+func (in *ProbeSpec) DeepCopy() *ProbeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeStatus) DeepCopyInto(out *ProbeStatus) {
+	*out = *in
+	if in.LastProbeSuccess != nil {
+		in, out := &in.LastProbeSuccess, &out.LastProbeSuccess
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LastProbeTime != nil {
+		in, out := &in.LastProbeTime, &out.LastProbeTime
+		*out = (*in).DeepCopy()
+	}
+	in.LastQueryTime.DeepCopyInto(&out.LastQueryTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeStatus.
+func (in *ProbeStatus) DeepCopy() *ProbeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteIngressSelector) DeepCopyInto(out *RouteIngressSelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteIngressSelector.
+func (in *RouteIngressSelector) DeepCopy() *RouteIngressSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteIngressSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteMonitor) DeepCopyInto(out *RouteMonitor) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMonitor.
@@ -190,9 +701,145 @@ func (in *RouteMonitorList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMonitorOperatorConfig) DeepCopyInto(out *RouteMonitorOperatorConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMonitorOperatorConfig.
+func (in *RouteMonitorOperatorConfig) DeepCopy() *RouteMonitorOperatorConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMonitorOperatorConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RouteMonitorOperatorConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMonitorOperatorConfigList) DeepCopyInto(out *RouteMonitorOperatorConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RouteMonitorOperatorConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMonitorOperatorConfigList.
+func (in *RouteMonitorOperatorConfigList) DeepCopy() *RouteMonitorOperatorConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMonitorOperatorConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RouteMonitorOperatorConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMonitorOperatorConfigSpec) DeepCopyInto(out *RouteMonitorOperatorConfigSpec) {
+	*out = *in
+	if in.OIDCClientSecretRef != nil {
+		in, out := &in.OIDCClientSecretRef, &out.OIDCClientSecretRef
+		*out = new(SecretKeyReference)
+		**out = **in
+	}
+	if in.OnlyPublicClusters != nil {
+		in, out := &in.OnlyPublicClusters, &out.OnlyPublicClusters
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SkipInfrastructureHealthCheck != nil {
+		in, out := &in.SkipInfrastructureHealthCheck, &out.SkipInfrastructureHealthCheck
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ReconcileInterval != nil {
+		in, out := &in.ReconcileInterval, &out.ReconcileInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.DynatraceEnabled != nil {
+		in, out := &in.DynatraceEnabled, &out.DynatraceEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BlackboxExporter != nil {
+		in, out := &in.BlackboxExporter, &out.BlackboxExporter
+		*out = new(BlackboxExporterConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMonitorOperatorConfigSpec.
+func (in *RouteMonitorOperatorConfigSpec) DeepCopy() *RouteMonitorOperatorConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMonitorOperatorConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMonitorOperatorConfigStatus) DeepCopyInto(out *RouteMonitorOperatorConfigStatus) {
+	*out = *in
+	if in.EffectiveValues != nil {
+		in, out := &in.EffectiveValues, &out.EffectiveValues
+		*out = make([]EffectiveConfigValue, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMonitorOperatorConfigStatus.
+func (in *RouteMonitorOperatorConfigStatus) DeepCopy() *RouteMonitorOperatorConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMonitorOperatorConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteMonitorRouteSpec) DeepCopyInto(out *RouteMonitorRouteSpec) {
 	*out = *in
+	if in.IngressSelector != nil {
+		in, out := &in.IngressSelector, &out.IngressSelector
+		*out = new(RouteIngressSelector)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMonitorRouteSpec.
@@ -205,11 +852,126 @@ func (in *RouteMonitorRouteSpec) DeepCopy() *RouteMonitorRouteSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMonitorSet) DeepCopyInto(out *RouteMonitorSet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMonitorSet.
+func (in *RouteMonitorSet) DeepCopy() *RouteMonitorSet {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMonitorSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RouteMonitorSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMonitorSetList) DeepCopyInto(out *RouteMonitorSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RouteMonitorSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMonitorSetList.
+func (in *RouteMonitorSetList) DeepCopy() *RouteMonitorSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMonitorSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RouteMonitorSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMonitorSetSpec) DeepCopyInto(out *RouteMonitorSetSpec) {
+	*out = *in
+	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
+	in.RouteSelector.DeepCopyInto(&out.RouteSelector)
+	in.Slo.DeepCopyInto(&out.Slo)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMonitorSetSpec.
+func (in *RouteMonitorSetSpec) DeepCopy() *RouteMonitorSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMonitorSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMonitorSetStatus) DeepCopyInto(out *RouteMonitorSetStatus) {
+	*out = *in
+	if in.RouteMonitors != nil {
+		in, out := &in.RouteMonitors, &out.RouteMonitors
+		*out = make([]NamespacedName, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMonitorSetStatus.
+func (in *RouteMonitorSetStatus) DeepCopy() *RouteMonitorSetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMonitorSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteMonitorSpec) DeepCopyInto(out *RouteMonitorSpec) {
 	*out = *in
-	out.Route = in.Route
-	out.Slo = in.Slo
+	in.Route.DeepCopyInto(&out.Route)
+	in.Slo.DeepCopyInto(&out.Slo)
+	in.Probe.DeepCopyInto(&out.Probe)
+	if in.CertificateExpiry != nil {
+		in, out := &in.CertificateExpiry, &out.CertificateExpiry
+		*out = new(CertificateExpirySpec)
+		**out = **in
+	}
+	if in.ProbeLocations != nil {
+		in, out := &in.ProbeLocations, &out.ProbeLocations
+		*out = new(ProbeLocationsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMonitorSpec.
@@ -225,8 +987,30 @@ func (in *RouteMonitorSpec) DeepCopy() *RouteMonitorSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteMonitorStatus) DeepCopyInto(out *RouteMonitorStatus) {
 	*out = *in
+	if in.IngressURLs != nil {
+		in, out := &in.IngressURLs, &out.IngressURLs
+		*out = make([]IngressURL, len(*in))
+		copy(*out, *in)
+	}
 	out.ServiceMonitorRef = in.ServiceMonitorRef
 	out.PrometheusRuleRef = in.PrometheusRuleRef
+	if in.ProbeStatus != nil {
+		in, out := &in.ProbeStatus, &out.ProbeStatus
+		*out = new(ProbeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ActiveMaintenanceWindow != nil {
+		in, out := &in.ActiveMaintenanceWindow, &out.ActiveMaintenanceWindow
+		*out = new(ActiveMaintenanceWindow)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMonitorStatus.
@@ -239,9 +1023,54 @@ func (in *RouteMonitorStatus) DeepCopy() *RouteMonitorStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretKeyReference) DeepCopyInto(out *SecretKeyReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyReference.
+func (in *SecretKeyReference) DeepCopy() *SecretKeyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretKeyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SloAlertingSpec) DeepCopyInto(out *SloAlertingSpec) {
+	*out = *in
+	if in.BurnRateWindows != nil {
+		in, out := &in.BurnRateWindows, &out.BurnRateWindows
+		*out = make([]BurnRateWindow, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SloAlertingSpec.
+func (in *SloAlertingSpec) DeepCopy() *SloAlertingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SloAlertingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SloSpec) DeepCopyInto(out *SloSpec) {
 	*out = *in
+	if in.Alerting != nil {
+		in, out := &in.Alerting, &out.Alerting
+		*out = new(SloAlertingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Latency != nil {
+		in, out := &in.Latency, &out.Latency
+		*out = new(LatencySloSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SloSpec.
@@ -0,0 +1,157 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterUrlMonitorSpec defines the desired state of ClusterUrlMonitor.
+// The probed URL is <prefix><cluster-domain>:<port><suffix>
+type ClusterUrlMonitorSpec struct {
+	// +kubebuilder:validation:Optional
+
+	// Prefix is prepended to the cluster domain, it usually ends with a dot, e.g. api.
+	Prefix string `json:"prefix,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// Suffix is the path appended to the port, it usually starts with a slash, e.g. /livez
+	Suffix string `json:"suffix,omitempty"`
+
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+
+	// Port is the port probed
+	Port int32 `json:"port"`
+
+	// +kubebuilder:validation:Optional
+
+	// Slo defines the objectives alerted on, no alerts are generated for the availability when it is absent
+	Slo SloSpec `json:"slo,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=infra
+
+	// DomainRef selects the object the cluster domain is determined from
+	DomainRef ClusterDomainRef `json:"domainRef,omitempty"`
+
+	// +kubebuilder:default=true
+	// +kubebuilder:validation:Optional
+
+	// CreatePrometheusRule instructs the controller to create the PrometheusRule alerting on the SLO.
+	// It is disabled for alerts that are defined separately, such as for hosted clusters
+	CreatePrometheusRule bool `json:"createPrometheusRule"`
+
+	// +kubebuilder:validation:Optional
+
+	// Probe customizes how the blackbox exporter probes the URL
+	Probe ProbeSpec `json:"probe,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// CertificateExpiry adds alerts firing before the certificate presented by the URL expires
+	CertificateExpiry *CertificateExpirySpec `json:"certificateExpiry,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// ProbeLocations probes the URL from several vantage points, the alerts only fire once the quorum of
+	// locations observes the failure. The blackbox exporter of the monitor is the only location when absent
+	ProbeLocations *ProbeLocationsSpec `json:"probeLocations,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+
+	// MaintenanceWindows suppress the burn rate alerts of the URL, e.g. during planned upgrades.
+	// The probes keep running, so the probe status covers the windows as well
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// BlackboxExporterPool is the name of the BlackboxExporterPool whose exporter probes the URL,
+	// the default blackbox exporter probes it when empty
+	BlackboxExporterPool string `json:"blackboxExporterPool,omitempty"`
+}
+
+// ClusterDomainRef defines the object used determine the cluster's domain
+// By default, 'infra' is used, which references the 'infrastructures/cluster' object
+// +kubebuilder:validation:Enum=infra;hcp
+type ClusterDomainRef string
+
+const (
+	// ClusterDomainRefInfra indicates the clusterDomain should be determined from the 'infrastructures/cluster' object
+	ClusterDomainRefInfra ClusterDomainRef = "infra"
+
+	// ClusterDomainRefHCP indicates the clusterDomain should be determined from the 'hcp/cluster' object in the same namespace as the ClusterURLMonitor being reconciled
+	ClusterDomainRefHCP ClusterDomainRef = "hcp"
+)
+
+// ClusterUrlMonitorStatus defines the observed state of ClusterUrlMonitor
+type ClusterUrlMonitorStatus struct {
+	ServiceMonitorRef NamespacedName `json:"serviceMonitorRef,omitempty"`
+	PrometheusRuleRef NamespacedName `json:"prometheusRuleRef,omitempty"`
+	ErrorStatus       string         `json:"errorStatus,omitempty"`
+	// URL is the probed URL, built from the prefix, the cluster domain, the port and the suffix
+	URL string `json:"url,omitempty"`
+	// +optional
+	// ProbeStatus is the health of the probes queried from Prometheus, it is only set when the operator is
+	// configured with a Prometheus or Thanos Querier endpoint
+	ProbeStatus *ProbeStatus `json:"probeStatus,omitempty"`
+	// +optional
+	// ActiveMaintenanceWindow is the maintenance window the alerts are currently suppressed by
+	ActiveMaintenanceWindow *ActiveMaintenanceWindow `json:"activeMaintenanceWindow,omitempty"`
+	// ObservedGeneration is the generation of the spec the conditions were last set for
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// Conditions report the progress of the reconciliation, Ready is true once the probes and alerts are in place
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Maintenance",type=string,JSONPath=`.status.activeMaintenanceWindow.name`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ClusterUrlMonitor is the Schema for the clusterurlmonitors API
+type ClusterUrlMonitor struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterUrlMonitorSpec   `json:"spec,omitempty"`
+	Status ClusterUrlMonitorStatus `json:"status,omitempty"`
+}
+
+// Hub marks v1beta1 as the version the other versions of the ClusterUrlMonitor are converted to
+func (*ClusterUrlMonitor) Hub() {}
+
+// +kubebuilder:object:root=true
+
+// ClusterUrlMonitorList contains a list of ClusterUrlMonitor
+type ClusterUrlMonitorList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterUrlMonitor `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterUrlMonitor{}, &ClusterUrlMonitorList{})
+}
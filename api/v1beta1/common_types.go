@@ -0,0 +1,312 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NamespacedName contains the name of a object and its namespace
+type NamespacedName struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// ServiceMonitorType is the API group of the ServiceMonitor probing a monitor
+// +kubebuilder:validation:Enum=monitoring.coreos.com;monitoring.rhobs
+type ServiceMonitorType string
+
+const (
+	// ServiceMonitorTypeCoreOS creates monitoring.coreos.com ServiceMonitors, scraped by the cluster monitoring
+	ServiceMonitorTypeCoreOS ServiceMonitorType = "monitoring.coreos.com"
+	// ServiceMonitorTypeRHOBS creates monitoring.rhobs ServiceMonitors, scraped by the observability operator
+	ServiceMonitorTypeRHOBS ServiceMonitorType = "monitoring.rhobs"
+)
+
+// Percent is a decimal percent number, e.g. 99.95
+// +kubebuilder:validation:Pattern=`^[0-9]{1,2}(\.[0-9]+)?$`
+type Percent string
+
+// ProbeSpec defines how the blackbox exporter probes the monitored URL
+type ProbeSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=http;tcp;dns;icmp;grpc
+	// Type is the blackbox exporter prober used, defaults to http.
+	// tcp and grpc connect to the host and port of the URL, icmp pings its host
+	// and dns resolves its host, unless target is set
+	Type string `json:"type,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=261
+	// Target probes another service than the URL: host:port for tcp and grpc, a host for icmp
+	// and the queried name for dns. It cannot be set for http, which always probes the URL
+	Target string `json:"target,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// DNS configures the dns prober
+	DNS *DNSProbeSpec `json:"dns,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// GRPC configures the grpc prober
+	GRPC *GRPCProbeSpec `json:"grpc,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// HTTP customizes the request sent by the http prober and which responses count as success.
+	// When absent, a GET request expecting a 2xx response is sent
+	HTTP *HTTPProbeSpec `json:"http,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Auth references the credentials the probe authenticates with
+	Auth *ProbeAuthSpec `json:"auth,omitempty"`
+}
+
+// ProbeAuthSpec references a Secret holding the credentials of a probe.
+// The Secret is copied to the namespace of the blackbox exporter, so changes to it are picked up by the probes
+type ProbeAuthSpec struct {
+	// +kubebuilder:validation:Enum=bearerToken;basicAuth;clientCertificate
+	// Type is the kind of credentials held by the Secret
+	Type string `json:"type"`
+	// SecretName is the name of a Secret in the namespace of the monitor. It needs the key
+	// "token" for bearerToken, the keys "username" and "password" for basicAuth,
+	// and the keys "tls.crt" and "tls.key" for clientCertificate
+	SecretName string `json:"secretName"`
+}
+
+// DNSProbeSpec defines the DNS query sent by the dns prober
+type DNSProbeSpec struct {
+	// +kubebuilder:validation:Optional
+	// Server is the DNS server queried, as host or host:port. Defaults to the cluster DNS
+	Server string `json:"server,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=A;AAAA;CNAME;MX;NS;SOA;SRV;TXT
+	// QueryType is the type of record queried, defaults to A
+	QueryType string `json:"queryType,omitempty"`
+}
+
+// GRPCProbeSpec defines the health check sent by the grpc prober
+type GRPCProbeSpec struct {
+	// +kubebuilder:validation:Optional
+	// Service is the service name sent with the health check, when empty the overall health of the server is checked
+	Service string `json:"service,omitempty"`
+}
+
+// HTTPProbeSpec defines the request and the expected response of a http probe
+type HTTPProbeSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=GET;HEAD;POST;PUT;PATCH;DELETE;OPTIONS
+	// Method is the HTTP method of the request, defaults to GET
+	Method string `json:"method,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Headers are added to the request, e.g. a custom Host or Content-Type header
+	Headers map[string]string `json:"headers,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Body is sent as the request body
+	Body string `json:"body,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:items:Minimum=100
+	// +kubebuilder:validation:items:Maximum=599
+	// ValidStatusCodes lists the status codes considered successful, defaults to any 2xx
+	ValidStatusCodes []int `json:"validStatusCodes,omitempty"`
+	// +kubebuilder:validation:Optional
+	// FailIfBodyMatchesRegexp fails the probe if the response body matches any of the regular expressions
+	FailIfBodyMatchesRegexp []string `json:"failIfBodyMatchesRegexp,omitempty"`
+	// +kubebuilder:validation:Optional
+	// FailIfBodyNotMatchesRegexp fails the probe if the response body does not match all of the regular expressions
+	FailIfBodyNotMatchesRegexp []string `json:"failIfBodyNotMatchesRegexp,omitempty"`
+}
+
+// SloSpec defines the objectives of a monitor
+type SloSpec struct {
+	// TargetAvailabilityPercent is the share of successful probes, e.g. 99.5. It has to be above 90 and below 100
+	TargetAvailabilityPercent Percent `json:"targetAvailabilityPercent"`
+
+	// +kubebuilder:validation:Optional
+	// Alerting optionally overrides the multiwindow multi-burn-rate alerts generated for this SLO.
+	// When absent, the default four-tier table (1h/5m, 6h/30m, 1d/2h, 3d/6h) is used
+	Alerting *SloAlertingSpec `json:"alerting,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Latency optionally defines a latency objective in addition to the availability objective.
+	// It uses the same burn rate windows as the availability objective
+	Latency *LatencySloSpec `json:"latency,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^([0-9]+(y|w|d|h|m|s|ms))+$`
+	// Window is the compliance period of the objective, the availability and the remaining error budget
+	// reported in the status are computed over it. Defaults to 28d
+	Window string `json:"window,omitempty"`
+}
+
+// ProbeStatus reports the health of the probes of the monitored URL as recorded in Prometheus
+type ProbeStatus struct {
+	// +optional
+	// LastProbeSuccess is the result of the most recent probe, it is absent while Prometheus has no results
+	LastProbeSuccess *bool `json:"lastProbeSuccess,omitempty"`
+	// +optional
+	// LastProbeTime is when the most recent probe result was scraped
+	LastProbeTime *metav1.Time `json:"lastProbeTime,omitempty"`
+	// +optional
+	// Window is the period the availability and the remaining error budget are computed over
+	Window string `json:"window,omitempty"`
+	// +optional
+	// AvailabilityPercent is the share of successful probes over the window
+	AvailabilityPercent string `json:"availabilityPercent,omitempty"`
+	// +optional
+	// RemainingErrorBudgetPercent is the share of the error budget of the SLO left over the window.
+	// It turns negative once the budget is exhausted and is absent for monitors without SLO
+	RemainingErrorBudgetPercent string `json:"remainingErrorBudgetPercent,omitempty"`
+	// LastQueryTime is when Prometheus was last queried, the status is refreshed once per configured interval
+	LastQueryTime metav1.Time `json:"lastQueryTime"`
+}
+
+// CertificateExpirySpec defines alerts on the expiry of the certificate presented to the probe
+type CertificateExpirySpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=30
+	// WarningDays is the number of days before the certificate expires from which a warning alert fires
+	WarningDays int `json:"warningDays,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=7
+	// CriticalDays is the number of days before the certificate expires from which a critical alert fires.
+	// It has to be less than WarningDays
+	CriticalDays int `json:"criticalDays,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="!has(self.quorum) || self.quorum <= size(self.locations)",message="quorum cannot exceed the number of locations"
+// +kubebuilder:validation:XValidation:rule="size(self.locations.filter(l, l.type == 'InCluster')) <= 1",message="only one location can be InCluster"
+
+// ProbeLocationsSpec defines the vantage points a monitor is probed from and how many of them have to agree
+// before the alerts of the monitor fire
+type ProbeLocationsSpec struct {
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=8
+	// Locations the monitor is probed from, every location is scraped separately and its probe metrics carry
+	// the location label
+	Locations []ProbeLocation `json:"locations"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// Quorum is the number of locations which have to observe the failure before an alert fires,
+	// a majority of the locations by default
+	Quorum *int32 `json:"quorum,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="self.type == 'RemoteExporter' ? has(self.url) : !has(self.url)",message="url has to be set for RemoteExporter locations only"
+
+// ProbeLocation is a single vantage point probing the monitor
+type ProbeLocation struct {
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	// Name of the location, the value of the location label of its probe metrics
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Enum=InCluster;RemoteExporter
+	// Type is InCluster for the blackbox exporter of the monitor, or RemoteExporter for a blackbox exporter
+	// running elsewhere, e.g. in another region, which is reachable by the Prometheus scraping the monitor
+	Type string `json:"type"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^https?://[^/]+$`
+	// URL is the scheme, host and port of a RemoteExporter, e.g. https://blackbox.eu-west-1.example.com:9115
+	URL string `json:"url,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Module of a RemoteExporter the probe is requested with, http_2xx by default. The operator does not
+	// manage the configuration of remote exporters, so the module has to be defined there
+	Module string `json:"module,omitempty"`
+}
+
+const (
+	// The following values should match the kubebuilder-enumerated values for the type of a probe location above
+	ProbeLocationInCluster      = "InCluster"
+	ProbeLocationRemoteExporter = "RemoteExporter"
+)
+
+// EffectiveQuorum returns the number of locations which have to observe a failure, a majority when unset
+func (s *ProbeLocationsSpec) EffectiveQuorum() int {
+	if s.Quorum != nil {
+		return int(*s.Quorum)
+	}
+	return len(s.Locations)/2 + 1
+}
+
+// +kubebuilder:validation:XValidation:rule="has(self.schedule) != has(self.start)",message="either start and end or schedule and duration have to be set"
+// +kubebuilder:validation:XValidation:rule="has(self.start) == has(self.end)",message="start and end have to be set together"
+// +kubebuilder:validation:XValidation:rule="has(self.schedule) == has(self.duration)",message="schedule and duration have to be set together"
+// +kubebuilder:validation:XValidation:rule="!has(self.start) || self.end > self.start",message="end has to be after start"
+// +kubebuilder:validation:XValidation:rule="!has(self.duration) || duration(self.duration) > duration('0s')",message="duration has to be positive"
+
+// MaintenanceWindow is a period in which the alerts of a monitor are suppressed, either a one-off time range
+// from start to end or a recurring window of the given duration starting at every time of the schedule
+type MaintenanceWindow struct {
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// Name of the window, reported in the status while the window is active
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Optional
+	// Start of a one-off window
+	Start *metav1.Time `json:"start,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// End of a one-off window
+	End *metav1.Time `json:"end,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Schedule is a cron expression with the five fields minute, hour, day of month, month and day of week,
+	// evaluated in UTC, e.g. "0 2 * * 6" for every Saturday at 02:00. Names of months and days are not supported
+	Schedule string `json:"schedule,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Duration of every window of the schedule, e.g. 2h
+	Duration *metav1.Duration `json:"duration,omitempty"`
+}
+
+// ActiveMaintenanceWindow is the occurrence of a maintenance window which is in progress
+type ActiveMaintenanceWindow struct {
+	// Name of the maintenance window
+	Name string `json:"name"`
+	// Start of the occurrence
+	Start metav1.Time `json:"start"`
+	// End of the occurrence, the alerts are no longer suppressed from then on
+	End metav1.Time `json:"end"`
+}
+
+// LatencySloSpec defines which share of the probes has to finish below a threshold
+type LatencySloSpec struct {
+	// TargetPercent defines the percent of probes which have to finish below the threshold, e.g. 99
+	TargetPercent Percent `json:"targetPercent"`
+	// Threshold is the maximum duration of a probe to count as good, e.g. 800ms
+	Threshold string `json:"threshold"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=resolve;connect;tls;processing;transfer
+	// Phase optionally restricts the objective to a single phase of the HTTP probe, it is only supported by http probes.
+	// When empty, the total probe duration is used
+	Phase string `json:"phase,omitempty"`
+}
+
+// SloAlertingSpec defines the burn rate alerts generated for a SLO
+type SloAlertingSpec struct {
+	// +kubebuilder:validation:MinItems=1
+	// BurnRateWindows lists the window pairs an alert is generated for
+	BurnRateWindows []BurnRateWindow `json:"burnRateWindows"`
+}
+
+// BurnRateWindow defines a single multiwindow burn rate alert
+type BurnRateWindow struct {
+	// LongWindow is the long lookback window of the alert, e.g. 1h
+	LongWindow string `json:"longWindow"`
+	// ShortWindow is the short lookback window of the alert, e.g. 5m. It has to be shorter than LongWindow
+	ShortWindow string `json:"shortWindow"`
+	// BurnRate is the factor of the error budget consumption rate the alert fires at, e.g. 14.4
+	BurnRate string `json:"burnRate"`
+	// +kubebuilder:validation:Optional
+	// For is the duration the condition has to be true before the alert fires
+	For string `json:"for,omitempty"`
+	// +kubebuilder:validation:Enum=critical;warning;info
+	// Severity is the severity label set on the alert
+	Severity string `json:"severity"`
+}
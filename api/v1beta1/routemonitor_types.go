@@ -0,0 +1,207 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RouteMonitorSpec defines the desired state of RouteMonitor
+type RouteMonitorSpec struct {
+	Route RouteMonitorRouteSpec `json:"route"`
+
+	// +kubebuilder:validation:Optional
+
+	// Slo defines the objectives alerted on, no alerts are generated for the availability when it is absent
+	Slo SloSpec `json:"slo,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// Probe customizes how the blackbox exporter probes the route
+	Probe ProbeSpec `json:"probe,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// CertificateExpiry adds alerts firing before the certificate presented by the route expires
+	CertificateExpiry *CertificateExpirySpec `json:"certificateExpiry,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// ProbeLocations probes the route from several vantage points, the alerts only fire once the quorum of
+	// locations observes the failure. The blackbox exporter of the monitor is the only location when absent
+	ProbeLocations *ProbeLocationsSpec `json:"probeLocations,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+
+	// MaintenanceWindows suppress the burn rate alerts of the route, e.g. during planned upgrades.
+	// The probes keep running, so the probe status covers the windows as well
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
+	// +kubebuilder:default=true
+	// +kubebuilder:validation:Optional
+
+	// CreatePrometheusRule instructs the controller to create the PrometheusRule alerting on the SLO.
+	// It is disabled for alerts that are defined separately, such as for hosted clusters
+	CreatePrometheusRule bool `json:"createPrometheusRule"`
+
+	// +kubebuilder:default=false
+	// +kubebuilder:validation:Optional
+
+	// InsecureSkipTLSVerify indicates that the blackbox exporter module used to probe this route
+	// should *not* verify the certificate of the route
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=monitoring.coreos.com
+
+	// ServiceMonitorType dictates the type of ServiceMonitor the RouteMonitor should create
+	ServiceMonitorType ServiceMonitorType `json:"serviceMonitorType,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// BlackboxExporterPool is the name of the BlackboxExporterPool whose exporter probes the route,
+	// the default blackbox exporter probes it when empty
+	BlackboxExporterPool string `json:"blackboxExporterPool,omitempty"`
+}
+
+// RouteMonitorRouteSpec references the observed Route resource
+type RouteMonitorRouteSpec struct {
+	// +kubebuilder:validation:MinLength=1
+
+	// Name is the name of the Route
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:MinLength=1
+
+	// Namespace is the namespace of the Route
+	Namespace string `json:"namespace"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+
+	// Port optionally defines the port we should use while probing
+	Port int32 `json:"port,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// Suffix optionally defines the path we should probe (/livez /readyz etc)
+	Suffix string `json:"suffix,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// IngressSelector selects which ingresses of the Route are probed, by default the first one
+	IngressSelector *RouteIngressSelector `json:"ingressSelector,omitempty"`
+}
+
+// IngressSelectorMode selects which ingresses of a Route are probed
+// +kubebuilder:validation:Enum=first;byRouterName;all
+type IngressSelectorMode string
+
+const (
+	// IngressSelectorModeFirst probes the first ingress of the Route
+	IngressSelectorModeFirst IngressSelectorMode = "first"
+	// IngressSelectorModeByRouterName probes the ingress admitted by the router named in the selector
+	IngressSelectorModeByRouterName IngressSelectorMode = "byRouterName"
+	// IngressSelectorModeAll probes every admitted ingress of the Route as a separate target
+	IngressSelectorModeAll IngressSelectorMode = "all"
+)
+
+// +kubebuilder:validation:XValidation:rule="self.mode != 'byRouterName' || has(self.routerName)",message="routerName is required for the byRouterName mode"
+
+// RouteIngressSelector selects the ingresses of a Route admitted by several IngressControllers
+type RouteIngressSelector struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=first
+
+	// Mode is first to probe the first ingress, byRouterName to probe the ingress admitted by RouterName
+	// and all to probe every admitted ingress as a separate target labeled with its router
+	Mode IngressSelectorMode `json:"mode,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// RouterName is the name of the router, i.e. the IngressController, whose ingress is probed in the byRouterName mode
+	RouterName string `json:"routerName,omitempty"`
+}
+
+// IngressURL is the url extracted from an admitted ingress of the Route
+type IngressURL struct {
+	// RouterName is the name of the router which admitted the ingress
+	RouterName string `json:"routerName"`
+	// URL is the url extracted from the ingress
+	URL string `json:"url"`
+}
+
+// RouteMonitorStatus defines the observed state of RouteMonitor
+type RouteMonitorStatus struct {
+	// RouteURL is the url extracted from the Route resource
+	RouteURL string `json:"routeURL,omitempty"`
+	// IngressURLs are the urls of every admitted ingress of the Route, they are only set in the all ingressSelector mode.
+	// RouteURL is the url of the first one then
+	IngressURLs       []IngressURL   `json:"ingressURLs,omitempty"`
+	ServiceMonitorRef NamespacedName `json:"serviceMonitorRef,omitempty"`
+	PrometheusRuleRef NamespacedName `json:"prometheusRuleRef,omitempty"`
+	ErrorStatus       string         `json:"errorStatus,omitempty"`
+	// +optional
+	// ProbeStatus is the health of the probes queried from Prometheus, it is only set when the operator is
+	// configured with a Prometheus or Thanos Querier endpoint
+	ProbeStatus *ProbeStatus `json:"probeStatus,omitempty"`
+	// +optional
+	// ActiveMaintenanceWindow is the maintenance window the alerts are currently suppressed by
+	ActiveMaintenanceWindow *ActiveMaintenanceWindow `json:"activeMaintenanceWindow,omitempty"`
+	// ObservedGeneration is the generation of the spec the conditions were last set for
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// Conditions report the progress of the reconciliation, Ready is true once the probes and alerts are in place
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Maintenance",type=string,JSONPath=`.status.activeMaintenanceWindow.name`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// RouteMonitor is the Schema for the routemonitors API
+type RouteMonitor struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RouteMonitorSpec   `json:"spec,omitempty"`
+	Status RouteMonitorStatus `json:"status,omitempty"`
+}
+
+// Hub marks v1beta1 as the version the other versions of the RouteMonitor are converted to
+func (*RouteMonitor) Hub() {}
+
+// +kubebuilder:object:root=true
+
+// RouteMonitorList contains a list of RouteMonitor
+type RouteMonitorList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RouteMonitor `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RouteMonitor{}, &RouteMonitorList{})
+}
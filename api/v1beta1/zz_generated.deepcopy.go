@@ -0,0 +1,668 @@
+//go:build !ignore_autogenerated
+
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActiveMaintenanceWindow) DeepCopyInto(out *ActiveMaintenanceWindow) {
+	*out = *in
+	in.Start.DeepCopyInto(&out.Start)
+	in.End.DeepCopyInto(&out.End)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActiveMaintenanceWindow.
+func (in *ActiveMaintenanceWindow) DeepCopy() *ActiveMaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(ActiveMaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BurnRateWindow) DeepCopyInto(out *BurnRateWindow) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BurnRateWindow.
+func (in *BurnRateWindow) DeepCopy() *BurnRateWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(BurnRateWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateExpirySpec) DeepCopyInto(out *CertificateExpirySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateExpirySpec.
+func (in *CertificateExpirySpec) DeepCopy() *CertificateExpirySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateExpirySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUrlMonitor) DeepCopyInto(out *ClusterUrlMonitor) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUrlMonitor.
+func (in *ClusterUrlMonitor) DeepCopy() *ClusterUrlMonitor {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUrlMonitor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterUrlMonitor) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUrlMonitorList) DeepCopyInto(out *ClusterUrlMonitorList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterUrlMonitor, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUrlMonitorList.
+func (in *ClusterUrlMonitorList) DeepCopy() *ClusterUrlMonitorList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUrlMonitorList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterUrlMonitorList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUrlMonitorSpec) DeepCopyInto(out *ClusterUrlMonitorSpec) {
+	*out = *in
+	in.Slo.DeepCopyInto(&out.Slo)
+	in.Probe.DeepCopyInto(&out.Probe)
+	if in.CertificateExpiry != nil {
+		in, out := &in.CertificateExpiry, &out.CertificateExpiry
+		*out = new(CertificateExpirySpec)
+		**out = **in
+	}
+	if in.ProbeLocations != nil {
+		in, out := &in.ProbeLocations, &out.ProbeLocations
+		*out = new(ProbeLocationsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUrlMonitorSpec.
+func (in *ClusterUrlMonitorSpec) DeepCopy() *ClusterUrlMonitorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUrlMonitorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUrlMonitorStatus) DeepCopyInto(out *ClusterUrlMonitorStatus) {
+	*out = *in
+	out.ServiceMonitorRef = in.ServiceMonitorRef
+	out.PrometheusRuleRef = in.PrometheusRuleRef
+	if in.ProbeStatus != nil {
+		in, out := &in.ProbeStatus, &out.ProbeStatus
+		*out = new(ProbeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ActiveMaintenanceWindow != nil {
+		in, out := &in.ActiveMaintenanceWindow, &out.ActiveMaintenanceWindow
+		*out = new(ActiveMaintenanceWindow)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUrlMonitorStatus.
+func (in *ClusterUrlMonitorStatus) DeepCopy() *ClusterUrlMonitorStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUrlMonitorStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSProbeSpec) DeepCopyInto(out *DNSProbeSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSProbeSpec.
+func (in *DNSProbeSpec) DeepCopy() *DNSProbeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSProbeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GRPCProbeSpec) DeepCopyInto(out *GRPCProbeSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GRPCProbeSpec.
+func (in *GRPCProbeSpec) DeepCopy() *GRPCProbeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GRPCProbeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPProbeSpec) DeepCopyInto(out *HTTPProbeSpec) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ValidStatusCodes != nil {
+		in, out := &in.ValidStatusCodes, &out.ValidStatusCodes
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailIfBodyMatchesRegexp != nil {
+		in, out := &in.FailIfBodyMatchesRegexp, &out.FailIfBodyMatchesRegexp
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailIfBodyNotMatchesRegexp != nil {
+		in, out := &in.FailIfBodyNotMatchesRegexp, &out.FailIfBodyNotMatchesRegexp
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProbeSpec.
+func (in *HTTPProbeSpec) DeepCopy() *HTTPProbeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPProbeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressURL) DeepCopyInto(out *IngressURL) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressURL.
+func (in *IngressURL) DeepCopy() *IngressURL {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressURL)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LatencySloSpec) DeepCopyInto(out *LatencySloSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LatencySloSpec.
+func (in *LatencySloSpec) DeepCopy() *LatencySloSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LatencySloSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	if in.Start != nil {
+		in, out := &in.Start, &out.Start
+		*out = (*in).DeepCopy()
+	}
+	if in.End != nil {
+		in, out := &in.End, &out.End
+		*out = (*in).DeepCopy()
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespacedName) DeepCopyInto(out *NamespacedName) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacedName.
+func (in *NamespacedName) DeepCopy() *NamespacedName {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacedName)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeAuthSpec) DeepCopyInto(out *ProbeAuthSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeAuthSpec.
+func (in *ProbeAuthSpec) DeepCopy() *ProbeAuthSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeAuthSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeLocation) DeepCopyInto(out *ProbeLocation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeLocation.
+func (in *ProbeLocation) DeepCopy() *ProbeLocation {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeLocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeLocationsSpec) DeepCopyInto(out *ProbeLocationsSpec) {
+	*out = *in
+	if in.Locations != nil {
+		in, out := &in.Locations, &out.Locations
+		*out = make([]ProbeLocation, len(*in))
+		copy(*out, *in)
+	}
+	if in.Quorum != nil {
+		in, out := &in.Quorum, &out.Quorum
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeLocationsSpec.
+func (in *ProbeLocationsSpec) DeepCopy() *ProbeLocationsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeLocationsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeSpec) DeepCopyInto(out *ProbeSpec) {
+	*out = *in
+	if in.DNS != nil {
+		in, out := &in.DNS, &out.DNS
+		*out = new(DNSProbeSpec)
+		**out = **in
+	}
+	if in.GRPC != nil {
+		in, out := &in.GRPC, &out.GRPC
+		*out = new(GRPCProbeSpec)
+		**out = **in
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(ProbeAuthSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeSpec.
+func (in *ProbeSpec) DeepCopy() *ProbeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeStatus) DeepCopyInto(out *ProbeStatus) {
+	*out = *in
+	if in.LastProbeSuccess != nil {
+		in, out := &in.LastProbeSuccess, &out.LastProbeSuccess
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LastProbeTime != nil {
+		in, out := &in.LastProbeTime, &out.LastProbeTime
+		*out = (*in).DeepCopy()
+	}
+	in.LastQueryTime.DeepCopyInto(&out.LastQueryTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeStatus.
+func (in *ProbeStatus) DeepCopy() *ProbeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteIngressSelector) DeepCopyInto(out *RouteIngressSelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteIngressSelector.
+func (in *RouteIngressSelector) DeepCopy() *RouteIngressSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteIngressSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMonitor) DeepCopyInto(out *RouteMonitor) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMonitor.
+func (in *RouteMonitor) DeepCopy() *RouteMonitor {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMonitor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RouteMonitor) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMonitorList) DeepCopyInto(out *RouteMonitorList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RouteMonitor, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMonitorList.
+func (in *RouteMonitorList) DeepCopy() *RouteMonitorList {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMonitorList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RouteMonitorList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMonitorRouteSpec) DeepCopyInto(out *RouteMonitorRouteSpec) {
+	*out = *in
+	if in.IngressSelector != nil {
+		in, out := &in.IngressSelector, &out.IngressSelector
+		*out = new(RouteIngressSelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMonitorRouteSpec.
+func (in *RouteMonitorRouteSpec) DeepCopy() *RouteMonitorRouteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMonitorRouteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMonitorSpec) DeepCopyInto(out *RouteMonitorSpec) {
+	*out = *in
+	in.Route.DeepCopyInto(&out.Route)
+	in.Slo.DeepCopyInto(&out.Slo)
+	in.Probe.DeepCopyInto(&out.Probe)
+	if in.CertificateExpiry != nil {
+		in, out := &in.CertificateExpiry, &out.CertificateExpiry
+		*out = new(CertificateExpirySpec)
+		**out = **in
+	}
+	if in.ProbeLocations != nil {
+		in, out := &in.ProbeLocations, &out.ProbeLocations
+		*out = new(ProbeLocationsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMonitorSpec.
+func (in *RouteMonitorSpec) DeepCopy() *RouteMonitorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMonitorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMonitorStatus) DeepCopyInto(out *RouteMonitorStatus) {
+	*out = *in
+	if in.IngressURLs != nil {
+		in, out := &in.IngressURLs, &out.IngressURLs
+		*out = make([]IngressURL, len(*in))
+		copy(*out, *in)
+	}
+	out.ServiceMonitorRef = in.ServiceMonitorRef
+	out.PrometheusRuleRef = in.PrometheusRuleRef
+	if in.ProbeStatus != nil {
+		in, out := &in.ProbeStatus, &out.ProbeStatus
+		*out = new(ProbeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ActiveMaintenanceWindow != nil {
+		in, out := &in.ActiveMaintenanceWindow, &out.ActiveMaintenanceWindow
+		*out = new(ActiveMaintenanceWindow)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMonitorStatus.
+func (in *RouteMonitorStatus) DeepCopy() *RouteMonitorStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMonitorStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SloAlertingSpec) DeepCopyInto(out *SloAlertingSpec) {
+	*out = *in
+	if in.BurnRateWindows != nil {
+		in, out := &in.BurnRateWindows, &out.BurnRateWindows
+		*out = make([]BurnRateWindow, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SloAlertingSpec.
+func (in *SloAlertingSpec) DeepCopy() *SloAlertingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SloAlertingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SloSpec) DeepCopyInto(out *SloSpec) {
+	*out = *in
+	if in.Alerting != nil {
+		in, out := &in.Alerting, &out.Alerting
+		*out = new(SloAlertingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Latency != nil {
+		in, out := &in.Latency, &out.Latency
+		*out = new(LatencySloSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SloSpec.
+func (in *SloSpec) DeepCopy() *SloSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SloSpec)
+	in.DeepCopyInto(out)
+	return out
+}
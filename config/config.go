@@ -3,6 +3,8 @@ package config
 const (
 	OperatorName      string = "route-monitor-operator"
 	OperatorNamespace string = "openshift-route-monitor-operator"
+	// OperatorServiceAccountName is the ServiceAccount the operator runs as
+	OperatorServiceAccountName string = "route-monitor-operator-system"
 
 	EnableOLMSkipRange = "true"
 )
@@ -0,0 +1,202 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blackboxexporter
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	"github.com/openshift/route-monitor-operator/controllers"
+	"github.com/openshift/route-monitor-operator/pkg/blackboxexporter"
+	blackboxexporterconsts "github.com/openshift/route-monitor-operator/pkg/consts/blackboxexporter"
+	utilreconcile "github.com/openshift/route-monitor-operator/pkg/util/reconcile"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// BlackBoxExporterReconciler applies the blackbox exporter while monitors use it. It is reconciled whenever one of its
+// resources, the monitors, the probe credentials or the RouteMonitorOperatorConfig change, so changes made to the
+// resources by others are reverted and new configurations are rolled out right away.
+// The monitors referencing the exporter are counted through the blackboxexporter.ExporterIndexKey index, the resources
+// are removed once no monitor references it anymore, however many monitors are deleted at once
+type BlackBoxExporterReconciler struct {
+	Client   client.Client
+	Log      logr.Logger
+	Recorder record.EventRecorder
+	Image    string
+	Replicas int32
+	// NamespacedName of the blackbox exporter, all requests are for it
+	NamespacedName types.NamespacedName
+}
+
+func NewReconciler(mgr manager.Manager, blackboxExporterImage, blackboxExporterNamespace string, blackboxExporterReplicas int32) *BlackBoxExporterReconciler {
+	return &BlackBoxExporterReconciler{
+		Client:         mgr.GetClient(),
+		Log:            ctrl.Log.WithName("controllers").WithName("BlackBoxExporter"),
+		Recorder:       mgr.GetEventRecorderFor("blackboxexporter-controller"),
+		Image:          blackboxExporterImage,
+		Replicas:       blackboxExporterReplicas,
+		NamespacedName: types.NamespacedName{Name: blackboxexporterconsts.BlackBoxExporterName, Namespace: blackboxExporterNamespace},
+	}
+}
+
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=*,resources=services,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=*,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=*,resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=bind,resourceNames=route-monitor-operator-blackbox-exporter-secrets-role
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=monitoring.openshift.io,resources=routemonitoroperatorconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+func (r *BlackBoxExporterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithName("Reconcile").WithValues("name", req.Name, "namespace", req.Namespace)
+	blackBoxExporter := blackboxexporter.New(r.Client, log, ctx, r.Image, r.NamespacedName.Namespace, r.Replicas)
+	// events are recorded on the Deployment, it is referenced by name as it may not exist yet
+	deployment := &appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: appsv1.SchemeGroupVersion.String(), Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: r.NamespacedName.Name, Namespace: r.NamespacedName.Namespace},
+	}
+
+	log.V(2).Info("Entering IsInUse")
+	inUse, err := blackBoxExporter.IsInUse()
+	if err != nil {
+		log.Error(err, "Failed to list the monitors. Requeueing...")
+		return utilreconcile.RequeueWith(err)
+	}
+	if !inUse {
+		log.V(2).Info("No monitor uses the blackbox exporter, entering EnsureBlackBoxExporterResourcesAbsent")
+		// the deletion is recorded once, along with the Deployment
+		err := r.Client.Get(ctx, r.NamespacedName, &appsv1.Deployment{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return utilreconcile.RequeueWith(err)
+		}
+		existed := err == nil
+		if err := blackBoxExporter.EnsureBlackBoxExporterResourcesAbsent(); err != nil {
+			log.Error(err, "Failed to remove BlackBoxExporter. Requeueing...")
+			controllers.RecordRequeue(r.Recorder, deployment, "Failed to remove the blackbox exporter", err)
+			return utilreconcile.RequeueWith(err)
+		}
+		if existed {
+			controllers.RecordDeletion(r.Recorder, deployment, controllers.KindBlackBoxExporter, r.NamespacedName.Name)
+		}
+		return utilreconcile.Stop()
+	}
+
+	log.V(2).Info("Entering EnsureBlackBoxExporterResourcesExist")
+	result, err := blackBoxExporter.EnsureBlackBoxExporterResourcesExist()
+	if err != nil {
+		log.Error(err, "Failed to apply BlackBoxExporter. Requeueing...")
+		controllers.RecordRequeue(r.Recorder, deployment, "Failed to apply the blackbox exporter", err)
+		return utilreconcile.RequeueWith(err)
+	}
+	controllers.RecordOperation(r.Recorder, deployment, result, controllers.KindBlackBoxExporter, r.NamespacedName.Name)
+	return utilreconcile.Stop()
+}
+
+func (r *BlackBoxExporterReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	enqueueBlackBoxExporter := handler.EnqueueRequestsFromMapFunc(func(context.Context, client.Object) []reconcile.Request {
+		return []reconcile.Request{{NamespacedName: r.NamespacedName}}
+	})
+	isBlackBoxExporterResource := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return obj.GetNamespace() == r.NamespacedName.Namespace && obj.GetName() == r.NamespacedName.Name
+	})
+	isOperatorConfig := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return obj.GetName() == v1alpha1.RouteMonitorOperatorConfigName
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("blackboxexporter").
+		Watches(&appsv1.Deployment{}, enqueueBlackBoxExporter, builder.WithPredicates(isBlackBoxExporterResource)).
+		Watches(&corev1.Service{}, enqueueBlackBoxExporter, builder.WithPredicates(isBlackBoxExporterResource)).
+		Watches(&corev1.ConfigMap{}, enqueueBlackBoxExporter, builder.WithPredicates(isBlackBoxExporterResource)).
+		Watches(&policyv1.PodDisruptionBudget{}, enqueueBlackBoxExporter, builder.WithPredicates(isBlackBoxExporterResource)).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.blackBoxExporterForSecret), builder.OnlyMetadata).
+		Watches(&v1alpha1.RouteMonitor{}, enqueueBlackBoxExporter, builder.WithPredicates(probeTargetChanged)).
+		Watches(&v1alpha1.ClusterUrlMonitor{}, enqueueBlackBoxExporter, builder.WithPredicates(probeTargetChanged)).
+		Watches(&v1alpha1.RouteMonitorOperatorConfig{}, enqueueBlackBoxExporter, builder.WithPredicates(isOperatorConfig, predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
+
+// blackBoxExporterForSecret reconciles the blackbox exporter when its credentials Secret or the Secret
+// a monitor authenticates its probe with changes, so rotated credentials are copied
+func (r *BlackBoxExporterReconciler) blackBoxExporterForSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	requests := []reconcile.Request{{NamespacedName: r.NamespacedName}}
+	if secret.GetNamespace() == r.NamespacedName.Namespace && secret.GetName() == blackboxexporterconsts.CredentialsSecretName {
+		return requests
+	}
+
+	routeMonitors := &v1alpha1.RouteMonitorList{}
+	if err := r.Client.List(ctx, routeMonitors, client.InNamespace(secret.GetNamespace())); err != nil {
+		r.Log.Error(err, "Failed to list RouteMonitors referencing Secret", "name", secret.GetName(), "namespace", secret.GetNamespace())
+		return nil
+	}
+	for _, routeMonitor := range routeMonitors.Items {
+		if auth := routeMonitor.Spec.Probe.Auth; auth != nil && auth.SecretName == secret.GetName() {
+			return requests
+		}
+	}
+	clusterUrlMonitors := &v1alpha1.ClusterUrlMonitorList{}
+	if err := r.Client.List(ctx, clusterUrlMonitors, client.InNamespace(secret.GetNamespace())); err != nil {
+		r.Log.Error(err, "Failed to list ClusterUrlMonitors referencing Secret", "name", secret.GetName(), "namespace", secret.GetNamespace())
+		return nil
+	}
+	for _, clusterUrlMonitor := range clusterUrlMonitors.Items {
+		if auth := clusterUrlMonitor.Spec.Probe.Auth; auth != nil && auth.SecretName == secret.GetName() {
+			return requests
+		}
+	}
+	return nil
+}
+
+// probeTargetChanged filters monitor updates to those changing the probes of the blackbox exporter:
+// the spec, the deletion and the URL resolved into the status
+var probeTargetChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		if e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration() ||
+			e.ObjectOld.GetDeletionTimestamp().IsZero() != e.ObjectNew.GetDeletionTimestamp().IsZero() {
+			return true
+		}
+		return probedURL(e.ObjectOld) != probedURL(e.ObjectNew)
+	},
+}
+
+// probedURL returns the URL the monitor probes
+func probedURL(obj client.Object) string {
+	switch monitor := obj.(type) {
+	case *v1alpha1.RouteMonitor:
+		return monitor.Status.RouteURL
+	case *v1alpha1.ClusterUrlMonitor:
+		return monitor.Status.URL
+	}
+	return ""
+}
@@ -0,0 +1,184 @@
+package blackboxexporter
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	"github.com/openshift/route-monitor-operator/pkg/blackboxexporter"
+	blackboxexporterconsts "github.com/openshift/route-monitor-operator/pkg/consts/blackboxexporter"
+	constinit "github.com/openshift/route-monitor-operator/pkg/consts/test/init"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestBlackBoxExporterReconciler_Reconcile(t *testing.T) {
+	routeMonitor := func(deleting bool) *v1alpha1.RouteMonitor {
+		routeMonitor := &v1alpha1.RouteMonitor{
+			ObjectMeta: metav1.ObjectMeta{Name: "console", Namespace: "openshift-console"},
+			Status:     v1alpha1.RouteMonitorStatus{RouteURL: "https://console.example.com"},
+		}
+		if deleting {
+			now := metav1.Now()
+			routeMonitor.DeletionTimestamp = &now
+			routeMonitor.Finalizers = []string{"routemonitor.routemonitoroperator.monitoring.openshift.io/finalizer"}
+		}
+		return routeMonitor
+	}
+	// the node selector skips the inference of the nodes from the ingress of the cluster
+	operatorConfig := &v1alpha1.RouteMonitorOperatorConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: v1alpha1.RouteMonitorOperatorConfigName},
+		Spec: v1alpha1.RouteMonitorOperatorConfigSpec{
+			BlackboxExporter: &v1alpha1.BlackboxExporterConfig{NodeSelector: map[string]string{"node-role.kubernetes.io/infra": ""}},
+		},
+	}
+
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: blackboxexporterconsts.BlackBoxExporterName, Namespace: "test-namespace"}}
+	pooledRouteMonitor := routeMonitor(false)
+	pooledRouteMonitor.Spec.BlackboxExporterPool = "tenant-a"
+	authenticatedRouteMonitor := routeMonitor(false)
+	authenticatedRouteMonitor.Spec.Probe.Auth = &v1alpha1.ProbeAuthSpec{Type: v1alpha1.ProbeAuthBearerToken, SecretName: "console-token"}
+	probeSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "console-token", Namespace: "openshift-console"},
+		Data:       map[string][]byte{"token": []byte("secret")},
+	}
+
+	tests := []struct {
+		name        string
+		objs        []client.Object
+		wantApplied []string
+		wantRemoved bool
+	}{
+		{
+			name: "no monitors",
+			objs: []client.Object{operatorConfig},
+		},
+		{
+			name:        "last monitor deleted",
+			objs:        []client.Object{operatorConfig, routeMonitor(true), deployment},
+			wantRemoved: true,
+		},
+		{
+			name:        "only monitors of a pool",
+			objs:        []client.Object{operatorConfig, pooledRouteMonitor, deployment},
+			wantRemoved: true,
+		},
+		{
+			name:        "monitor in use",
+			objs:        []client.Object{operatorConfig, routeMonitor(false)},
+			wantApplied: []string{"ConfigMap", "Deployment", "PodDisruptionBudget", "Service"},
+		},
+		{
+			name:        "monitor probing with credentials",
+			objs:        []client.Object{operatorConfig, authenticatedRouteMonitor, probeSecret},
+			wantApplied: []string{"RoleBinding", "Secret", "ConfigMap", "Deployment", "PodDisruptionBudget", "Service"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var applied []string
+			c := fake.NewClientBuilder().
+				WithScheme(constinit.Scheme).
+				WithObjects(tt.objs...).
+				WithIndex(&v1alpha1.RouteMonitor{}, blackboxexporter.ExporterIndexKey, blackboxexporter.IndexByExporter).
+				WithIndex(&v1alpha1.ClusterUrlMonitor{}, blackboxexporter.ExporterIndexKey, blackboxexporter.IndexByExporter).
+				WithInterceptorFuncs(interceptor.Funcs{
+					// the fake client cannot apply, the applied resources are recorded instead
+					Patch: func(_ context.Context, _ client.WithWatch, obj client.Object, patch client.Patch, _ ...client.PatchOption) error {
+						if patch != client.Apply {
+							t.Errorf("%s patched with %s, want an apply", obj.GetObjectKind().GroupVersionKind().Kind, patch.Type())
+						}
+						applied = append(applied, obj.GetObjectKind().GroupVersionKind().Kind)
+						return nil
+					},
+				}).
+				Build()
+			recorder := record.NewFakeRecorder(10)
+			r := &BlackBoxExporterReconciler{
+				Client:         c,
+				Log:            logr.Discard(),
+				Recorder:       recorder,
+				Image:          "test-image:latest",
+				Replicas:       2,
+				NamespacedName: types.NamespacedName{Name: blackboxexporterconsts.BlackBoxExporterName, Namespace: "test-namespace"},
+			}
+
+			res, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: r.NamespacedName})
+			if err != nil {
+				t.Fatalf("Reconcile() unexpected error = %v", err)
+			}
+			if res.Requeue || res.RequeueAfter != 0 {
+				t.Errorf("Reconcile() = %+v, want no requeue", res)
+			}
+			if !reflect.DeepEqual(applied, tt.wantApplied) {
+				t.Errorf("Reconcile() applied %v, want %v", applied, tt.wantApplied)
+			}
+			if err := c.Get(context.TODO(), r.NamespacedName, &appsv1.Deployment{}); tt.wantRemoved && !k8serrors.IsNotFound(err) {
+				t.Errorf("Reconcile() kept the Deployment, want it removed: %v", err)
+			}
+			if tt.wantRemoved && !strings.Contains(<-recorder.Events, "BlackBoxExporterDeleted") {
+				t.Errorf("Reconcile() did not record the deletion")
+			}
+		})
+	}
+}
+
+func TestBlackBoxExporterReconciler_blackBoxExporterForSecret(t *testing.T) {
+	namespacedName := types.NamespacedName{Name: blackboxexporterconsts.BlackBoxExporterName, Namespace: "test-namespace"}
+	routeMonitor := &v1alpha1.RouteMonitor{
+		ObjectMeta: metav1.ObjectMeta{Name: "console", Namespace: "openshift-console"},
+		Spec: v1alpha1.RouteMonitorSpec{
+			Probe: v1alpha1.ProbeSpec{Auth: &v1alpha1.ProbeAuthSpec{Type: "bearerToken", SecretName: "console-probe"}},
+		},
+	}
+	secret := func(name, namespace string) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	}
+
+	tests := []struct {
+		name   string
+		secret *corev1.Secret
+		want   []reconcile.Request
+	}{
+		{
+			name:   "credentials of the blackbox exporter",
+			secret: secret(blackboxexporterconsts.CredentialsSecretName, "test-namespace"),
+			want:   []reconcile.Request{{NamespacedName: namespacedName}},
+		},
+		{
+			name:   "referenced by a monitor",
+			secret: secret("console-probe", "openshift-console"),
+			want:   []reconcile.Request{{NamespacedName: namespacedName}},
+		},
+		{
+			name:   "unrelated",
+			secret: secret("console-probe", "other-namespace"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &BlackBoxExporterReconciler{
+				Client:         fake.NewClientBuilder().WithScheme(constinit.Scheme).WithObjects(routeMonitor).Build(),
+				Log:            logr.Discard(),
+				NamespacedName: namespacedName,
+			}
+			if got := r.blackBoxExporterForSecret(context.TODO(), tt.secret); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("blackBoxExporterForSecret() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blackboxexporter
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	"github.com/openshift/route-monitor-operator/controllers"
+	"github.com/openshift/route-monitor-operator/pkg/blackboxexporter"
+	"github.com/openshift/route-monitor-operator/pkg/util/finalizer"
+	utilreconcile "github.com/openshift/route-monitor-operator/pkg/util/reconcile"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// BlackboxExporterPoolReconciler applies the dedicated blackbox exporter of every BlackboxExporterPool. The resources
+// of the exporter are owned by the pool, so they are removed by the garbage collector along with it
+type BlackboxExporterPoolReconciler struct {
+	Client   client.Client
+	Log      logr.Logger
+	Recorder record.EventRecorder
+	Image    string
+	// Namespace the exporters of pools not setting a namespace run in, the one of the default blackbox exporter
+	Namespace string
+	// Replicas of the exporters of pools not setting replicas
+	Replicas int32
+}
+
+func NewPoolReconciler(mgr manager.Manager, blackboxExporterImage, blackboxExporterNamespace string, blackboxExporterReplicas int32) *BlackboxExporterPoolReconciler {
+	return &BlackboxExporterPoolReconciler{
+		Client:    mgr.GetClient(),
+		Log:       ctrl.Log.WithName("controllers").WithName("BlackboxExporterPool"),
+		Recorder:  mgr.GetEventRecorderFor("blackboxexporterpool-controller"),
+		Image:     blackboxExporterImage,
+		Namespace: blackboxExporterNamespace,
+		Replicas:  blackboxExporterReplicas,
+	}
+}
+
+// +kubebuilder:rbac:groups=monitoring.openshift.io,resources=blackboxexporterpools,verbs=get;list;watch
+// +kubebuilder:rbac:groups=monitoring.openshift.io,resources=blackboxexporterpools/status,verbs=get;update;patch
+
+func (r *BlackboxExporterPoolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithName("Reconcile").WithValues("name", req.Name)
+
+	pool := v1alpha1.BlackboxExporterPool{}
+	if err := r.Client.Get(ctx, req.NamespacedName, &pool); err != nil {
+		if k8serrors.IsNotFound(err) {
+			log.V(2).Info("BlackboxExporterPool not found, stopping")
+			return utilreconcile.Stop()
+		}
+		return utilreconcile.RequeueWith(err)
+	}
+	if finalizer.WasDeleteRequested(&pool) {
+		log.V(2).Info("BlackboxExporterPool is being deleted, the garbage collector removes its exporter")
+		return utilreconcile.Stop()
+	}
+
+	blackBoxExporter := blackboxexporter.NewForPool(r.Client, log, ctx, r.Image, r.Namespace, r.Replicas, &pool)
+	status := pool.Status.DeepCopy()
+	status.Exporter = v1alpha1.NamespacedName{Name: blackBoxExporter.NamespacedName.Name, Namespace: blackBoxExporter.NamespacedName.Namespace}
+	status.ObservedGeneration = pool.Generation
+	ready := metav1.Condition{
+		Type:               v1alpha1.ConditionReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             v1alpha1.ReasonReconciled,
+		ObservedGeneration: pool.Generation,
+	}
+
+	log.V(2).Info("Entering EnsureBlackBoxExporterResourcesExist")
+	result, applyErr := blackBoxExporter.EnsureBlackBoxExporterResourcesExist()
+	if applyErr != nil {
+		log.Error(applyErr, "Failed to apply the exporter of the BlackboxExporterPool. Requeueing...")
+		controllers.RecordRequeue(r.Recorder, &pool, "Failed to apply the blackbox exporter", applyErr)
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = v1alpha1.ReasonReconcileFailed
+		ready.Message = applyErr.Error()
+	} else {
+		controllers.RecordOperation(r.Recorder, &pool, result, controllers.KindBlackBoxExporter, blackBoxExporter.NamespacedName.String())
+	}
+	meta.SetStatusCondition(&status.Conditions, ready)
+
+	if !reflect.DeepEqual(pool.Status, *status) {
+		pool.Status = *status
+		if err := r.Client.Status().Update(ctx, &pool); err != nil {
+			log.Error(err, "Failed to update the BlackboxExporterPool status. Requeueing...")
+			return utilreconcile.RequeueWith(err)
+		}
+	}
+	if applyErr != nil {
+		return utilreconcile.RequeueWith(applyErr)
+	}
+	return utilreconcile.Stop()
+}
+
+// SetupWithManager reconciles a pool when its spec, one of its exporter resources, the monitors or the Secrets
+// the monitors selecting it authenticate their probes with change
+func (r *BlackboxExporterPoolReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.BlackboxExporterPool{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Owns(&appsv1.Deployment{}).
+		Owns(&corev1.Service{}).
+		Owns(&corev1.ConfigMap{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
+		Owns(&corev1.Secret{}, builder.OnlyMetadata).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.poolsForSecret), builder.OnlyMetadata).
+		Watches(&v1alpha1.RouteMonitor{}, handler.EnqueueRequestsFromMapFunc(r.allPools), builder.WithPredicates(probeTargetChanged)).
+		Watches(&v1alpha1.ClusterUrlMonitor{}, handler.EnqueueRequestsFromMapFunc(r.allPools), builder.WithPredicates(probeTargetChanged)).
+		Complete(r)
+}
+
+// allPools reconciles every pool when a monitor changes, the pool the monitor selected before the change
+// is not known and has to stop probing it
+func (r *BlackboxExporterPoolReconciler) allPools(ctx context.Context, _ client.Object) []reconcile.Request {
+	pools := &v1alpha1.BlackboxExporterPoolList{}
+	if err := r.Client.List(ctx, pools); err != nil {
+		r.Log.Error(err, "Failed to list BlackboxExporterPools")
+		return nil
+	}
+	requests := []reconcile.Request{}
+	for _, pool := range pools.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: pool.Name}})
+	}
+	return requests
+}
+
+// poolsForSecret reconciles the pools of the monitors authenticating their probe with the Secret, so rotated credentials are copied
+func (r *BlackboxExporterPoolReconciler) poolsForSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	pools := map[string]struct{}{}
+	routeMonitors := &v1alpha1.RouteMonitorList{}
+	if err := r.Client.List(ctx, routeMonitors, client.InNamespace(secret.GetNamespace())); err != nil {
+		r.Log.Error(err, "Failed to list RouteMonitors referencing Secret", "name", secret.GetName(), "namespace", secret.GetNamespace())
+		return nil
+	}
+	for _, routeMonitor := range routeMonitors.Items {
+		if auth := routeMonitor.Spec.Probe.Auth; auth != nil && auth.SecretName == secret.GetName() && routeMonitor.Spec.BlackboxExporterPool != "" {
+			pools[routeMonitor.Spec.BlackboxExporterPool] = struct{}{}
+		}
+	}
+	clusterUrlMonitors := &v1alpha1.ClusterUrlMonitorList{}
+	if err := r.Client.List(ctx, clusterUrlMonitors, client.InNamespace(secret.GetNamespace())); err != nil {
+		r.Log.Error(err, "Failed to list ClusterUrlMonitors referencing Secret", "name", secret.GetName(), "namespace", secret.GetNamespace())
+		return nil
+	}
+	for _, clusterUrlMonitor := range clusterUrlMonitors.Items {
+		if auth := clusterUrlMonitor.Spec.Probe.Auth; auth != nil && auth.SecretName == secret.GetName() && clusterUrlMonitor.Spec.BlackboxExporterPool != "" {
+			pools[clusterUrlMonitor.Spec.BlackboxExporterPool] = struct{}{}
+		}
+	}
+
+	requests := []reconcile.Request{}
+	for pool := range pools {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: pool}})
+	}
+	return requests
+}
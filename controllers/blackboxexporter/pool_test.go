@@ -0,0 +1,196 @@
+package blackboxexporter
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	"github.com/openshift/route-monitor-operator/pkg/blackboxexporter"
+	blackboxexporterconsts "github.com/openshift/route-monitor-operator/pkg/consts/blackboxexporter"
+	constinit "github.com/openshift/route-monitor-operator/pkg/consts/test/init"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestBlackboxExporterPoolReconciler_Reconcile(t *testing.T) {
+	tests := []struct {
+		name          string
+		namespace     string
+		applyErr      error
+		wantExporter  v1alpha1.NamespacedName
+		wantReady     metav1.ConditionStatus
+		wantReason    string
+		wantRequeue   bool
+		wantNamespace string
+	}{
+		{
+			name:          "exporter in the default namespace",
+			wantExporter:  v1alpha1.NamespacedName{Name: "blackbox-exporter-tenant-a", Namespace: "test-namespace"},
+			wantReady:     metav1.ConditionTrue,
+			wantReason:    v1alpha1.ReasonReconciled,
+			wantNamespace: "test-namespace",
+		},
+		{
+			name:          "exporter in the namespace of the pool",
+			namespace:     "tenant-a",
+			wantExporter:  v1alpha1.NamespacedName{Name: "blackbox-exporter-tenant-a", Namespace: "tenant-a"},
+			wantReady:     metav1.ConditionTrue,
+			wantReason:    v1alpha1.ReasonReconciled,
+			wantNamespace: "tenant-a",
+		},
+		{
+			name:         "failed apply",
+			applyErr:     errors.New("apply failed"),
+			wantExporter: v1alpha1.NamespacedName{Name: "blackbox-exporter-tenant-a", Namespace: "test-namespace"},
+			wantReady:    metav1.ConditionFalse,
+			wantReason:   v1alpha1.ReasonReconcileFailed,
+			wantRequeue:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the node selector skips the inference of the nodes from the ingress of the cluster
+			pool := &v1alpha1.BlackboxExporterPool{
+				ObjectMeta: metav1.ObjectMeta{Name: "tenant-a", Generation: 2},
+				Spec: v1alpha1.BlackboxExporterPoolSpec{
+					Namespace:              tt.namespace,
+					BlackboxExporterConfig: v1alpha1.BlackboxExporterConfig{NodeSelector: map[string]string{"node-role.kubernetes.io/infra": ""}},
+				},
+			}
+			routeMonitor := &v1alpha1.RouteMonitor{
+				ObjectMeta: metav1.ObjectMeta{Name: "console", Namespace: "openshift-console"},
+				Spec:       v1alpha1.RouteMonitorSpec{BlackboxExporterPool: "tenant-a"},
+				Status:     v1alpha1.RouteMonitorStatus{RouteURL: "https://console.example.com"},
+			}
+			c := fake.NewClientBuilder().
+				WithScheme(constinit.Scheme).
+				WithObjects(pool, routeMonitor).
+				WithIndex(&v1alpha1.RouteMonitor{}, blackboxexporter.ExporterIndexKey, blackboxexporter.IndexByExporter).
+				WithIndex(&v1alpha1.ClusterUrlMonitor{}, blackboxexporter.ExporterIndexKey, blackboxexporter.IndexByExporter).
+				WithStatusSubresource(&v1alpha1.BlackboxExporterPool{}).
+				WithInterceptorFuncs(interceptor.Funcs{
+					// the fake client cannot apply, the namespace and owner of the applied resources are checked instead
+					Patch: func(_ context.Context, _ client.WithWatch, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+						if tt.applyErr != nil {
+							return tt.applyErr
+						}
+						if obj.GetNamespace() != tt.wantNamespace {
+							t.Errorf("%s applied in namespace %s, want %s", obj.GetName(), obj.GetNamespace(), tt.wantNamespace)
+						}
+						if owners := obj.GetOwnerReferences(); len(owners) != 1 || owners[0].Name != pool.Name {
+							t.Errorf("%s owned by %v, want the pool", obj.GetName(), owners)
+						}
+						if obj.GetLabels()[blackboxexporterconsts.PoolLabel] != pool.Name {
+							t.Errorf("%s labeled %v, want the pool label", obj.GetName(), obj.GetLabels())
+						}
+						return nil
+					},
+				}).
+				Build()
+			r := &BlackboxExporterPoolReconciler{
+				Client:    c,
+				Log:       logr.Discard(),
+				Recorder:  record.NewFakeRecorder(10),
+				Image:     "test-image:latest",
+				Namespace: "test-namespace",
+				Replicas:  2,
+			}
+
+			_, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Name: pool.Name}})
+			if (err != nil) != tt.wantRequeue {
+				t.Fatalf("Reconcile() error = %v, want requeue %t", err, tt.wantRequeue)
+			}
+
+			got := &v1alpha1.BlackboxExporterPool{}
+			if err := c.Get(context.TODO(), client.ObjectKeyFromObject(pool), got); err != nil {
+				t.Fatalf("failed to get the BlackboxExporterPool: %v", err)
+			}
+			if got.Status.Exporter != tt.wantExporter {
+				t.Errorf("exporter = %v, want %v", got.Status.Exporter, tt.wantExporter)
+			}
+			if got.Status.ObservedGeneration != 2 {
+				t.Errorf("observedGeneration = %d, want 2", got.Status.ObservedGeneration)
+			}
+			ready := meta.FindStatusCondition(got.Status.Conditions, v1alpha1.ConditionReady)
+			if ready == nil || ready.Status != tt.wantReady || ready.Reason != tt.wantReason {
+				t.Errorf("Ready condition = %+v, want status %s and reason %s", ready, tt.wantReady, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestBlackboxExporterPoolReconciler_Reconcile_NotFound(t *testing.T) {
+	r := &BlackboxExporterPoolReconciler{
+		Client: fake.NewClientBuilder().WithScheme(constinit.Scheme).Build(),
+		Log:    logr.Discard(),
+	}
+
+	res, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "tenant-a"}})
+	if err != nil {
+		t.Fatalf("Reconcile() unexpected error = %v", err)
+	}
+	if res.Requeue || res.RequeueAfter != 0 {
+		t.Errorf("Reconcile() = %+v, want no requeue", res)
+	}
+}
+
+func TestBlackboxExporterPoolReconciler_poolsForSecret(t *testing.T) {
+	routeMonitor := func(name, pool string) *v1alpha1.RouteMonitor {
+		return &v1alpha1.RouteMonitor{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "openshift-console"},
+			Spec: v1alpha1.RouteMonitorSpec{
+				BlackboxExporterPool: pool,
+				Probe:                v1alpha1.ProbeSpec{Auth: &v1alpha1.ProbeAuthSpec{Type: "bearerToken", SecretName: name + "-probe"}},
+			},
+		}
+	}
+	secret := func(name string) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "openshift-console"}}
+	}
+
+	tests := []struct {
+		name   string
+		secret *corev1.Secret
+		want   []reconcile.Request
+	}{
+		{
+			name:   "referenced by a monitor selecting a pool",
+			secret: secret("console-probe"),
+			want:   []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "tenant-a"}}},
+		},
+		{
+			name:   "referenced by a monitor of the default exporter",
+			secret: secret("downloads-probe"),
+			want:   []reconcile.Request{},
+		},
+		{
+			name:   "unrelated",
+			secret: secret("other"),
+			want:   []reconcile.Request{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &BlackboxExporterPoolReconciler{
+				Client: fake.NewClientBuilder().WithScheme(constinit.Scheme).WithObjects(routeMonitor("console", "tenant-a"), routeMonitor("downloads", "")).Build(),
+				Log:    logr.Discard(),
+			}
+			if got := r.poolsForSecret(context.TODO(), tt.secret); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("poolsForSecret() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
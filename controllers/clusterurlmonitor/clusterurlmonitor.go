@@ -24,15 +24,24 @@ import (
 	"github.com/openshift/route-monitor-operator/controllers"
 	"github.com/openshift/route-monitor-operator/pkg/alert"
 	"github.com/openshift/route-monitor-operator/pkg/blackboxexporter"
+	"github.com/openshift/route-monitor-operator/pkg/metrics"
+	"github.com/openshift/route-monitor-operator/pkg/probestatus"
 	reconcileCommon "github.com/openshift/route-monitor-operator/pkg/reconcile"
 	"github.com/openshift/route-monitor-operator/pkg/servicemonitor"
+	"github.com/openshift/route-monitor-operator/pkg/util/conditions"
 	utilreconcile "github.com/openshift/route-monitor-operator/pkg/util/reconcile"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 // ClusterUrlMonitorReconciler reconciles a ClusterUrlMonitor object
@@ -46,21 +55,28 @@ type ClusterUrlMonitorReconciler struct {
 	ServiceMonitor   controllers.ServiceMonitorHandler
 	Prom             controllers.PrometheusRuleHandler
 	Common           controllers.MonitorResourceHandler
+	Recorder         record.EventRecorder
+	// ProbeStatus is nil unless a Prometheus endpoint is configured
+	ProbeStatus controllers.ProbeStatusHandler
+	// ProbeStatusReloader resolves ProbeStatus from the operator configuration when reconciling, unless it is nil
+	ProbeStatusReloader *probestatus.Reloader
 }
 
-func NewReconciler(mgr manager.Manager, blackboxExporterImage, blackboxExporterNamespace string, enablehypershift bool, probeAPIURL string) *ClusterUrlMonitorReconciler {
+func NewReconciler(mgr manager.Manager, blackboxExporterImage, blackboxExporterNamespace string, blackboxExporterReplicas int32, enablehypershift bool, probeAPIURL string, probeStatusReloader *probestatus.Reloader) *ClusterUrlMonitorReconciler {
 	log := ctrl.Log.WithName("controllers").WithName("ClusterUrlMonitor")
 	client := mgr.GetClient()
 	ctx := context.Background()
 	return &ClusterUrlMonitorReconciler{
-		Client:           client,
-		Ctx:              ctx,
-		Log:              log,
-		Scheme:           mgr.GetScheme(),
-		BlackBoxExporter: blackboxexporter.New(client, log, ctx, blackboxExporterImage, blackboxExporterNamespace),
-		ServiceMonitor:   servicemonitor.NewServiceMonitor(ctx, client),
-		Prom:             alert.NewPrometheusRule(ctx, client),
-		Common:           reconcileCommon.NewMonitorResourceCommon(ctx, client),
+		Client:              client,
+		Ctx:                 ctx,
+		Log:                 log,
+		Scheme:              mgr.GetScheme(),
+		BlackBoxExporter:    blackboxexporter.New(client, log, ctx, blackboxExporterImage, blackboxExporterNamespace, blackboxExporterReplicas),
+		ServiceMonitor:      servicemonitor.NewServiceMonitor(ctx, client),
+		Prom:                alert.NewPrometheusRule(ctx, client),
+		Common:              reconcileCommon.NewMonitorResourceCommon(ctx, client),
+		ProbeStatusReloader: probeStatusReloader,
+		Recorder:            mgr.GetEventRecorderFor("clusterurlmonitor-controller"),
 	}
 }
 
@@ -72,6 +88,7 @@ const (
 
 // +kubebuilder:rbac:groups=monitoring.openshift.io,resources=clusterurlmonitors,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=monitoring.openshift.io,resources=clusterurlmonitors/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=monitoring.openshift.io,resources=blackboxexporterpools,verbs=get;list;watch
 // +kubebuilder:rbac:groups=config.openshift.io,resources=dnses,verbs=get;list;watch
 // +kubebuilder:rbac:groups=monitoring.coreos.com,resources=prometheusrules,verbs=get;list;watch;create;delete
 // +kubebuilder:rbac:groups=config.openshift.io,resources=clusterversions,verbs=get;list;watch
@@ -79,9 +96,11 @@ const (
 // +kubebuilder:rbac:groups=operator.openshift.io,resources=ingresscontrollers,verbs=get;list;watch
 // +kubebuilder:rbac:groups=hypershift.openshift.io,resources=hostedcontrolplanes,verbs=get;list;watch
 // +kubebuilder:rbac:groups=hypershift.openshift.io,resources=hostedclusters,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *ClusterUrlMonitorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	r.Ctx = ctx
+	r.resolveProbeStatus()
 	log := r.Log.WithName("Reconcile").WithValues("name", req.Name, "namespace", req.Namespace)
 
 	log.V(2).Info("Entering GetClusterUrlMonitor")
@@ -91,12 +110,17 @@ func (r *ClusterUrlMonitorReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return utilreconcile.RequeueWith(err)
 	}
 	if res.ShouldStop() {
+		metrics.DeleteMonitorState(metrics.KindClusterUrlMonitor, req.NamespacedName)
 		return utilreconcile.Stop()
 	}
+	// the state is recorded once the steps updated the conditions
+	defer r.recordMonitorState(req)
 
 	res, err = r.EnsureMonitorAndDependenciesAbsent(clusterUrlMonitor)
+	metrics.RecordReconcileStep(metrics.KindClusterUrlMonitor, "EnsureMonitorAndDependenciesAbsent", res, err)
 	if err != nil {
 		log.Error(err, "Failed to delete ClusterUrlMontior. Requeueing...")
+		controllers.RecordRequeue(r.Recorder, &clusterUrlMonitor, "Failed to delete the ClusterUrlMonitor", err)
 		return utilreconcile.RequeueWith(err)
 	}
 	if res.ShouldStop() {
@@ -106,8 +130,10 @@ func (r *ClusterUrlMonitorReconciler) Reconcile(ctx context.Context, req ctrl.Re
 
 	log.V(2).Info("Entering EnsureFinalizerSet")
 	res, err = r.EnsureFinalizerSet(clusterUrlMonitor)
+	metrics.RecordReconcileStep(metrics.KindClusterUrlMonitor, "EnsureFinalizerSet", res, err)
 	if err != nil {
 		log.Error(err, "Failed to set ClusterUrlMonitor's Finalizer. Requeueing...")
+		controllers.RecordRequeue(r.Recorder, &clusterUrlMonitor, "Failed to set the finalizer", err)
 		return utilreconcile.RequeueWith(err)
 	}
 	if res.ShouldStop() {
@@ -115,18 +141,24 @@ func (r *ClusterUrlMonitorReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return utilreconcile.Stop()
 	}
 
-	log.V(2).Info("Entering EnsureBlackBoxExporterResourcesExist")
-	err = r.BlackBoxExporter.EnsureBlackBoxExporterResourcesExist()
+	log.V(2).Info("Entering EnsureURLExists")
+	res, err = r.EnsureURLExists(clusterUrlMonitor)
+	metrics.RecordReconcileStep(metrics.KindClusterUrlMonitor, "EnsureURLExists", res, err)
 	if err != nil {
-		log.Error(err, "Failed to create BlackBoxExporter. Requeueing...")
-		return utilreconcile.RequeueWith(err)
+		log.Error(err, "Failed to get URL for ClusterUrlMonitor. Requeueing...")
+		return r.requeueWithCondition(clusterUrlMonitor, monitoringv1alpha1.ConditionRouteResolved, "Failed to get the URL", err)
+	}
+	if res.ShouldStop() {
+		log.Info("Successfully patched ClusterUrlMonitor with URL. Stopping...")
+		return utilreconcile.Stop()
 	}
 
 	log.V(2).Info("Entering EnsureServiceMonitorExists")
 	res, err = r.EnsureServiceMonitorExists(clusterUrlMonitor)
+	metrics.RecordReconcileStep(metrics.KindClusterUrlMonitor, "EnsureServiceMonitorExists", res, err)
 	if err != nil {
 		log.Error(err, "Failed to set ServiceMonitor. Requeueing...")
-		return utilreconcile.RequeueWith(err)
+		return r.requeueWithCondition(clusterUrlMonitor, monitoringv1alpha1.ConditionServiceMonitorReady, "Failed to set the ServiceMonitor", err)
 	}
 	if res.ShouldStop() {
 		log.Info("Successfully patched ClusterUrlMonitor with ServiceMonitorRef. Stopping...")
@@ -135,17 +167,75 @@ func (r *ClusterUrlMonitorReconciler) Reconcile(ctx context.Context, req ctrl.Re
 
 	log.V(2).Info("Entering EnsurePrometheusRuleResourceExists")
 	res, err = r.EnsurePrometheusRuleExists(clusterUrlMonitor)
+	metrics.RecordReconcileStep(metrics.KindClusterUrlMonitor, "EnsurePrometheusRuleExists", res, err)
 	if err != nil {
 		log.Error(err, "Failed to set PrometheusRule. Requeueing...")
-		return utilreconcile.RequeueWith(err)
+		return r.requeueWithCondition(clusterUrlMonitor, monitoringv1alpha1.ConditionPrometheusRuleReady, "Failed to set the PrometheusRule", err)
 	}
 	if res.ShouldStop() {
 		log.Info("Successfully patched ClusterUrlMonitor with PrometheusRuleRef. Stopping...")
 		return utilreconcile.Stop()
 	}
 
+	log.V(2).Info("Entering EnsureProbeStatusUpdated")
+	res, err = r.EnsureProbeStatusUpdated(clusterUrlMonitor)
+	metrics.RecordReconcileStep(metrics.KindClusterUrlMonitor, "EnsureProbeStatusUpdated", res, err)
+	if err != nil {
+		log.Error(err, "Failed to update the probe status. Requeueing...")
+		controllers.RecordRequeue(r.Recorder, &clusterUrlMonitor, "Failed to update the probe status", err)
+		return utilreconcile.RequeueWith(err)
+	}
+	if res.ShouldStop() {
+		log.Info("Successfully patched ClusterUrlMonitor with the probe status. Stopping...")
+		return utilreconcile.Stop()
+	}
+
+	log.V(2).Info("Entering EnsureMaintenanceStatusUpdated")
+	maintenanceRes, err := r.EnsureMaintenanceStatusUpdated(clusterUrlMonitor)
+	metrics.RecordReconcileStep(metrics.KindClusterUrlMonitor, "EnsureMaintenanceStatusUpdated", maintenanceRes, err)
+	if err != nil {
+		log.Error(err, "Failed to update the active maintenance window. Requeueing...")
+		controllers.RecordRequeue(r.Recorder, &clusterUrlMonitor, "Failed to update the active maintenance window", err)
+		return utilreconcile.RequeueWith(err)
+	}
+	if maintenanceRes.ShouldStop() {
+		log.Info("Successfully patched ClusterUrlMonitor with the active maintenance window. Stopping...")
+		return utilreconcile.Stop()
+	}
+
 	log.Info("All operations for ClusterUrlMonitor completed. Finished Reconcile.")
-	return utilreconcile.Stop()
+	return res.RequeueSooner(maintenanceRes.RequeueAfter).Convert(), nil
+}
+
+// recordMonitorState records the state of the ClusterUrlMonitor derived from the conditions set by the reconcile steps.
+// The ClusterUrlMonitor is read again, as every step updates the status of its own copy
+func (r *ClusterUrlMonitorReconciler) recordMonitorState(req ctrl.Request) {
+	clusterUrlMonitor, res, err := r.GetClusterUrlMonitor(req)
+	if err != nil {
+		r.Log.V(2).Info("Failed to read the ClusterUrlMonitor to record its state", "error", err.Error())
+		return
+	}
+	if res.ShouldStop() {
+		metrics.DeleteMonitorState(metrics.KindClusterUrlMonitor, req.NamespacedName)
+		return
+	}
+	domainRef := clusterUrlMonitor.Spec.DomainRef
+	if domainRef == "" {
+		domainRef = monitoringv1alpha1.ClusterDomainRefInfra
+	}
+	metrics.SetMonitorState(metrics.KindClusterUrlMonitor, req.NamespacedName, string(domainRef), clusterUrlMonitor.Status.Conditions)
+}
+
+// requeueWithCondition records the error of a reconcile step in the condition of the step and as warning event before requeueing.
+// Updating the status is best effort, as the step is retried anyways
+func (r *ClusterUrlMonitorReconciler) requeueWithCondition(clusterUrlMonitor monitoringv1alpha1.ClusterUrlMonitor, conditionType, message string, err error) (ctrl.Result, error) {
+	controllers.RecordRequeue(r.Recorder, &clusterUrlMonitor, message, err)
+	if conditions.MarkFalse(&clusterUrlMonitor, conditionType, monitoringv1alpha1.ReasonReconcileFailed, err) {
+		if _, updateErr := r.Common.UpdateMonitorResourceStatus(&clusterUrlMonitor); updateErr != nil {
+			r.Log.V(2).Info("Failed to record the error in the ClusterUrlMonitor conditions", "error", updateErr.Error())
+		}
+	}
+	return utilreconcile.RequeueWith(err)
 }
 
 func (r *ClusterUrlMonitorReconciler) SetupWithManager(mgr ctrl.Manager) error {
@@ -155,5 +245,49 @@ func (r *ClusterUrlMonitorReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			&monitoringv1.ServiceMonitor{},
 			handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &monitoringv1alpha1.ClusterUrlMonitor{}, handler.OnlyControllerOwner()),
 		).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.clusterUrlMonitorsForSecret),
+			builder.OnlyMetadata,
+		).
+		Watches(
+			&monitoringv1alpha1.BlackboxExporterPool{},
+			handler.EnqueueRequestsFromMapFunc(r.clusterUrlMonitorsForPool),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
 		Complete(r)
 }
+
+// clusterUrlMonitorsForPool returns the ClusterUrlMonitors selecting the BlackboxExporterPool,
+// so their ServiceMonitor is created once the pool exists
+func (r *ClusterUrlMonitorReconciler) clusterUrlMonitorsForPool(ctx context.Context, pool client.Object) []reconcile.Request {
+	clusterUrlMonitors := &monitoringv1alpha1.ClusterUrlMonitorList{}
+	if err := r.Client.List(ctx, clusterUrlMonitors); err != nil {
+		r.Log.Error(err, "Failed to list ClusterUrlMonitors selecting BlackboxExporterPool", "name", pool.GetName())
+		return nil
+	}
+	requests := []reconcile.Request{}
+	for _, clusterUrlMonitor := range clusterUrlMonitors.Items {
+		if clusterUrlMonitor.Spec.BlackboxExporterPool == pool.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: clusterUrlMonitor.Name, Namespace: clusterUrlMonitor.Namespace}})
+		}
+	}
+	return requests
+}
+
+// clusterUrlMonitorsForSecret returns the ClusterUrlMonitors authenticating their probe with the Secret,
+// so the validation of their probe is updated when it changes
+func (r *ClusterUrlMonitorReconciler) clusterUrlMonitorsForSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	clusterUrlMonitors := &monitoringv1alpha1.ClusterUrlMonitorList{}
+	if err := r.Client.List(ctx, clusterUrlMonitors, client.InNamespace(secret.GetNamespace())); err != nil {
+		r.Log.Error(err, "Failed to list ClusterUrlMonitors referencing Secret", "name", secret.GetName(), "namespace", secret.GetNamespace())
+		return nil
+	}
+	requests := []reconcile.Request{}
+	for _, clusterUrlMonitor := range clusterUrlMonitors.Items {
+		if auth := clusterUrlMonitor.Spec.Probe.Auth; auth != nil && auth.SecretName == secret.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: clusterUrlMonitor.Name, Namespace: clusterUrlMonitor.Namespace}})
+		}
+	}
+	return requests
+}
@@ -1,16 +1,24 @@
 package clusterurlmonitor
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
 	"reflect"
 	"strings"
+	"time"
 
 	configv1 "github.com/openshift/api/config/v1"
 	hypershiftv1beta1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
 	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	"github.com/openshift/route-monitor-operator/controllers"
 	"github.com/openshift/route-monitor-operator/pkg/alert"
-	blackboxexporterconsts "github.com/openshift/route-monitor-operator/pkg/consts/blackboxexporter"
+	"github.com/openshift/route-monitor-operator/pkg/blackboxexporter"
+	"github.com/openshift/route-monitor-operator/pkg/maintenance"
+	"github.com/openshift/route-monitor-operator/pkg/probestatus"
+	"github.com/openshift/route-monitor-operator/pkg/servicemonitor"
+	"github.com/openshift/route-monitor-operator/pkg/util/conditions"
+	customerrors "github.com/openshift/route-monitor-operator/pkg/util/errors"
 	utilreconcile "github.com/openshift/route-monitor-operator/pkg/util/reconcile"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -23,15 +31,66 @@ const (
 	hcpClusterAnnotation = "hypershift.openshift.io/cluster"
 )
 
+// resolveProbeStatus picks up the Prometheus endpoints of the current operator configuration
+func (s *ClusterUrlMonitorReconciler) resolveProbeStatus() {
+	if s.ProbeStatusReloader == nil {
+		return
+	}
+	// the client of the previous endpoints is returned along with the errors of the configuration
+	probeStatus, err := s.ProbeStatusReloader.Get(s.Ctx)
+	if err != nil {
+		s.Log.Error(err, "Failed to resolve the Prometheus endpoints of the probe status")
+	}
+	s.ProbeStatus = nil
+	if probeStatus != nil {
+		s.ProbeStatus = probeStatus
+	}
+}
+
+// EnsureProbeStatusUpdated records the health of the probes queried from Prometheus in the status.
+// Queries are throttled to the refresh interval, as every status update triggers another reconcile
+func (s *ClusterUrlMonitorReconciler) EnsureProbeStatusUpdated(clusterUrlMonitor v1alpha1.ClusterUrlMonitor) (utilreconcile.Result, error) {
+	if s.ProbeStatus == nil {
+		return utilreconcile.ContinueReconcile()
+	}
+	if wait := probestatus.NextRefresh(clusterUrlMonitor.Status.ProbeStatus, s.ProbeStatus.RefreshInterval()); wait > 0 {
+		return utilreconcile.Result{Continue: true, RequeueAfter: wait}, nil
+	}
+
+	// HCP monitors are probed through RHOBS ServiceMonitors
+	isHCP := (clusterUrlMonitor.Spec.DomainRef == v1alpha1.ClusterDomainRefHCP)
+	status, err := s.ProbeStatus.GetProbeStatus(clusterUrlMonitor.Status.URL, isHCP, clusterUrlMonitor.Spec.Slo)
+	if err != nil {
+		s.Log.V(2).Info("Failed to query the probe status", "error", err.Error())
+	}
+	clusterUrlMonitor.Status.ProbeStatus = probestatus.Record(&clusterUrlMonitor, clusterUrlMonitor.Status.ProbeStatus, status, err)
+	return s.Common.UpdateMonitorResourceStatus(&clusterUrlMonitor)
+}
+
+// EnsureMaintenanceStatusUpdated reports the maintenance window in progress in the status. It requeues when the next
+// window starts or ends, so the status and the windows of the PrometheusRule follow the schedule
+func (s *ClusterUrlMonitorReconciler) EnsureMaintenanceStatusUpdated(clusterUrlMonitor v1alpha1.ClusterUrlMonitor) (utilreconcile.Result, error) {
+	now := time.Now()
+	windows := maintenance.Upcoming(clusterUrlMonitor.Spec.MaintenanceWindows, now)
+	if active := maintenance.Active(windows, now); !maintenance.Equal(active, clusterUrlMonitor.Status.ActiveMaintenanceWindow) {
+		clusterUrlMonitor.Status.ActiveMaintenanceWindow = active
+		return s.Common.UpdateMonitorResourceStatus(&clusterUrlMonitor)
+	}
+	return utilreconcile.Result{Continue: true, RequeueAfter: maintenance.NextTransition(windows, now)}, nil
+}
+
 // Takes care that right PrometheusRules for the defined ClusterURLMonitor are in place
 func (s *ClusterUrlMonitorReconciler) EnsurePrometheusRuleExists(clusterUrlMonitor v1alpha1.ClusterUrlMonitor) (utilreconcile.Result, error) {
 	// If .spec.skipPrometheusRule is true, ensure that the PrometheusRule does NOT exist
 	if clusterUrlMonitor.Spec.SkipPrometheusRule {
 		// Cleanup any existing PrometheusRules and update the status
-		if err := s.Prom.DeletePrometheusRuleDeployment(clusterUrlMonitor.Status.PrometheusRuleRef); err != nil {
+		if err := s.deletePrometheusRule(clusterUrlMonitor); err != nil {
 			return utilreconcile.RequeueReconcileWith(err)
 		}
-		updated, _ := s.Common.SetResourceReference(&clusterUrlMonitor.Status.PrometheusRuleRef, types.NamespacedName{})
+		updated := s.Common.SetResourceReference(&clusterUrlMonitor.Status.PrometheusRuleRef, types.NamespacedName{})
+		if conditions.MarkTrue(&clusterUrlMonitor, v1alpha1.ConditionPrometheusRuleReady, v1alpha1.ReasonNotRequired) {
+			updated = true
+		}
 		if updated {
 			return s.Common.UpdateMonitorResourceStatus(&clusterUrlMonitor)
 		}
@@ -41,6 +100,9 @@ func (s *ClusterUrlMonitorReconciler) EnsurePrometheusRuleExists(clusterUrlMonit
 
 	// We shouldn't create prometheusrules for HCP clusterUrlMonitors, since alerting is implemented in the upstream RHOBS tenant
 	if clusterUrlMonitor.Spec.DomainRef == v1alpha1.ClusterDomainRefHCP {
+		if conditions.MarkTrue(&clusterUrlMonitor, v1alpha1.ConditionPrometheusRuleReady, v1alpha1.ReasonNotRequired) {
+			return s.Common.UpdateMonitorResourceStatus(&clusterUrlMonitor)
+		}
 		return utilreconcile.ContinueReconcile()
 	}
 
@@ -52,16 +114,33 @@ func (s *ClusterUrlMonitorReconciler) EnsurePrometheusRuleExists(clusterUrlMonit
 	spec := clusterUrlMonitor.Spec
 	clusterUrl := spec.Prefix + clusterDomain + ":" + spec.Port + spec.Suffix
 	parsedSlo, err := s.Common.ParseMonitorSLOSpecs(clusterUrl, clusterUrlMonitor.Spec.Slo)
+	if err == nil && clusterUrlMonitor.Spec.Slo.Latency != nil && !clusterUrlMonitor.Spec.Slo.Latency.IsValidFor(clusterUrlMonitor.Spec.Probe) {
+		err = customerrors.ErrInvalidLatencySLO
+	}
+	if err == nil && clusterUrlMonitor.Spec.CertificateExpiry != nil && !clusterUrlMonitor.Spec.CertificateExpiry.IsValid() {
+		err = customerrors.ErrInvalidCertificateExpiry
+	}
+	if err == nil && maintenance.Validate(clusterUrlMonitor.Spec.MaintenanceWindows) != nil {
+		err = customerrors.ErrInvalidMaintenanceWindow
+	}
 
-	if s.Common.SetErrorStatus(&clusterUrlMonitor.Status.ErrorStatus, err) {
+	errorStatusUpdated := s.Common.SetErrorStatus(&clusterUrlMonitor.Status.ErrorStatus, err)
+	if err != nil && conditions.MarkFalse(&clusterUrlMonitor, v1alpha1.ConditionPrometheusRuleReady, v1alpha1.ReasonInvalidSpec, err) {
+		errorStatusUpdated = true
+	}
+	if errorStatusUpdated {
 		return s.Common.UpdateMonitorResourceStatus(&clusterUrlMonitor)
 	}
-	if parsedSlo == "" {
-		err = s.Prom.DeletePrometheusRuleDeployment(clusterUrlMonitor.Status.PrometheusRuleRef)
-		if err != nil {
+	// an invalid spec never renders alerts, its PrometheusRule is removed until it is fixed
+	if err != nil || (parsedSlo == "" && clusterUrlMonitor.Spec.CertificateExpiry == nil) {
+		// the error of the spec is kept, so the condition is only set when the PrometheusRule is not required
+		if err := s.deletePrometheusRule(clusterUrlMonitor); err != nil {
 			return utilreconcile.RequeueReconcileWith(err)
 		}
-		updated, _ := s.Common.SetResourceReference(&clusterUrlMonitor.Status.PrometheusRuleRef, types.NamespacedName{})
+		updated := s.Common.SetResourceReference(&clusterUrlMonitor.Status.PrometheusRuleRef, types.NamespacedName{})
+		if err == nil && conditions.MarkTrue(&clusterUrlMonitor, v1alpha1.ConditionPrometheusRuleReady, v1alpha1.ReasonNotRequired) {
+			updated = true
+		}
 		if updated {
 			return s.Common.UpdateMonitorResourceStatus(&clusterUrlMonitor)
 		}
@@ -69,14 +148,22 @@ func (s *ClusterUrlMonitorReconciler) EnsurePrometheusRuleExists(clusterUrlMonit
 	}
 
 	namespacedName := types.NamespacedName{Namespace: clusterUrlMonitor.Namespace, Name: clusterUrlMonitor.Name}
-	template := alert.TemplateForPrometheusRuleResource(clusterUrl, parsedSlo, namespacedName)
-	err = s.Prom.UpdatePrometheusRuleDeployment(template)
+	template := alert.TemplateForPrometheusRuleResource(clusterUrl, parsedSlo, clusterUrlMonitor.Spec.Slo, clusterUrlMonitor.Spec.CertificateExpiry, clusterUrlMonitor.Spec.ProbeLocations, maintenance.Upcoming(clusterUrlMonitor.Spec.MaintenanceWindows, time.Now()), namespacedName)
+	result, err := s.Prom.UpdatePrometheusRuleDeployment(template)
 	if err != nil {
 		return utilreconcile.RequeueReconcileWith(err)
 	}
+	controllers.RecordOperation(s.Recorder, &clusterUrlMonitor, result, controllers.KindPrometheusRule, namespacedName.String())
 
+	// Replace the previous PrometheusRule once the new one exists
+	if err := controllers.MigratePrometheusRule(s.Prom, s.Recorder, &clusterUrlMonitor, clusterUrlMonitor.Status.PrometheusRuleRef, namespacedName); err != nil {
+		return utilreconcile.RequeueReconcileWith(err)
+	}
 	// Update PrometheusRuleReference in ClusterUrlMonitor if necessary
-	updated, _ := s.Common.SetResourceReference(&clusterUrlMonitor.Status.PrometheusRuleRef, namespacedName)
+	updated := s.Common.SetResourceReference(&clusterUrlMonitor.Status.PrometheusRuleRef, namespacedName)
+	if conditions.MarkTrue(&clusterUrlMonitor, v1alpha1.ConditionPrometheusRuleReady, v1alpha1.ReasonReconciled) {
+		updated = true
+	}
 	if updated {
 		return s.Common.UpdateMonitorResourceStatus(&clusterUrlMonitor)
 	}
@@ -90,16 +177,62 @@ func isClusterVersionAvailable(hcp hypershiftv1beta1.HostedControlPlane) error {
 	return nil
 }
 
+// EnsureURLExists records the URL built from the spec and the cluster domain in the status.
+// The blackbox exporter configuration is rendered from the recorded URL, so it has to be recorded first
+func (s *ClusterUrlMonitorReconciler) EnsureURLExists(clusterUrlMonitor v1alpha1.ClusterUrlMonitor) (utilreconcile.Result, error) {
+	clusterDomain, err := s.GetClusterDomain(clusterUrlMonitor)
+	if err != nil {
+		return utilreconcile.RequeueReconcileWith(err)
+	}
+
+	spec := clusterUrlMonitor.Spec
+	clusterUrl := spec.Prefix + clusterDomain + ":" + spec.Port + spec.Suffix
+	updated := conditions.MarkTrue(&clusterUrlMonitor, v1alpha1.ConditionRouteResolved, v1alpha1.ReasonReconciled)
+	if clusterUrlMonitor.Status.URL != clusterUrl {
+		clusterUrlMonitor.Status.URL = clusterUrl
+		updated = true
+	}
+	if updated {
+		return s.Common.UpdateMonitorResourceStatus(&clusterUrlMonitor)
+	}
+	return utilreconcile.ContinueReconcile()
+}
+
 // Takes care that right ServiceMonitor for the defined ClusterURLMonitor are in place
 func (s *ClusterUrlMonitorReconciler) EnsureServiceMonitorExists(clusterUrlMonitor v1alpha1.ClusterUrlMonitor) (utilreconcile.Result, error) {
-	clusterDomain, err := s.GetClusterDomain(clusterUrlMonitor)
+	// An invalid probe has no module in the blackbox exporter configuration
+	err := blackboxexporter.ValidateProbe(s.Ctx, s.Client, clusterUrlMonitor.Namespace, clusterUrlMonitor.Spec.Probe)
+	var blackBoxExporter types.NamespacedName
+	if err == nil {
+		// Monitors selecting a pool which does not exist are not probed until it is created
+		blackBoxExporter, err = s.BlackBoxExporter.GetBlackBoxExporterForPool(clusterUrlMonitor.Spec.BlackboxExporterPool)
+	}
+	if blackboxexporter.IsInvalidProbe(err) || errors.Is(err, customerrors.ErrBlackboxExporterPoolNotFound) {
+		errorStatusUpdated := s.Common.SetErrorStatus(&clusterUrlMonitor.Status.ErrorStatus, err)
+		if conditions.MarkFalse(&clusterUrlMonitor, v1alpha1.ConditionServiceMonitorReady, v1alpha1.ReasonInvalidSpec, err) {
+			errorStatusUpdated = true
+		}
+		if errorStatusUpdated {
+			return s.Common.UpdateMonitorResourceStatus(&clusterUrlMonitor)
+		}
+		return utilreconcile.StopReconcile()
+	}
 	if err != nil {
 		return utilreconcile.RequeueReconcileWith(err)
 	}
 
+	// Was the URL recorded by a previous step?
+	clusterUrl := clusterUrlMonitor.Status.URL
+	if clusterUrl == "" {
+		return utilreconcile.RequeueReconcileWith(customerrors.ErrNoHost)
+	}
+
 	namespacedName := types.NamespacedName{Name: clusterUrlMonitor.Name, Namespace: clusterUrlMonitor.Namespace}
 	spec := clusterUrlMonitor.Spec
-	clusterUrl := spec.Prefix + clusterDomain + ":" + spec.Port + spec.Suffix
+	target, err := blackboxexporter.ProbeTarget(spec.Probe, clusterUrl)
+	if err != nil {
+		return utilreconcile.RequeueReconcileWith(err)
+	}
 	isHCP := (clusterUrlMonitor.Spec.DomainRef == v1alpha1.ClusterDomainRefHCP)
 	var id string
 	if isHCP {
@@ -124,15 +257,20 @@ func (s *ClusterUrlMonitorReconciler) EnsureServiceMonitorExists(clusterUrlMonit
 	}
 
 	owner := metav1.NewControllerRef(&clusterUrlMonitor.ObjectMeta, clusterUrlMonitor.GroupVersionKind())
-	if err := s.ServiceMonitor.TemplateAndUpdateServiceMonitorDeployment(clusterUrl, s.BlackBoxExporter.GetBlackBoxExporterNamespace(), namespacedName, id, isHCP, false, owner); err != nil {
+	result, err := s.ServiceMonitor.TemplateAndUpdateServiceMonitorDeployment(clusterUrl, servicemonitor.WithLocations([]servicemonitor.Target{{URL: target}}, spec.ProbeLocations), blackBoxExporter, namespacedName, id, isHCP, blackboxexporter.ModuleName(clusterUrlMonitor.Namespace, clusterUrl, spec.Probe, false), owner)
+	if err != nil {
 		return utilreconcile.RequeueReconcileWith(err)
 	}
+	controllers.RecordOperation(s.Recorder, &clusterUrlMonitor, result, controllers.KindServiceMonitor, namespacedName.String())
 
-	// Update RouteMonitor ServiceMonitorRef if required
-	updated, err := s.Common.SetResourceReference(&clusterUrlMonitor.Status.ServiceMonitorRef, namespacedName)
-	if err != nil {
+	// Replace the previous ServiceMonitor once the new one exists and update the ServiceMonitorRef
+	if err := controllers.MigrateServiceMonitor(s.ServiceMonitor, s.Recorder, &clusterUrlMonitor, clusterUrlMonitor.Status.ServiceMonitorRef, namespacedName, isHCP); err != nil {
 		return utilreconcile.RequeueReconcileWith(err)
 	}
+	updated := s.Common.SetResourceReference(&clusterUrlMonitor.Status.ServiceMonitorRef, namespacedName)
+	if conditions.MarkTrue(&clusterUrlMonitor, v1alpha1.ConditionServiceMonitorReady, v1alpha1.ReasonReconciled) {
+		updated = true
+	}
 	if updated {
 		return s.Common.UpdateMonitorResourceStatus(&clusterUrlMonitor)
 	}
@@ -146,24 +284,15 @@ func (s *ClusterUrlMonitorReconciler) EnsureMonitorAndDependenciesAbsent(cluster
 	}
 
 	isHCP := (clusterUrlMonitor.Spec.DomainRef == v1alpha1.ClusterDomainRefHCP)
-	err := s.ServiceMonitor.DeleteServiceMonitorDeployment(clusterUrlMonitor.Status.ServiceMonitorRef, isHCP)
+	deleted, err := s.ServiceMonitor.DeleteServiceMonitorDeployment(clusterUrlMonitor.Status.ServiceMonitorRef, isHCP)
 	if err != nil {
 		return utilreconcile.RequeueReconcileWith(err)
 	}
-
-	shouldDelete, err := s.BlackBoxExporter.ShouldDeleteBlackBoxExporterResources()
-	if err != nil {
-		return utilreconcile.RequeueReconcileWith(err)
-	}
-	if shouldDelete == blackboxexporterconsts.DeleteBlackBoxExporter {
-		err := s.BlackBoxExporter.EnsureBlackBoxExporterResourcesAbsent()
-		if err != nil {
-			return utilreconcile.RequeueReconcileWith(err)
-		}
+	if deleted {
+		controllers.RecordDeletion(s.Recorder, &clusterUrlMonitor, controllers.KindServiceMonitor, clusterUrlMonitor.Status.ServiceMonitorRef.String())
 	}
 
-	err = s.Prom.DeletePrometheusRuleDeployment(clusterUrlMonitor.Status.PrometheusRuleRef)
-	if err != nil {
+	if err := s.deletePrometheusRule(clusterUrlMonitor); err != nil {
 		return utilreconcile.RequeueReconcileWith(err)
 	}
 
@@ -176,6 +305,18 @@ func (s *ClusterUrlMonitorReconciler) EnsureMonitorAndDependenciesAbsent(cluster
 	return utilreconcile.ContinueReconcile()
 }
 
+// deletePrometheusRule deletes the PrometheusRule referenced in the status and records the deletion on the ClusterUrlMonitor
+func (s *ClusterUrlMonitorReconciler) deletePrometheusRule(clusterUrlMonitor v1alpha1.ClusterUrlMonitor) error {
+	deleted, err := s.Prom.DeletePrometheusRuleDeployment(clusterUrlMonitor.Status.PrometheusRuleRef)
+	if err != nil {
+		return err
+	}
+	if deleted {
+		controllers.RecordDeletion(s.Recorder, &clusterUrlMonitor, controllers.KindPrometheusRule, clusterUrlMonitor.Status.PrometheusRuleRef.String())
+	}
+	return nil
+}
+
 func (s *ClusterUrlMonitorReconciler) EnsureFinalizerSet(clusterUrlMonitor v1alpha1.ClusterUrlMonitor) (utilreconcile.Result, error) {
 	if s.Common.SetFinalizer(&clusterUrlMonitor, FinalizerKey) {
 		// ignore the output as we want to remove the PrevFinalizerKey anyways
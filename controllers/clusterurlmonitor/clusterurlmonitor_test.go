@@ -6,14 +6,18 @@ import (
 	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/route-monitor-operator/pkg/util/conditions"
 	customerrors "github.com/openshift/route-monitor-operator/pkg/util/errors"
 	"go.uber.org/mock/gomock"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 
 	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	"github.com/openshift/route-monitor-operator/controllers"
 	"github.com/openshift/route-monitor-operator/controllers/clusterurlmonitor"
-	"github.com/openshift/route-monitor-operator/pkg/consts/blackboxexporter"
 	constinit "github.com/openshift/route-monitor-operator/pkg/consts/test/init"
 	utilreconcile "github.com/openshift/route-monitor-operator/pkg/util/reconcile"
 	clientmocks "github.com/openshift/route-monitor-operator/pkg/util/test/generated/mocks/client"
@@ -29,6 +33,7 @@ var _ = Describe("Clusterurlmonitor", func() {
 		mockCommon           *controllermocks.MockMonitorResourceHandler
 		mockPrometheusRule   *controllermocks.MockPrometheusRuleHandler
 		mockServiceMonitor   *controllermocks.MockServiceMonitorHandler
+		recorder             *record.FakeRecorder
 
 		mockCtrl *gomock.Controller
 
@@ -47,6 +52,7 @@ var _ = Describe("Clusterurlmonitor", func() {
 		mockServiceMonitor = controllermocks.NewMockServiceMonitorHandler(mockCtrl)
 		mockPrometheusRule = controllermocks.NewMockPrometheusRuleHandler(mockCtrl)
 		mockCommon = controllermocks.NewMockMonitorResourceHandler(mockCtrl)
+		recorder = record.NewFakeRecorder(10)
 		clusterUrlMonitor = v1alpha1.ClusterUrlMonitor{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      "fake-clusterurlmonitor",
@@ -67,6 +73,7 @@ var _ = Describe("Clusterurlmonitor", func() {
 			Common:           mockCommon,
 			ServiceMonitor:   mockServiceMonitor,
 			Prom:             mockPrometheusRule,
+			Recorder:         recorder,
 		}
 	})
 
@@ -74,6 +81,43 @@ var _ = Describe("Clusterurlmonitor", func() {
 		mockCtrl.Finish()
 	})
 
+	Describe("EnsureURLExists", func() {
+		infra := configv1.Infrastructure{Status: configv1.InfrastructureStatus{APIServerURL: "https://api.example.com:6443"}}
+		BeforeEach(func() {
+			port = "1337"
+			prefix = "prefix."
+			suffix = "/suffix"
+			mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).SetArg(2, infra) // fetching domain
+		})
+		JustBeforeEach(func() {
+			res, err = reconciler.EnsureURLExists(clusterUrlMonitor)
+		})
+		When("the URL is not recorded yet", func() {
+			BeforeEach(func() {
+				mockCommon.EXPECT().UpdateMonitorResourceStatus(gomock.Any()).Times(1).DoAndReturn(
+					func(monitor *v1alpha1.ClusterUrlMonitor) (utilreconcile.Result, error) {
+						Expect(monitor.Status.URL).To(Equal("prefix.example.com:1337/suffix"))
+						Expect(meta.IsStatusConditionTrue(monitor.Status.Conditions, v1alpha1.ConditionRouteResolved)).To(BeTrue())
+						return utilreconcile.StopReconcile()
+					})
+			})
+			It("records the URL and sets the RouteResolved condition", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res).To(Equal(utilreconcile.StopOperation()))
+			})
+		})
+		When("the URL is already recorded", func() {
+			BeforeEach(func() {
+				clusterUrlMonitor.Status.URL = "prefix.example.com:1337/suffix"
+				conditions.MarkTrue(&clusterUrlMonitor, v1alpha1.ConditionRouteResolved, v1alpha1.ReasonReconciled)
+			})
+			It("continues reconciling", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res).To(Equal(utilreconcile.ContinueOperation()))
+			})
+		})
+	})
+
 	Describe("EnsureServiceMonitorExists", func() {
 		BeforeEach(func() {
 			port = "1337"
@@ -83,15 +127,47 @@ var _ = Describe("Clusterurlmonitor", func() {
 		JustBeforeEach(func() {
 			res, err = reconciler.EnsureServiceMonitorExists(clusterUrlMonitor)
 		})
+		When("the URL is not recorded yet", func() {
+			BeforeEach(func() {
+				mockBlackBoxExporter.EXPECT().GetBlackBoxExporterForPool("").Return(types.NamespacedName{}, nil)
+			})
+			It("requeues with an error", func() {
+				Expect(err).To(Equal(customerrors.ErrNoHost))
+				Expect(res).To(Equal(utilreconcile.RequeueOperation()))
+			})
+		})
+		When("the selected BlackboxExporterPool does not exist", func() {
+			BeforeEach(func() {
+				clusterUrlMonitor.Status.URL = "prefix.example.com:1337/suffix"
+				clusterUrlMonitor.Spec.BlackboxExporterPool = "private"
+				mockBlackBoxExporter.EXPECT().GetBlackBoxExporterForPool("private").Return(types.NamespacedName{}, customerrors.ErrBlackboxExporterPoolNotFound)
+				mockCommon.EXPECT().SetErrorStatus(gomock.Any(), customerrors.ErrBlackboxExporterPoolNotFound).Return(true)
+				mockCommon.EXPECT().UpdateMonitorResourceStatus(gomock.Any()).Times(1).DoAndReturn(
+					func(monitor *v1alpha1.ClusterUrlMonitor) (utilreconcile.Result, error) {
+						condition := meta.FindStatusCondition(monitor.Status.Conditions, v1alpha1.ConditionServiceMonitorReady)
+						Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+						Expect(condition.Reason).To(Equal(v1alpha1.ReasonInvalidSpec))
+						return utilreconcile.StopReconcile()
+					})
+			})
+			It("reports the missing pool and does not update the ServiceMonitor", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res).To(Equal(utilreconcile.StopOperation()))
+			})
+		})
 		When("the ServiceMonitor doesn't exist", func() {
 			BeforeEach(func() {
-				mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(1) // fetching domain
-				mockServiceMonitor.EXPECT().TemplateAndUpdateServiceMonitorDeployment(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
-				mockBlackBoxExporter.EXPECT().GetBlackBoxExporterNamespace().Times(1).Return("")
+				clusterUrlMonitor.Status.URL = "prefix.example.com:1337/suffix"
+				mockServiceMonitor.EXPECT().TemplateAndUpdateServiceMonitorDeployment(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+				mockBlackBoxExporter.EXPECT().GetBlackBoxExporterForPool("").Times(1).Return(types.NamespacedName{}, nil)
 				ns := types.NamespacedName{Name: clusterUrlMonitor.Name, Namespace: clusterUrlMonitor.Namespace}
 				mockCommon.EXPECT().GetOSDClusterID().Times(1)
-				mockCommon.EXPECT().SetResourceReference(&clusterUrlMonitor.Status.ServiceMonitorRef, ns).Times(1).Return(true, nil)
-				mockCommon.EXPECT().UpdateMonitorResourceStatus(&clusterUrlMonitor).Times(1)
+				mockCommon.EXPECT().SetResourceReference(&clusterUrlMonitor.Status.ServiceMonitorRef, ns).Times(1).Return(true)
+				mockCommon.EXPECT().UpdateMonitorResourceStatus(gomock.Any()).Times(1).DoAndReturn(
+					func(monitor *v1alpha1.ClusterUrlMonitor) (utilreconcile.Result, error) {
+						Expect(meta.IsStatusConditionTrue(monitor.Status.Conditions, v1alpha1.ConditionServiceMonitorReady)).To(BeTrue())
+						return utilreconcile.StopReconcile()
+					})
 			})
 			It("creates a ServiceMonitor and updates the ServiceRef", func() {
 				Expect(err).NotTo(HaveOccurred())
@@ -112,6 +188,7 @@ var _ = Describe("Clusterurlmonitor", func() {
 			BeforeEach(func() {
 				mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
 				err := customerrors.ErrInvalidSLO
+				conditions.MarkFalse(&clusterUrlMonitor, v1alpha1.ConditionPrometheusRuleReady, v1alpha1.ReasonInvalidSpec, err)
 				mockCommon.EXPECT().ParseMonitorSLOSpecs(gomock.Any(), clusterUrlMonitor.Spec.Slo).Times(1).Return("", err)
 				mockCommon.EXPECT().SetErrorStatus(&clusterUrlMonitor.Status.ErrorStatus, err)
 				// It deletes old prometheus rule deployment if still there
@@ -130,7 +207,8 @@ var _ = Describe("Clusterurlmonitor", func() {
 				mockCommon.EXPECT().ParseMonitorSLOSpecs(gomock.Any(), clusterUrlMonitor.Spec.Slo).Times(1).Return("99.5", nil)
 				mockCommon.EXPECT().SetErrorStatus(&clusterUrlMonitor.Status.ErrorStatus, nil)
 				mockPrometheusRule.EXPECT().UpdatePrometheusRuleDeployment(gomock.Any()).Times(1)
-				mockCommon.EXPECT().SetResourceReference(&clusterUrlMonitor.Status.PrometheusRuleRef, gomock.Any()).Times(1).Return(false, nil)
+				mockCommon.EXPECT().SetResourceReference(&clusterUrlMonitor.Status.PrometheusRuleRef, gomock.Any()).Times(1).Return(false)
+				conditions.MarkTrue(&clusterUrlMonitor, v1alpha1.ConditionPrometheusRuleReady, v1alpha1.ReasonReconciled)
 			})
 			It("doesn't update the clusterUrlMonitor reference and continues reconciling", func() {
 				Expect(err).NotTo(HaveOccurred())
@@ -145,8 +223,8 @@ var _ = Describe("Clusterurlmonitor", func() {
 				mockCommon.EXPECT().SetErrorStatus(&clusterUrlMonitor.Status.ErrorStatus, nil)
 				mockPrometheusRule.EXPECT().UpdatePrometheusRuleDeployment(gomock.Any()).Times(1)
 				ns := types.NamespacedName{Name: clusterUrlMonitor.Name, Namespace: clusterUrlMonitor.Namespace}
-				mockCommon.EXPECT().SetResourceReference(&clusterUrlMonitor.Status.PrometheusRuleRef, ns).Times(1).Return(true, nil)
-				mockCommon.EXPECT().UpdateMonitorResourceStatus(&clusterUrlMonitor).Times(1).Return(utilreconcile.StopOperation(), nil)
+				mockCommon.EXPECT().SetResourceReference(&clusterUrlMonitor.Status.PrometheusRuleRef, ns).Times(1).Return(true)
+				mockCommon.EXPECT().UpdateMonitorResourceStatus(gomock.Any()).Times(1).Return(utilreconcile.StopOperation(), nil)
 			})
 
 			It("should create one and update the clusterURLMonitor", func() {
@@ -155,6 +233,42 @@ var _ = Describe("Clusterurlmonitor", func() {
 				Expect(res).To(Equal(utilreconcile.StopOperation()))
 			})
 		})
+		When("the PrometheusRuleRef points to a previous PrometheusRule", func() {
+			previousRef := v1alpha1.NamespacedName{Name: "previous", Namespace: "fake-namespace"}
+			newRef := v1alpha1.NamespacedName{Name: "fake-clusterurlmonitor", Namespace: "fake-namespace"}
+			BeforeEach(func() {
+				clusterUrlMonitor.Status.PrometheusRuleRef = previousRef
+				mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+				mockCommon.EXPECT().ParseMonitorSLOSpecs(gomock.Any(), clusterUrlMonitor.Spec.Slo).Times(1).Return("99.5", nil)
+				mockCommon.EXPECT().SetErrorStatus(&clusterUrlMonitor.Status.ErrorStatus, nil)
+				mockPrometheusRule.EXPECT().UpdatePrometheusRuleDeployment(gomock.Any()).Times(1)
+			})
+			When("the new PrometheusRule does not exist yet", func() {
+				BeforeEach(func() {
+					mockPrometheusRule.EXPECT().PrometheusRuleExists(newRef).Times(1).Return(false, nil)
+				})
+				It("keeps the previous PrometheusRule and requeues", func() {
+					Expect(err).To(Equal(customerrors.ErrReferenceMigrationPending))
+					Expect(res).To(Equal(utilreconcile.RequeueOperation()))
+				})
+			})
+			When("the new PrometheusRule exists", func() {
+				BeforeEach(func() {
+					gomock.InOrder(
+						mockPrometheusRule.EXPECT().PrometheusRuleExists(newRef).Times(1).Return(true, nil),
+						mockPrometheusRule.EXPECT().DeletePrometheusRuleDeployment(previousRef).Times(1),
+					)
+					ns := types.NamespacedName{Name: clusterUrlMonitor.Name, Namespace: clusterUrlMonitor.Namespace}
+					mockCommon.EXPECT().SetResourceReference(&clusterUrlMonitor.Status.PrometheusRuleRef, ns).Times(1).Return(true)
+					mockCommon.EXPECT().UpdateMonitorResourceStatus(gomock.Any()).Times(1).Return(utilreconcile.StopOperation(), nil)
+				})
+				It("deletes the previous PrometheusRule and records the migration", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(res).To(Equal(utilreconcile.StopOperation()))
+					Expect(recorder.Events).To(Receive(ContainSubstring(controllers.ReasonPrometheusRuleMigrated)))
+				})
+			})
+		})
 	})
 
 	Describe("EnsureDeletionProcessed", func() {
@@ -182,7 +296,7 @@ var _ = Describe("Clusterurlmonitor", func() {
 			When("the ServiceMonitor still exists", func() {
 				BeforeEach(func() {
 					mockPrometheusRule.EXPECT().DeletePrometheusRuleDeployment(clusterUrlMonitor.Status.PrometheusRuleRef).Times(1)
-					mockServiceMonitor.EXPECT().DeleteServiceMonitorDeployment(clusterUrlMonitor.Status.ServiceMonitorRef, gomock.Any()).Times(1)
+					mockServiceMonitor.EXPECT().DeleteServiceMonitorDeployment(clusterUrlMonitor.Status.ServiceMonitorRef, gomock.Any()).Times(1).Return(true, nil)
 					gomock.InOrder(
 						mockCommon.EXPECT().DeleteFinalizer(&clusterUrlMonitor, clusterurlmonitor.FinalizerKey).Times(1).Return(true),
 						mockCommon.EXPECT().DeleteFinalizer(&clusterUrlMonitor, clusterurlmonitor.PrevFinalizerKey).Times(1),
@@ -190,25 +304,11 @@ var _ = Describe("Clusterurlmonitor", func() {
 					mockCommon.EXPECT().UpdateMonitorResource(&clusterUrlMonitor).Return(utilreconcile.StopOperation(), nil)
 
 				})
-				When("the blackboxexporter needs to be cleaned up", func() {
-					BeforeEach(func() {
-						mockBlackBoxExporter.EXPECT().ShouldDeleteBlackBoxExporterResources().Return(blackboxexporter.DeleteBlackBoxExporter, nil)
-						mockBlackBoxExporter.EXPECT().EnsureBlackBoxExporterResourcesAbsent().Times(1)
-					})
-					It("removes the servicemonitor, the blackbox exporter and cleans up the finalizer", func() {
-						Expect(err).NotTo(HaveOccurred())
-						Expect(res).To(Equal(utilreconcile.StopOperation()))
-					})
-				})
-
-				When("the blackboxexporter doesn't need to be cleaned up", func() {
-					BeforeEach(func() {
-						mockBlackBoxExporter.EXPECT().ShouldDeleteBlackBoxExporterResources().Return(blackboxexporter.KeepBlackBoxExporter, nil)
-					})
-					It("removes the servicemonitor and cleans up the finalizer", func() {
-						Expect(err).NotTo(HaveOccurred())
-						Expect(res).To(Equal(utilreconcile.StopOperation()))
-					})
+				It("removes the servicemonitor and cleans up the finalizer, leaving the blackbox exporter to its controller", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(res).To(Equal(utilreconcile.StopOperation()))
+					Expect(recorder.Events).To(Receive(ContainSubstring("ServiceMonitorDeleted")))
+					Expect(recorder.Events).NotTo(Receive(ContainSubstring("BlackBoxExporterDeleted")))
 				})
 			})
 		})
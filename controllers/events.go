@@ -0,0 +1,61 @@
+package controllers
+
+import (
+	"github.com/openshift/route-monitor-operator/pkg/metrics"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// The kinds of the resources the reconcilers manage on behalf of a monitor, they prefix the reasons of the events
+const (
+	KindServiceMonitor   = "ServiceMonitor"
+	KindPrometheusRule   = "PrometheusRule"
+	KindBlackBoxExporter = "BlackBoxExporter"
+)
+
+const (
+	// ReasonServiceMonitorMigrated is the reason of the event recorded when the ServiceMonitor of a monitor was replaced
+	ReasonServiceMonitorMigrated = "ServiceMonitorMigrated"
+	// ReasonPrometheusRuleMigrated is the reason of the event recorded when the PrometheusRule of a monitor was replaced
+	ReasonPrometheusRuleMigrated = "PrometheusRuleMigrated"
+	// ReasonInvalidAnnotation is the reason of the warning recorded when a routemonitor.openshift.io annotation of a Route is invalid
+	ReasonInvalidAnnotation = "InvalidAnnotation"
+	// ReasonReconcileFailed is the reason of the warning recorded when a reconciliation is requeued because of an error
+	ReasonReconcileFailed = "ReconcileFailed"
+)
+
+// RecordOperation records a Normal event on the object and the operation metric if the resource of the kind
+// was created or updated, e.g. with the reason ServiceMonitorCreated. Nothing is recorded when the resource was unchanged
+func RecordOperation(recorder record.EventRecorder, object runtime.Object, result controllerutil.OperationResult, kind, name string) {
+	switch result {
+	case controllerutil.OperationResultCreated:
+		recorder.Eventf(object, corev1.EventTypeNormal, kind+"Created", "Created the %s %s", kind, name)
+		recordOperationMetric(kind, metrics.OperationCreated)
+	case controllerutil.OperationResultUpdated:
+		recorder.Eventf(object, corev1.EventTypeNormal, kind+"Updated", "Updated the %s %s", kind, name)
+		recordOperationMetric(kind, metrics.OperationUpdated)
+	}
+}
+
+// RecordDeletion records a Normal event on the object and the operation metric for the deletion of the resource of the kind,
+// e.g. with the reason ServiceMonitorDeleted
+func RecordDeletion(recorder record.EventRecorder, object runtime.Object, kind, name string) {
+	recorder.Eventf(object, corev1.EventTypeNormal, kind+"Deleted", "Deleted the %s %s", kind, name)
+	recordOperationMetric(kind, metrics.OperationDeleted)
+}
+
+// recordOperationMetric counts the operation on the blackbox exporter separately from the ones on the monitoring resources
+func recordOperationMetric(kind, operation string) {
+	if kind == KindBlackBoxExporter {
+		metrics.RecordBlackBoxExporterEvent(operation)
+		return
+	}
+	metrics.RecordResourceOperation(kind, operation)
+}
+
+// RecordRequeue records a Warning event on the object for the error the reconciliation is requeued with
+func RecordRequeue(recorder record.EventRecorder, object runtime.Object, message string, err error) {
+	recorder.Eventf(object, corev1.EventTypeWarning, ReasonReconcileFailed, "%s: %v", message, err)
+}
@@ -19,7 +19,6 @@ package hostedcontrolplane
 import (
 	"context"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -28,7 +27,9 @@ import (
 	hypershiftv1beta1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
 	"github.com/openshift/route-monitor-operator/api/v1alpha1"
 	"github.com/openshift/route-monitor-operator/config"
+	"github.com/openshift/route-monitor-operator/controllers"
 	"github.com/openshift/route-monitor-operator/pkg/dynatrace"
+	"github.com/openshift/route-monitor-operator/pkg/operatorconfig"
 	"github.com/openshift/route-monitor-operator/pkg/rhobs"
 	"github.com/openshift/route-monitor-operator/pkg/util/finalizer"
 	utilreconcile "github.com/openshift/route-monitor-operator/pkg/util/reconcile"
@@ -39,6 +40,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -80,8 +82,13 @@ const (
 	// probes when triggered by HCP events (create/update/delete).
 	periodicReconcileInterval = 10 * time.Minute
 
-	// ConfigMap name for dynamic configuration (uses config.OperatorName + "-config")
-	configMapName = config.OperatorName + "-config"
+	// Deprecated ConfigMap for dynamic configuration, superseded by the RouteMonitorOperatorConfig
+	configMapName = operatorconfig.ConfigMapName
+
+	// Reasons of the events recorded on the HostedControlPlane
+	reasonRHOBSProbeDeleted          = "RHOBSProbeDeleted"
+	reasonRHOBSProbeDeletionTimedOut = "RHOBSProbeDeletionTimedOut"
+	reasonKubeAPIServerUnreachable   = "KubeAPIServerUnreachable"
 )
 
 var logger logr.Logger = ctrl.Log.WithName("controllers").WithName("HostedControlPlane")
@@ -91,6 +98,7 @@ type HostedControlPlaneReconciler struct {
 	client.Client
 	Scheme      *runtime.Scheme
 	RHOBSConfig RHOBSConfig
+	Recorder    record.EventRecorder
 }
 
 // NewHostedControlPlaneReconciler creates a HostedControlPlaneReconciler
@@ -99,68 +107,50 @@ func NewHostedControlPlaneReconciler(mgr manager.Manager, rhobsConfig RHOBSConfi
 		Client:      mgr.GetClient(),
 		Scheme:      mgr.GetScheme(),
 		RHOBSConfig: rhobsConfig,
+		Recorder:    mgr.GetEventRecorderFor("hostedcontrolplane-controller"),
 	}
 }
 
-// getRHOBSConfig reads RHOBS and Dynatrace configuration from the ConfigMap at reconcile time.
-// If the ConfigMap doesn't exist or has empty values, it falls back to the command-line
-// flags stored in r.RHOBSConfig.
+// getRHOBSConfig resolves the RHOBS and Dynatrace configuration at reconcile time, so changes of the
+// RouteMonitorOperatorConfig or the deprecated ConfigMap are picked up without restarting the operator.
+// Values which are not set or rejected fall back to the command-line flags stored in r.RHOBSConfig,
+// the rejected values are reported in the status of the RouteMonitorOperatorConfig.
 func (r *HostedControlPlaneReconciler) getRHOBSConfig(ctx context.Context) (RHOBSConfig, DynatraceConfig) {
-	configMap := &corev1.ConfigMap{}
-	err := r.Get(ctx, types.NamespacedName{
-		Name:      configMapName,
-		Namespace: config.OperatorNamespace,
-	}, configMap)
-
+	loader := operatorconfig.Loader{Client: r.Client, Flags: operatorconfig.Config{
+		ProbeAPIURL:                   r.RHOBSConfig.ProbeAPIURL,
+		ProbeTenant:                   r.RHOBSConfig.Tenant,
+		OIDCClientID:                  r.RHOBSConfig.OIDCClientID,
+		OIDCClientSecret:              r.RHOBSConfig.OIDCClientSecret,
+		OIDCIssuerURL:                 r.RHOBSConfig.OIDCIssuerURL,
+		OnlyPublicClusters:            r.RHOBSConfig.OnlyPublicClusters,
+		SkipInfrastructureHealthCheck: r.RHOBSConfig.SkipInfrastructureHealthCheck,
+		ReconcileInterval:             r.RHOBSConfig.ReconcileInterval,
+	}}
+	result, err := loader.Load(ctx)
 	if err != nil {
-		// ConfigMap not found or error - return fallback config from command-line flags
-		if !kerr.IsNotFound(err) {
-			logger.V(2).Info("Failed to read ConfigMap, using fallback config", "error", err.Error())
-		}
-		return r.RHOBSConfig, DynatraceConfig{Enabled: false}
-	}
-
-	// Merge ConfigMap values with fallback defaults
-	cfg := r.RHOBSConfig
-	if v := strings.TrimSpace(configMap.Data["probe-api-url"]); v != "" {
-		cfg.ProbeAPIURL = v
-	}
-	if v := strings.TrimSpace(configMap.Data["probe-tenant"]); v != "" {
-		cfg.Tenant = v
-	}
-	if v := strings.TrimSpace(configMap.Data["oidc-client-id"]); v != "" {
-		cfg.OIDCClientID = v
-	}
-	if v := strings.TrimSpace(configMap.Data["oidc-client-secret"]); v != "" {
-		cfg.OIDCClientSecret = v
-	}
-	if v := strings.TrimSpace(configMap.Data["oidc-issuer-url"]); v != "" {
-		cfg.OIDCIssuerURL = v
-	}
-	if strings.TrimSpace(configMap.Data["only-public-clusters"]) == "true" {
-		cfg.OnlyPublicClusters = true
-	}
-	if strings.TrimSpace(configMap.Data["skip-infrastructure-health-check"]) == "true" {
-		cfg.SkipInfrastructureHealthCheck = true
-	}
-	if v := strings.TrimSpace(configMap.Data["reconcile-interval"]); v != "" {
-		if d, err := time.ParseDuration(v); err == nil && d >= 10*time.Second {
-			cfg.ReconcileInterval = d
-		}
-	}
-
-	// Read Dynatrace configuration - defaults to disabled
-	dynatraceConfig := DynatraceConfig{Enabled: false}
-	if strings.TrimSpace(configMap.Data["dynatrace-enabled"]) == "true" {
-		dynatraceConfig.Enabled = true
-	}
-
-	return cfg, dynatraceConfig
+		// the configuration is resolved from the sources which could be read
+		logger.V(2).Info("Failed to read the operator configuration, using fallback config", "error", err.Error())
+	}
+
+	cfg := result.Config
+	rhobsConfig := RHOBSConfig{
+		ProbeAPIURL:                   cfg.ProbeAPIURL,
+		Tenant:                        cfg.ProbeTenant,
+		OIDCClientID:                  cfg.OIDCClientID,
+		OIDCClientSecret:              cfg.OIDCClientSecret,
+		OIDCIssuerURL:                 cfg.OIDCIssuerURL,
+		OnlyPublicClusters:            cfg.OnlyPublicClusters,
+		SkipInfrastructureHealthCheck: cfg.SkipInfrastructureHealthCheck,
+		ReconcileInterval:             cfg.ReconcileInterval,
+	}
+	return rhobsConfig, DynatraceConfig{Enabled: cfg.DynatraceEnabled}
 }
 
 //+kubebuilder:rbac:groups=openshift.io,resources=hostedcontrolplanes,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=openshift.io,resources=hostedcontrolplanes/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=openshift.io,resources=hostedcontrolplanes/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups=monitoring.openshift.io,resources=routemonitoroperatorconfigs,verbs=get;list;watch
 
 // Reconcile responds to events against watched objects
 func (r *HostedControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -194,7 +184,7 @@ func (r *HostedControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 				log.Info("Dynatrace client creation failed, continuing with RHOBS-only monitoring", "error", err.Error())
 			} else {
 				log.Error(err, "failed to create dynatrace client")
-				return utilreconcile.RequeueWith(err)
+				return r.requeueWithEvent(hostedcontrolplane, "Failed to create the Dynatrace client", err)
 			}
 		} else {
 			dynatraceApiClient = client
@@ -215,7 +205,7 @@ func (r *HostedControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 					log.Info("Dynatrace HTTP Monitor deletion failed, continuing with RHOBS probe deletion", "error", err.Error())
 				} else {
 					log.Error(err, "failed to delete Dynatrace HTTP Monitor Resources")
-					return utilreconcile.RequeueWith(err)
+					return r.requeueWithEvent(hostedcontrolplane, "Failed to delete the Dynatrace HTTP monitor", err)
 				}
 			}
 		}
@@ -246,7 +236,7 @@ func (r *HostedControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 					if rhobs.IsNon200Error(err) {
 						return utilreconcile.RequeueAfter(rhobsAPIRetryTimeout), nil
 					}
-					return utilreconcile.RequeueWith(err)
+					return r.requeueWithEvent(hostedcontrolplane, "Failed to delete the RHOBS probe", err)
 				} else {
 					// Past timeout window - fail open to allow cluster deletion
 					log.Error(err, "Failed to delete RHOBS probe but deletion timeout exceeded, allowing cluster deletion to proceed",
@@ -256,10 +246,14 @@ func (r *HostedControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 						"behavior", "fail_open",
 						"note", "Orphaned probe may require manual cleanup via synthetics-api or will be cleaned up when API is restored")
 					rhobs.RecordProbeDeletionTimeout()
+					r.Recorder.Eventf(hostedcontrolplane, corev1.EventTypeWarning, reasonRHOBSProbeDeletionTimedOut,
+						"Failed to delete the RHOBS probe of the cluster %s within %s, it may require a manual cleanup: %v", hostedcontrolplane.Spec.ClusterID, rhobsProbeDeletionTimeout, err)
 					// Continue with deletion (do not return error)
 				}
 			} else {
 				log.Info("Successfully deleted RHOBS probe", "cluster_id", hostedcontrolplane.Spec.ClusterID)
+				r.Recorder.Eventf(hostedcontrolplane, corev1.EventTypeNormal, reasonRHOBSProbeDeleted,
+					"Deleted the RHOBS probe of the cluster %s", hostedcontrolplane.Spec.ClusterID)
 			}
 		} else {
 			// SREP-2832: Log warning if RHOBS API URL is not configured during deletion
@@ -270,12 +264,12 @@ func (r *HostedControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 		err := r.finalizeHostedControlPlane(ctx, log, hostedcontrolplane)
 		if err != nil {
 			log.Error(err, "failed to finalize HostedControlPlane")
-			return utilreconcile.RequeueWith(err)
+			return r.requeueWithEvent(hostedcontrolplane, "Failed to delete the internal monitoring objects", err)
 		}
 		finalizer.Remove(hostedcontrolplane, hostedcontrolplaneFinalizer)
 		err = r.Update(ctx, hostedcontrolplane)
 		if err != nil {
-			return utilreconcile.RequeueWith(err)
+			return r.requeueWithEvent(hostedcontrolplane, "Failed to remove the finalizer", err)
 		}
 		return utilreconcile.Stop()
 	}
@@ -284,7 +278,7 @@ func (r *HostedControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 		finalizer.Add(hostedcontrolplane, hostedcontrolplaneFinalizer)
 		err := r.Update(ctx, hostedcontrolplane)
 		if err != nil {
-			return utilreconcile.RequeueWith(err)
+			return r.requeueWithEvent(hostedcontrolplane, "Failed to set the finalizer", err)
 		}
 	}
 
@@ -303,7 +297,7 @@ func (r *HostedControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 	hcpReady, err := r.hcpReady(ctx, hostedcontrolplane, rhobsConfig)
 	if err != nil {
 		log.Error(err, "HCP readiness check failed")
-		return utilreconcile.RequeueWith(fmt.Errorf("HCP readiness check failed: %v", err))
+		return r.requeueWithEvent(hostedcontrolplane, "HCP readiness check failed", err)
 	}
 	if !hcpReady {
 		log.Info("skipped deploying monitoring objects, HostedControlPlane not yet ready")
@@ -313,7 +307,7 @@ func (r *HostedControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 	vpcEndpointReady, err := r.isVpcEndpointReady(ctx, hostedcontrolplane, rhobsConfig)
 	if err != nil {
 		log.Error(err, "VPC Endpoint check failed")
-		return utilreconcile.RequeueWith(err)
+		return r.requeueWithEvent(hostedcontrolplane, "VPC Endpoint check failed", err)
 	}
 	if !vpcEndpointReady {
 		log.Info("VPC Endpoint is not ready, delaying HTTP Monitor deployment")
@@ -333,8 +327,10 @@ func (r *HostedControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 		}
 		if err := isKubeAPIServerReachable(hostedcontrolplane, apiServerPort); err != nil {
 			log.Info("kube-apiserver TLS not ready, removing monitoring objects", "error", err.Error())
+			r.Recorder.Eventf(hostedcontrolplane, corev1.EventTypeWarning, reasonKubeAPIServerUnreachable,
+				"Removing the internal monitoring objects, the kube-apiserver TLS is not ready: %v", err)
 			if deleteErr := r.deleteInternalMonitoringObjects(ctx, log, hostedcontrolplane); deleteErr != nil {
-				return utilreconcile.RequeueWith(fmt.Errorf("failed to remove internal monitoring objects while kube-apiserver TLS is unavailable: %w", deleteErr))
+				return r.requeueWithEvent(hostedcontrolplane, "Failed to remove the internal monitoring objects while kube-apiserver TLS is unavailable", deleteErr)
 			}
 			return utilreconcile.RequeueAfter(healthcheckIntervalSeconds * time.Second), nil
 		}
@@ -345,7 +341,7 @@ func (r *HostedControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 	err = r.deployInternalMonitoringObjects(ctx, log, hostedcontrolplane, rhobsConfig)
 	if err != nil {
 		log.Error(err, "failed to deploy internal monitoring components")
-		return utilreconcile.RequeueWith(err)
+		return r.requeueWithEvent(hostedcontrolplane, "Failed to deploy the internal monitoring objects", err)
 	}
 
 	// Only attempt Dynatrace deployment if Dynatrace is enabled and client was successfully created
@@ -358,7 +354,7 @@ func (r *HostedControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 				log.Info("Dynatrace HTTP Monitor deployment failed, continuing with RHOBS probe deployment", "error", err.Error())
 			} else {
 				log.Error(err, "failed to deploy Dynatrace HTTP Monitor Resources")
-				return utilreconcile.RequeueWith(err)
+				return r.requeueWithEvent(hostedcontrolplane, "Failed to deploy the Dynatrace HTTP monitor", err)
 			}
 		}
 	}
@@ -373,7 +369,7 @@ func (r *HostedControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 			if rhobs.IsNon200Error(err) {
 				return utilreconcile.RequeueAfter(rhobsAPIRetryTimeout), nil
 			}
-			return utilreconcile.RequeueWith(err)
+			return r.requeueWithEvent(hostedcontrolplane, "Failed to deploy the RHOBS probe", err)
 		}
 	}
 
@@ -387,6 +383,12 @@ func (r *HostedControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 	return ctrl.Result{RequeueAfter: interval}, err
 }
 
+// requeueWithEvent records the error of a reconcile step as warning event on the HostedControlPlane before requeueing
+func (r *HostedControlPlaneReconciler) requeueWithEvent(hostedcontrolplane *hypershiftv1beta1.HostedControlPlane, message string, err error) (ctrl.Result, error) {
+	controllers.RecordRequeue(r.Recorder, hostedcontrolplane, message, err)
+	return utilreconcile.RequeueWith(err)
+}
+
 // getKubeAPIServerPort resolves the kube-apiserver Service port. Used by both the
 // TLS reachability check and RouteMonitor creation to ensure both use the same port.
 func (r *HostedControlPlaneReconciler) getKubeAPIServerPort(ctx context.Context, hostedcontrolplane *hypershiftv1beta1.HostedControlPlane) (int64, error) {
@@ -593,22 +595,13 @@ func (r *HostedControlPlaneReconciler) SetupWithManager(mgr ctrl.Manager) error
 		return fmt.Errorf("failed to build label selector predicate for routes: %w", err)
 	}
 
-	// Create handler that requeues all HCPs when ConfigMap changes
-	configMapHandler := handler.EnqueueRequestsFromMapFunc(
+	// Create handler that requeues all HCPs when the configuration changes
+	configHandler := handler.EnqueueRequestsFromMapFunc(
 		func(ctx context.Context, obj client.Object) []reconcile.Request {
-			cm, ok := obj.(*corev1.ConfigMap)
-			if !ok {
-				return nil
-			}
-			// Only react to our config ConfigMap
-			if cm.Name != configMapName || cm.Namespace != config.OperatorNamespace {
-				return nil
-			}
-
 			// List all HostedControlPlanes and requeue them
 			hcpList := &hypershiftv1beta1.HostedControlPlaneList{}
 			if err := r.List(ctx, hcpList); err != nil {
-				logger.Error(err, "failed to list HostedControlPlanes for ConfigMap change requeue")
+				logger.Error(err, "failed to list HostedControlPlanes for configuration change requeue")
 				return nil
 			}
 
@@ -621,8 +614,8 @@ func (r *HostedControlPlaneReconciler) SetupWithManager(mgr ctrl.Manager) error
 					},
 				})
 			}
-			logger.Info("ConfigMap changed, requeuing HostedControlPlanes",
-				"configmap", cm.Name, "count", len(requests))
+			logger.Info("Configuration changed, requeuing HostedControlPlanes",
+				"kind", fmt.Sprintf("%T", obj), "name", obj.GetName(), "count", len(requests))
 			return requests
 		},
 	)
@@ -637,7 +630,8 @@ func (r *HostedControlPlaneReconciler) SetupWithManager(mgr ctrl.Manager) error
 	// - Additionally watches against route & routemonitor objects with the 'watchResourceLabel' present.
 	//   When these objects are modified, the HCP specified in the objects' .metadata.OwnerReferences is
 	//   reconciled
-	// - Watches the operator ConfigMap to requeue all HCPs when configuration changes
+	// - Watches the RouteMonitorOperatorConfig and the deprecated operator ConfigMap to requeue all HCPs when
+	//   the configuration changes
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&hypershiftv1beta1.HostedControlPlane{}).
 		Watches(
@@ -652,8 +646,15 @@ func (r *HostedControlPlaneReconciler) SetupWithManager(mgr ctrl.Manager) error
 		).
 		Watches(
 			&corev1.ConfigMap{},
-			configMapHandler,
+			configHandler,
 			builder.WithPredicates(configMapPredicate),
 		).
+		Watches(
+			&v1alpha1.RouteMonitorOperatorConfig{},
+			configHandler,
+			builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+				return obj.GetName() == v1alpha1.RouteMonitorOperatorConfigName
+			})),
+		).
 		Complete(r)
 }
@@ -19,6 +19,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -748,8 +749,9 @@ func newTestReconciler(t *testing.T, objs ...client.Object) *HostedControlPlaneR
 	client := fake.NewClientBuilder().WithScheme(s).WithObjects(objs...).Build()
 
 	r := &HostedControlPlaneReconciler{
-		Client: client,
-		Scheme: s,
+		Client:   client,
+		Scheme:   s,
+		Recorder: &record.FakeRecorder{},
 	}
 	return r
 }
@@ -1088,10 +1090,37 @@ func TestReconcile_HCPAvailableGate(t *testing.T) {
 	}
 }
 
+func TestHostedControlPlaneReconciler_requeueWithEvent(t *testing.T) {
+	hcp := &hypershiftv1beta1.HostedControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-hcp",
+			Namespace: "test-namespace",
+		},
+	}
+	recorder := record.NewFakeRecorder(1)
+	r := newTestReconciler(t, hcp)
+	r.Recorder = recorder
+
+	_, err := r.requeueWithEvent(hcp, "Failed to deploy the RHOBS probe", fmt.Errorf("connection refused"))
+	if err == nil {
+		t.Error("expected the error to be returned")
+	}
+	select {
+	case event := <-recorder.Events:
+		expected := "Warning ReconcileFailed Failed to deploy the RHOBS probe: connection refused"
+		if event != expected {
+			t.Errorf("expected event %q, got %q", expected, event)
+		}
+	default:
+		t.Error("expected a warning event to be recorded")
+	}
+}
+
 func TestHostedControlPlaneReconciler_getRHOBSConfig(t *testing.T) {
 	ctx := context.Background()
 
 	// Fallback config from command-line flags
+	dynatraceDisabled := false
 	fallbackConfig := RHOBSConfig{
 		ProbeAPIURL:        "https://fallback-api.example.com/probes",
 		Tenant:             "fallback-tenant",
@@ -1104,6 +1133,7 @@ func TestHostedControlPlaneReconciler_getRHOBSConfig(t *testing.T) {
 	tests := []struct {
 		name              string
 		configMap         *corev1.ConfigMap
+		operatorConfig    *v1alpha1.RouteMonitorOperatorConfig
 		fallbackConfig    RHOBSConfig
 		expectedRHOBS     RHOBSConfig
 		expectedDynatrace DynatraceConfig
@@ -1270,6 +1300,54 @@ func TestHostedControlPlaneReconciler_getRHOBSConfig(t *testing.T) {
 			expectedRHOBS:     fallbackConfig,
 			expectedDynatrace: DynatraceConfig{Enabled: false}, // Invalid value defaults to disabled
 		},
+		{
+			name: "ConfigMap with a reconcile interval below the minimum - uses fallback config",
+			configMap: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      configMapName,
+					Namespace: config.OperatorNamespace,
+				},
+				Data: map[string]string{
+					"reconcile-interval": "5s",
+				},
+			},
+			fallbackConfig:    fallbackConfig,
+			expectedRHOBS:     fallbackConfig,
+			expectedDynatrace: DynatraceConfig{Enabled: false},
+		},
+		{
+			name: "RouteMonitorOperatorConfig present - overrides ConfigMap and fallback",
+			configMap: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      configMapName,
+					Namespace: config.OperatorNamespace,
+				},
+				Data: map[string]string{
+					"probe-api-url":      "https://configmap-api.example.com/probes",
+					"probe-tenant":       "configmap-tenant",
+					"reconcile-interval": "1m",
+					"dynatrace-enabled":  "true",
+				},
+			},
+			operatorConfig: &v1alpha1.RouteMonitorOperatorConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: v1alpha1.RouteMonitorOperatorConfigName},
+				Spec: v1alpha1.RouteMonitorOperatorConfigSpec{
+					ProbeAPIURL:       "https://config-api.example.com/probes",
+					ReconcileInterval: &metav1.Duration{Duration: 2 * time.Minute},
+					DynatraceEnabled:  &dynatraceDisabled,
+				},
+			},
+			fallbackConfig: fallbackConfig,
+			expectedRHOBS: RHOBSConfig{
+				ProbeAPIURL:       "https://config-api.example.com/probes",
+				Tenant:            "configmap-tenant",
+				OIDCClientID:      "fallback-client-id",
+				OIDCClientSecret:  "fallback-secret",
+				OIDCIssuerURL:     "https://fallback-issuer.example.com",
+				ReconcileInterval: 2 * time.Minute,
+			},
+			expectedDynatrace: DynatraceConfig{Enabled: false},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1278,6 +1356,9 @@ func TestHostedControlPlaneReconciler_getRHOBSConfig(t *testing.T) {
 			if tt.configMap != nil {
 				objs = append(objs, tt.configMap)
 			}
+			if tt.operatorConfig != nil {
+				objs = append(objs, tt.operatorConfig)
+			}
 
 			r := newTestReconciler(t, objs...)
 			r.RHOBSConfig = tt.fallbackConfig
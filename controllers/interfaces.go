@@ -1,15 +1,18 @@
 package controllers
 
 import (
+	"time"
+
 	hypershiftv1beta1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
 	"github.com/openshift/route-monitor-operator/api/v1alpha1"
-	"github.com/openshift/route-monitor-operator/pkg/consts/blackboxexporter"
+	"github.com/openshift/route-monitor-operator/pkg/servicemonitor"
 	utilreconcile "github.com/openshift/route-monitor-operator/pkg/util/reconcile"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	rhobsv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 //go:generate mockgen -source $GOFILE -destination ../pkg/util/test/generated/mocks/$GOPACKAGE/interfaces.go -package $GOPACKAGE
@@ -28,8 +31,9 @@ type MonitorResourceHandler interface {
 
 	// SetResourceReference updates the ResourceRef in the Monitor Resources
 	// It receives a pointer to the ref string within the monitor resource
-	// In case the reference has been changed it returns true as a boolean
-	SetResourceReference(reference *v1alpha1.NamespacedName, target types.NamespacedName) (bool, error)
+	// In case the reference has been changed it returns true as a boolean.
+	// A reference to a previous resource has to be migrated first, see MigrateServiceMonitor and MigratePrometheusRule
+	SetResourceReference(reference *v1alpha1.NamespacedName, target types.NamespacedName) bool
 
 	// UpdateMonitorResource updates the Spec of the ClusterURLMonitor & RouteMonitor CR
 	// Should be called after object that triggered reconcile loop has been changed
@@ -58,34 +62,53 @@ type MonitorResourceHandler interface {
 type ServiceMonitorHandler interface {
 	// UpdateServiceMonitorDeployment ensures that a ServiceMonitor deployment according
 	// to the template exists. If none exists, it will create a new one.
-	// If the template changed, it will update the existing deployment
-	UpdateServiceMonitorDeployment(template monitoringv1.ServiceMonitor) error
+	// If the template changed, it will update the existing deployment.
+	// It returns whether the ServiceMonitor was created or updated
+	UpdateServiceMonitorDeployment(template monitoringv1.ServiceMonitor) (controllerutil.OperationResult, error)
 
 	// TemplateAndUpdateServiceMonitorDeployment will generate a template and then
 	// call UpdateServiceMonitorDeployment to ensure its current state matches the template.
-	TemplateAndUpdateServiceMonitorDeployment(url, blackBoxExporterNamespace string, namespacedName types.NamespacedName, clusterID string, hcp bool, useInsecure bool, owner *metav1.OwnerReference) error
+	// Every target is probed by a separate endpoint of the blackbox exporter
+	TemplateAndUpdateServiceMonitorDeployment(url string, targets []servicemonitor.Target, blackBoxExporter types.NamespacedName, namespacedName types.NamespacedName, clusterID string, hcp bool, module string, owner *metav1.OwnerReference) (controllerutil.OperationResult, error)
 
 	// DeleteServiceMonitorDeployment deletes a ServiceMonitor refrenced by a namespaced name
-	DeleteServiceMonitorDeployment(serviceMonitorRef v1alpha1.NamespacedName, hcp bool) error
+	// It returns whether the ServiceMonitor existed
+	DeleteServiceMonitorDeployment(serviceMonitorRef v1alpha1.NamespacedName, hcp bool) (bool, error)
+
+	// ServiceMonitorExists returns whether the ServiceMonitor refrenced by a namespaced name exists
+	ServiceMonitorExists(serviceMonitorRef v1alpha1.NamespacedName, hcp bool) (bool, error)
 
 	// HypershiftUpdateServiceMonitorDeployment is for HyperShift cluster to ensure that a ServiceMonitor deployment according
 	// to the template exists. If none exists, it will create a new one. If the template changed, it will update the existing deployment
-	HypershiftUpdateServiceMonitorDeployment(template rhobsv1.ServiceMonitor) error
+	HypershiftUpdateServiceMonitorDeployment(template rhobsv1.ServiceMonitor) (controllerutil.OperationResult, error)
 }
 
 type PrometheusRuleHandler interface {
 	// UpdatePrometheusRuleDeployment ensures that a PrometheusRule deployment according
 	// to the template exists. If none exists, it will create a new one.
-	// If the template changed, it will update the existing deployment
-	UpdatePrometheusRuleDeployment(template monitoringv1.PrometheusRule) error
+	// If the template changed, it will update the existing deployment.
+	// It returns whether the PrometheusRule was created or updated
+	UpdatePrometheusRuleDeployment(template monitoringv1.PrometheusRule) (controllerutil.OperationResult, error)
 
 	// DeletePrometheusRuleDeployment deletes a PrometheusRule refrenced by a namespaced name
-	DeletePrometheusRuleDeployment(prometheusRuleRef v1alpha1.NamespacedName) error
+	// It returns whether the PrometheusRule existed
+	DeletePrometheusRuleDeployment(prometheusRuleRef v1alpha1.NamespacedName) (bool, error)
+
+	// PrometheusRuleExists returns whether the PrometheusRule refrenced by a namespaced name exists
+	PrometheusRuleExists(prometheusRuleRef v1alpha1.NamespacedName) (bool, error)
+}
+
+type ProbeStatusHandler interface {
+	// GetProbeStatus queries the last probe result of the URL, its availability over the SLO window
+	// and the remaining error budget of the SLO. The RHOBS endpoint is queried when rhobs is set
+	GetProbeStatus(url string, rhobs bool, slo v1alpha1.SloSpec) (v1alpha1.ProbeStatus, error)
+
+	// RefreshInterval returns how often the probe status of a monitor is refreshed
+	RefreshInterval() time.Duration
 }
 
 type BlackBoxExporterHandler interface {
-	EnsureBlackBoxExporterResourcesExist() error
-	EnsureBlackBoxExporterResourcesAbsent() error
-	ShouldDeleteBlackBoxExporterResources() (blackboxexporter.ShouldDeleteBlackBoxExporter, error)
-	GetBlackBoxExporterNamespace() string
+	// GetBlackBoxExporterForPool returns the blackbox exporter probing the monitors selecting the BlackboxExporterPool,
+	// the default blackbox exporter if the pool is empty
+	GetBlackBoxExporterForPool(pool string) (types.NamespacedName, error)
 }
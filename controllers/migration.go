@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	"github.com/openshift/route-monitor-operator/pkg/metrics"
+	customerrors "github.com/openshift/route-monitor-operator/pkg/util/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+)
+
+// IsReferenceMigration returns whether the reference recorded in the status of a monitor points to
+// another resource than the target, i.e. the previous resource has to be replaced by the target
+func IsReferenceMigration(reference v1alpha1.NamespacedName, target types.NamespacedName) bool {
+	if reference == (v1alpha1.NamespacedName{}) || target == (types.NamespacedName{}) {
+		return false
+	}
+	return reference != v1alpha1.NamespacedName{Name: target.Name, Namespace: target.Namespace}
+}
+
+// MigrateServiceMonitor deletes the ServiceMonitor the reference points to once the ServiceMonitor of the target exists,
+// so the URL is probed throughout the migration. The transition is recorded as event on the monitor.
+// It returns ErrReferenceMigrationPending while the ServiceMonitor of the target does not exist yet,
+// and does nothing if the reference already points to the target
+func MigrateServiceMonitor(handler ServiceMonitorHandler, recorder record.EventRecorder, monitor runtime.Object, reference v1alpha1.NamespacedName, target types.NamespacedName, hcp bool) error {
+	if !IsReferenceMigration(reference, target) {
+		return nil
+	}
+	exists, err := handler.ServiceMonitorExists(v1alpha1.NamespacedName{Name: target.Name, Namespace: target.Namespace}, hcp)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return customerrors.ErrReferenceMigrationPending
+	}
+	deleted, err := handler.DeleteServiceMonitorDeployment(reference, hcp)
+	if err != nil {
+		return err
+	}
+	if deleted {
+		metrics.RecordResourceOperation(KindServiceMonitor, metrics.OperationDeleted)
+	}
+	recorder.Eventf(monitor, corev1.EventTypeNormal, ReasonServiceMonitorMigrated,
+		"Replaced the ServiceMonitor %s/%s by %s", reference.Namespace, reference.Name, target)
+	return nil
+}
+
+// MigratePrometheusRule deletes the PrometheusRule the reference points to once the PrometheusRule of the target exists,
+// so the alerts are not interrupted by the migration. The transition is recorded as event on the monitor.
+// It returns ErrReferenceMigrationPending while the PrometheusRule of the target does not exist yet,
+// and does nothing if the reference already points to the target
+func MigratePrometheusRule(handler PrometheusRuleHandler, recorder record.EventRecorder, monitor runtime.Object, reference v1alpha1.NamespacedName, target types.NamespacedName) error {
+	if !IsReferenceMigration(reference, target) {
+		return nil
+	}
+	exists, err := handler.PrometheusRuleExists(v1alpha1.NamespacedName{Name: target.Name, Namespace: target.Namespace})
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return customerrors.ErrReferenceMigrationPending
+	}
+	deleted, err := handler.DeletePrometheusRuleDeployment(reference)
+	if err != nil {
+		return err
+	}
+	if deleted {
+		metrics.RecordResourceOperation(KindPrometheusRule, metrics.OperationDeleted)
+	}
+	recorder.Eventf(monitor, corev1.EventTypeNormal, ReasonPrometheusRuleMigrated,
+		"Replaced the PrometheusRule %s/%s by %s", reference.Namespace, reference.Name, target)
+	return nil
+}
@@ -0,0 +1,125 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operatorconfig
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	"github.com/openshift/route-monitor-operator/config"
+	"github.com/openshift/route-monitor-operator/pkg/operatorconfig"
+	utilreconcile "github.com/openshift/route-monitor-operator/pkg/util/reconcile"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// RouteMonitorOperatorConfigReconciler reports the effective configuration and the rejected values in the status
+// of the RouteMonitorOperatorConfig. The configuration itself is resolved by its consumers
+type RouteMonitorOperatorConfigReconciler struct {
+	Client client.Client
+	Log    logr.Logger
+	Loader operatorconfig.Loader
+}
+
+// NewReconciler returns a reconciler reporting the configuration resolved over the command-line flags
+func NewReconciler(mgr manager.Manager, flags operatorconfig.Config) *RouteMonitorOperatorConfigReconciler {
+	return &RouteMonitorOperatorConfigReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("RouteMonitorOperatorConfig"),
+		Loader: operatorconfig.Loader{Client: mgr.GetClient(), Flags: flags},
+	}
+}
+
+// +kubebuilder:rbac:groups=monitoring.openshift.io,resources=routemonitoroperatorconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=monitoring.openshift.io,resources=routemonitoroperatorconfigs/status,verbs=get;update;patch
+
+func (r *RouteMonitorOperatorConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithName("Reconcile").WithValues("name", req.Name)
+
+	operatorConfig := v1alpha1.RouteMonitorOperatorConfig{}
+	if err := r.Client.Get(ctx, req.NamespacedName, &operatorConfig); err != nil {
+		if k8serrors.IsNotFound(err) {
+			log.V(2).Info("RouteMonitorOperatorConfig not found, stopping")
+			return utilreconcile.Stop()
+		}
+		return utilreconcile.RequeueWith(err)
+	}
+
+	result, err := r.Loader.Load(ctx)
+	if err != nil {
+		log.Error(err, "Failed to read the configuration. Requeueing...")
+		return utilreconcile.RequeueWith(err)
+	}
+
+	status := operatorConfig.Status.DeepCopy()
+	status.EffectiveValues = result.Values
+	status.ObservedGeneration = operatorConfig.Generation
+	degraded := metav1.Condition{
+		Type:               v1alpha1.ConditionDegraded,
+		Status:             metav1.ConditionFalse,
+		Reason:             v1alpha1.ReasonAsExpected,
+		ObservedGeneration: operatorConfig.Generation,
+	}
+	if len(result.Errors) > 0 {
+		log.Info("Rejected invalid configuration values, the values of the previous sources are used", "errors", result.Errors.ToAggregate().Error())
+		degraded.Status = metav1.ConditionTrue
+		degraded.Reason = v1alpha1.ReasonInvalidSpec
+		degraded.Message = result.Errors.ToAggregate().Error()
+	}
+	meta.SetStatusCondition(&status.Conditions, degraded)
+
+	if !reflect.DeepEqual(operatorConfig.Status, *status) {
+		operatorConfig.Status = *status
+		if err := r.Client.Status().Update(ctx, &operatorConfig); err != nil {
+			log.Error(err, "Failed to update the RouteMonitorOperatorConfig status. Requeueing...")
+			return utilreconcile.RequeueWith(err)
+		}
+	}
+	return utilreconcile.Stop()
+}
+
+// SetupWithManager reconciles the RouteMonitorOperatorConfig when it, the deprecated ConfigMap or a Secret
+// in the operator namespace changes, as the OIDC client secret is read from there
+func (r *RouteMonitorOperatorConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	enqueueConfig := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: v1alpha1.RouteMonitorOperatorConfigName}}}
+	})
+	inOperatorNamespace := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return obj.GetNamespace() == config.OperatorNamespace
+	})
+	isConfigMap := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return obj.GetName() == operatorconfig.ConfigMapName
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.RouteMonitorOperatorConfig{}).
+		Watches(&corev1.ConfigMap{}, enqueueConfig, builder.WithPredicates(inOperatorNamespace, isConfigMap)).
+		Watches(&corev1.Secret{}, enqueueConfig, builder.WithPredicates(inOperatorNamespace), builder.OnlyMetadata).
+		Complete(r)
+}
@@ -0,0 +1,117 @@
+package operatorconfig
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	"github.com/openshift/route-monitor-operator/config"
+	constinit "github.com/openshift/route-monitor-operator/pkg/consts/test/init"
+	"github.com/openshift/route-monitor-operator/pkg/operatorconfig"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRouteMonitorOperatorConfigReconciler_Reconcile(t *testing.T) {
+	tests := []struct {
+		name           string
+		configMapData  map[string]string
+		wantDegraded   metav1.ConditionStatus
+		wantReason     string
+		wantTenant     string
+		wantTenantFrom v1alpha1.ConfigSource
+	}{
+		{
+			name:           "valid configuration",
+			configMapData:  map[string]string{"probe-tenant": "configmap-tenant"},
+			wantDegraded:   metav1.ConditionFalse,
+			wantReason:     v1alpha1.ReasonAsExpected,
+			wantTenant:     "config-tenant",
+			wantTenantFrom: v1alpha1.ConfigSourceRouteMonitorOperatorConfig,
+		},
+		{
+			name:           "rejected configuration",
+			configMapData:  map[string]string{"reconcile-interval": "1s"},
+			wantDegraded:   metav1.ConditionTrue,
+			wantReason:     v1alpha1.ReasonInvalidSpec,
+			wantTenant:     "config-tenant",
+			wantTenantFrom: v1alpha1.ConfigSourceRouteMonitorOperatorConfig,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			operatorConfig := &v1alpha1.RouteMonitorOperatorConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: v1alpha1.RouteMonitorOperatorConfigName, Generation: 3},
+				Spec:       v1alpha1.RouteMonitorOperatorConfigSpec{ProbeTenant: "config-tenant"},
+			}
+			configMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: operatorconfig.ConfigMapName, Namespace: config.OperatorNamespace},
+				Data:       tt.configMapData,
+			}
+			c := fake.NewClientBuilder().
+				WithScheme(constinit.Scheme).
+				WithObjects(operatorConfig, configMap).
+				WithStatusSubresource(&v1alpha1.RouteMonitorOperatorConfig{}).
+				Build()
+			r := &RouteMonitorOperatorConfigReconciler{
+				Client: c,
+				Log:    logr.Discard(),
+				Loader: operatorconfig.Loader{Client: c, Flags: operatorconfig.Config{ProbeTenant: "flag-tenant"}},
+			}
+
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: v1alpha1.RouteMonitorOperatorConfigName}}
+			if _, err := r.Reconcile(context.TODO(), req); err != nil {
+				t.Fatalf("Reconcile() unexpected error = %v", err)
+			}
+
+			got := &v1alpha1.RouteMonitorOperatorConfig{}
+			if err := c.Get(context.TODO(), client.ObjectKeyFromObject(operatorConfig), got); err != nil {
+				t.Fatalf("failed to get the RouteMonitorOperatorConfig: %v", err)
+			}
+			if got.Status.ObservedGeneration != 3 {
+				t.Errorf("observedGeneration = %d, want 3", got.Status.ObservedGeneration)
+			}
+			degraded := meta.FindStatusCondition(got.Status.Conditions, v1alpha1.ConditionDegraded)
+			if degraded == nil || degraded.Status != tt.wantDegraded || degraded.Reason != tt.wantReason {
+				t.Errorf("Degraded condition = %+v, want status %s and reason %s", degraded, tt.wantDegraded, tt.wantReason)
+			}
+			found := false
+			for _, value := range got.Status.EffectiveValues {
+				if value.Name == "probeTenant" {
+					found = true
+					if value.Value != tt.wantTenant || value.Source != tt.wantTenantFrom {
+						t.Errorf("probeTenant = %+v, want %s from %s", value, tt.wantTenant, tt.wantTenantFrom)
+					}
+				}
+			}
+			if !found {
+				t.Errorf("probeTenant missing from the effective values")
+			}
+		})
+	}
+}
+
+func TestRouteMonitorOperatorConfigReconciler_Reconcile_NotFound(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(constinit.Scheme).Build()
+	r := &RouteMonitorOperatorConfigReconciler{
+		Client: c,
+		Log:    logr.Discard(),
+		Loader: operatorconfig.Loader{Client: c},
+	}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: v1alpha1.RouteMonitorOperatorConfigName}}
+	res, err := r.Reconcile(context.TODO(), req)
+	if err != nil {
+		t.Fatalf("Reconcile() unexpected error = %v", err)
+	}
+	if res.Requeue || res.RequeueAfter != 0 {
+		t.Errorf("Reconcile() = %+v, want no requeue", res)
+	}
+}
@@ -0,0 +1,217 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routeannotation
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	"github.com/openshift/route-monitor-operator/controllers"
+	utilreconcile "github.com/openshift/route-monitor-operator/pkg/util/reconcile"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	// SloAnnotation opts a Route into monitoring, its value is the target availability percent of the RouteMonitor
+	SloAnnotation = "routemonitor.openshift.io/slo"
+	// SuffixAnnotation optionally sets the path probed on the Route
+	SuffixAnnotation = "routemonitor.openshift.io/suffix"
+	// PortAnnotation optionally sets the port probed on the Route
+	PortAnnotation = "routemonitor.openshift.io/port"
+	// InsecureSkipTLSVerifyAnnotation optionally disables the verification of the certificate of the Route when "true"
+	InsecureSkipTLSVerifyAnnotation = "routemonitor.openshift.io/insecure-skip-tls-verify"
+)
+
+// RouteAnnotationReconciler creates a RouteMonitor for every Route carrying the SloAnnotation and deletes it once
+// the annotation is removed. The RouteMonitor is named after the Route and garbage collected with it
+type RouteAnnotationReconciler struct {
+	Client   client.Client
+	Ctx      context.Context
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+func NewReconciler(mgr manager.Manager) *RouteAnnotationReconciler {
+	return &RouteAnnotationReconciler{
+		Client:   mgr.GetClient(),
+		Ctx:      context.Background(),
+		Log:      ctrl.Log.WithName("controllers").WithName("RouteAnnotation"),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("routeannotation-controller"),
+	}
+}
+
+// +kubebuilder:rbac:groups=route.openshift.io,resources=routes/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+func (r *RouteAnnotationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	r.Ctx = ctx
+	log := r.Log.WithName("Reconcile").WithValues("name", req.Name, "namespace", req.Namespace)
+
+	route := routev1.Route{}
+	if err := r.Client.Get(ctx, req.NamespacedName, &route); err != nil {
+		if k8serrors.IsNotFound(err) {
+			// the RouteMonitor is garbage collected through its owner reference
+			log.V(2).Info("Route not found, stopping")
+			return utilreconcile.Stop()
+		}
+		return utilreconcile.RequeueWith(err)
+	}
+	if route.DeletionTimestamp != nil {
+		return utilreconcile.Stop()
+	}
+
+	if _, ok := route.Annotations[SloAnnotation]; !ok {
+		log.V(2).Info("Entering EnsureRouteMonitorDeleted")
+		if err := r.EnsureRouteMonitorDeleted(route); err != nil {
+			log.Error(err, "Failed to delete the RouteMonitor. Requeueing...")
+			return utilreconcile.RequeueWith(err)
+		}
+		return utilreconcile.Stop()
+	}
+
+	template, err := r.TemplateForRouteMonitor(route)
+	if err != nil {
+		// the annotations are only fixed by updating the Route, which triggers a new reconcile
+		log.Error(err, "Invalid RouteMonitor annotations on Route, skipping")
+		r.Recorder.Eventf(&route, corev1.EventTypeWarning, controllers.ReasonInvalidAnnotation, "The Route is not monitored: %v", err)
+		return utilreconcile.Stop()
+	}
+
+	log.V(2).Info("Entering EnsureRouteMonitor")
+	if err := r.EnsureRouteMonitor(route, template); err != nil {
+		log.Error(err, "Failed to reconcile the RouteMonitor. Requeueing...")
+		return utilreconcile.RequeueWith(err)
+	}
+
+	log.V(2).Info("All operations for Route completed. Finished Reconcile.")
+	return utilreconcile.Stop()
+}
+
+// TemplateForRouteMonitor returns the RouteMonitor described by the annotations of the Route, controlled by the Route
+func (r *RouteAnnotationReconciler) TemplateForRouteMonitor(route routev1.Route) (v1alpha1.RouteMonitor, error) {
+	routeMonitor := v1alpha1.RouteMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      route.Name,
+			Namespace: route.Namespace,
+		},
+		Spec: v1alpha1.RouteMonitorSpec{
+			Route: v1alpha1.RouteMonitorRouteSpec{
+				Name:      route.Name,
+				Namespace: route.Namespace,
+				Suffix:    route.Annotations[SuffixAnnotation],
+			},
+			Slo: v1alpha1.SloSpec{TargetAvailabilityPercent: route.Annotations[SloAnnotation]},
+		},
+	}
+	if isValid, _ := routeMonitor.Spec.Slo.IsValid(); !isValid {
+		return routeMonitor, fmt.Errorf("invalid %s annotation %q, expected a percent between 0 and 100", SloAnnotation, route.Annotations[SloAnnotation])
+	}
+	if port, ok := route.Annotations[PortAnnotation]; ok {
+		value, err := strconv.ParseInt(port, 10, 64)
+		if err != nil || value < 1 {
+			return routeMonitor, fmt.Errorf("invalid %s annotation %q, expected a positive port", PortAnnotation, port)
+		}
+		routeMonitor.Spec.Route.Port = value
+	}
+	if insecure, ok := route.Annotations[InsecureSkipTLSVerifyAnnotation]; ok {
+		value, err := strconv.ParseBool(insecure)
+		if err != nil {
+			return routeMonitor, fmt.Errorf("invalid %s annotation %q, expected true or false", InsecureSkipTLSVerifyAnnotation, insecure)
+		}
+		routeMonitor.Spec.InsecureSkipTLSVerify = value
+	}
+	err := controllerutil.SetControllerReference(&route, &routeMonitor, r.Scheme)
+	return routeMonitor, err
+}
+
+// EnsureRouteMonitor creates the RouteMonitor of the Route or updates the fields set from its annotations.
+// A RouteMonitor of the same name not controlled by the Route is left alone
+func (r *RouteAnnotationReconciler) EnsureRouteMonitor(route routev1.Route, template v1alpha1.RouteMonitor) error {
+	routeMonitor := v1alpha1.RouteMonitor{}
+	err := r.Client.Get(r.Ctx, client.ObjectKeyFromObject(&template), &routeMonitor)
+	if k8serrors.IsNotFound(err) {
+		r.Log.V(2).Info("Creating RouteMonitor for annotated Route", "name", template.Name, "namespace", template.Namespace)
+		return r.Client.Create(r.Ctx, &template)
+	}
+	if err != nil {
+		return err
+	}
+	if !isControlledBy(&routeMonitor, route) {
+		r.Log.Info("RouteMonitor already exists and is not controlled by the Route, skipping", "name", routeMonitor.Name, "namespace", routeMonitor.Namespace)
+		return nil
+	}
+	if routeMonitor.Spec.Route == template.Spec.Route &&
+		reflect.DeepEqual(routeMonitor.Spec.Slo, template.Spec.Slo) &&
+		routeMonitor.Spec.InsecureSkipTLSVerify == template.Spec.InsecureSkipTLSVerify {
+		return nil
+	}
+	routeMonitor.Spec.Route = template.Spec.Route
+	routeMonitor.Spec.Slo = template.Spec.Slo
+	routeMonitor.Spec.InsecureSkipTLSVerify = template.Spec.InsecureSkipTLSVerify
+	return r.Client.Update(r.Ctx, &routeMonitor)
+}
+
+// EnsureRouteMonitorDeleted deletes the RouteMonitor controlled by the Route, if there is one
+func (r *RouteAnnotationReconciler) EnsureRouteMonitorDeleted(route routev1.Route) error {
+	routeMonitor := v1alpha1.RouteMonitor{}
+	err := r.Client.Get(r.Ctx, client.ObjectKeyFromObject(&route), &routeMonitor)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !isControlledBy(&routeMonitor, route) {
+		return nil
+	}
+	r.Log.V(2).Info("Deleting RouteMonitor of Route without annotation", "name", routeMonitor.Name, "namespace", routeMonitor.Namespace)
+	if err := r.Client.Delete(r.Ctx, &routeMonitor); err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// isControlledBy returns whether the Route is the controller of the RouteMonitor
+func isControlledBy(routeMonitor *v1alpha1.RouteMonitor, route routev1.Route) bool {
+	owner := metav1.GetControllerOf(routeMonitor)
+	return owner != nil && owner.UID == route.UID
+}
+
+func (r *RouteAnnotationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("routeannotation").
+		For(&routev1.Route{}, builder.WithPredicates(predicate.AnnotationChangedPredicate{})).
+		Owns(&v1alpha1.RouteMonitor{}).
+		Complete(r)
+}
@@ -0,0 +1,13 @@
+package routeannotation_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestRouteAnnotation(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "RouteAnnotation Suite")
+}
@@ -0,0 +1,157 @@
+package routeannotation_test
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	"github.com/openshift/route-monitor-operator/controllers/routeannotation"
+	constinit "github.com/openshift/route-monitor-operator/pkg/consts/test/init"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("RouteAnnotation", func() {
+	var (
+		objects    []client.Object
+		fakeClient client.Client
+		recorder   *record.FakeRecorder
+		reconciler routeannotation.RouteAnnotationReconciler
+
+		route routev1.Route
+		err   error
+	)
+
+	getRouteMonitor := func() (v1alpha1.RouteMonitor, error) {
+		routeMonitor := v1alpha1.RouteMonitor{}
+		err := fakeClient.Get(context.Background(), types.NamespacedName{Name: "frontend", Namespace: "team-a"}, &routeMonitor)
+		return routeMonitor, err
+	}
+	ownedRouteMonitor := func(spec v1alpha1.RouteMonitorSpec) *v1alpha1.RouteMonitor {
+		routeMonitor := &v1alpha1.RouteMonitor{
+			ObjectMeta: metav1.ObjectMeta{Name: "frontend", Namespace: "team-a"},
+			Spec:       spec,
+		}
+		Expect(ctrl.SetControllerReference(&route, routeMonitor, constinit.Scheme)).To(Succeed())
+		return routeMonitor
+	}
+
+	BeforeEach(func() {
+		route = routev1.Route{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "frontend",
+				Namespace: "team-a",
+				UID:       "route-uid",
+				Annotations: map[string]string{
+					routeannotation.SloAnnotation: "99.9",
+				},
+			},
+		}
+		objects = []client.Object{}
+	})
+	JustBeforeEach(func() {
+		fakeClient = fake.NewClientBuilder().
+			WithScheme(constinit.Scheme).
+			WithObjects(append(objects, &route)...).
+			Build()
+		recorder = record.NewFakeRecorder(10)
+		reconciler = routeannotation.RouteAnnotationReconciler{
+			Client:   fakeClient,
+			Log:      logr.Discard(),
+			Scheme:   constinit.Scheme,
+			Recorder: recorder,
+		}
+		_, err = reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: route.Name, Namespace: route.Namespace}})
+	})
+
+	It("creates a RouteMonitor controlled by the annotated Route", func() {
+		Expect(err).NotTo(HaveOccurred())
+		routeMonitor, err := getRouteMonitor()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(routeMonitor.Spec.Route).To(Equal(v1alpha1.RouteMonitorRouteSpec{Name: "frontend", Namespace: "team-a"}))
+		Expect(routeMonitor.Spec.Slo.TargetAvailabilityPercent).To(Equal("99.9"))
+		Expect(metav1.GetControllerOf(&routeMonitor).UID).To(Equal(route.UID))
+	})
+
+	When("the Route sets the optional annotations", func() {
+		BeforeEach(func() {
+			route.Annotations[routeannotation.SuffixAnnotation] = "/healthz"
+			route.Annotations[routeannotation.PortAnnotation] = "8443"
+			route.Annotations[routeannotation.InsecureSkipTLSVerifyAnnotation] = "true"
+		})
+		It("maps them to the RouteMonitor", func() {
+			Expect(err).NotTo(HaveOccurred())
+			routeMonitor, err := getRouteMonitor()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(routeMonitor.Spec.Route.Suffix).To(Equal("/healthz"))
+			Expect(routeMonitor.Spec.Route.Port).To(Equal(int64(8443)))
+			Expect(routeMonitor.Spec.InsecureSkipTLSVerify).To(BeTrue())
+		})
+	})
+
+	When("an annotation is invalid", func() {
+		BeforeEach(func() {
+			route.Annotations[routeannotation.PortAnnotation] = "https"
+		})
+		It("does not create a RouteMonitor", func() {
+			Expect(err).NotTo(HaveOccurred())
+			_, err := getRouteMonitor()
+			Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		})
+		It("records a warning naming the annotation on the Route", func() {
+			Expect(recorder.Events).To(Receive(And(
+				HavePrefix("Warning InvalidAnnotation "),
+				ContainSubstring(routeannotation.PortAnnotation),
+				ContainSubstring(`"https", expected a positive port`),
+			)))
+		})
+	})
+
+	When("the annotations of the Route changed", func() {
+		BeforeEach(func() {
+			objects = append(objects, ownedRouteMonitor(v1alpha1.RouteMonitorSpec{
+				Route: v1alpha1.RouteMonitorRouteSpec{Name: "frontend", Namespace: "team-a"},
+				Slo:   v1alpha1.SloSpec{TargetAvailabilityPercent: "99"},
+			}))
+		})
+		It("updates the RouteMonitor", func() {
+			Expect(err).NotTo(HaveOccurred())
+			routeMonitor, err := getRouteMonitor()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(routeMonitor.Spec.Slo.TargetAvailabilityPercent).To(Equal("99.9"))
+		})
+	})
+
+	When("the annotation was removed", func() {
+		BeforeEach(func() {
+			objects = append(objects, ownedRouteMonitor(v1alpha1.RouteMonitorSpec{}))
+			route.Annotations = nil
+		})
+		It("deletes the RouteMonitor", func() {
+			Expect(err).NotTo(HaveOccurred())
+			_, err := getRouteMonitor()
+			Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	When("a RouteMonitor not controlled by the Route uses the name", func() {
+		BeforeEach(func() {
+			objects = append(objects, &v1alpha1.RouteMonitor{ObjectMeta: metav1.ObjectMeta{Name: "frontend", Namespace: "team-a"}})
+			route.Annotations = nil
+		})
+		It("leaves it alone", func() {
+			Expect(err).NotTo(HaveOccurred())
+			_, err := getRouteMonitor()
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,24 @@
+package routemonitor
+
+import (
+	"context"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// the watch of the Routes is tested without a manager
+var (
+	RouteIndexKey   = routeIndexKey
+	IndexByRoute    = indexByRoute
+	RouteURLChanged = routeURLChanged
+)
+
+func (r *RouteMonitorReconciler) RouteMonitorsForRoute(ctx context.Context, route client.Object) []reconcile.Request {
+	return r.routeMonitorsForRoute(ctx, route)
+}
+
+func (r *RouteMonitorReconciler) RecordMonitorState(req ctrl.Request) {
+	r.recordMonitorState(req)
+}
@@ -20,20 +20,31 @@ import (
 	"context"
 
 	"github.com/go-logr/logr"
+	routev1 "github.com/openshift/api/route/v1"
 	monitoringv1alpha1 "github.com/openshift/route-monitor-operator/api/v1alpha1"
 	"github.com/openshift/route-monitor-operator/controllers"
 	"github.com/openshift/route-monitor-operator/pkg/alert"
 	"github.com/openshift/route-monitor-operator/pkg/blackboxexporter"
+	"github.com/openshift/route-monitor-operator/pkg/metrics"
+	"github.com/openshift/route-monitor-operator/pkg/probestatus"
 	reconcileCommon "github.com/openshift/route-monitor-operator/pkg/reconcile"
 	"github.com/openshift/route-monitor-operator/pkg/servicemonitor"
+	"github.com/openshift/route-monitor-operator/pkg/util/conditions"
 	"github.com/openshift/route-monitor-operator/pkg/util/finalizer"
 	utilreconcile "github.com/openshift/route-monitor-operator/pkg/util/reconcile"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 // RouteMonitorReconciler reconciles a RouteMonitor object
@@ -46,39 +57,51 @@ type RouteMonitorReconciler struct {
 	ServiceMonitor   controllers.ServiceMonitorHandler
 	Prom             controllers.PrometheusRuleHandler
 	Common           controllers.MonitorResourceHandler
+	Recorder         record.EventRecorder
+	// ProbeStatus is nil unless a Prometheus endpoint is configured
+	ProbeStatus controllers.ProbeStatusHandler
+	// ProbeStatusReloader resolves ProbeStatus from the operator configuration when reconciling, unless it is nil
+	ProbeStatusReloader *probestatus.Reloader
 }
 
-func NewReconciler(mgr manager.Manager, blackboxExporterImage, blackboxExporterNamespace string, enablehypershift bool, probeAPIURL string) *RouteMonitorReconciler {
+func NewReconciler(mgr manager.Manager, blackboxExporterImage, blackboxExporterNamespace string, blackboxExporterReplicas int32, enablehypershift bool, probeAPIURL string, probeStatusReloader *probestatus.Reloader) *RouteMonitorReconciler {
 	log := ctrl.Log.WithName("controllers").WithName("RouteMonitor")
 	client := mgr.GetClient()
 	ctx := context.Background()
 	return &RouteMonitorReconciler{
-		Client:           client,
-		Ctx:              ctx,
-		Log:              log,
-		Scheme:           mgr.GetScheme(),
-		BlackBoxExporter: blackboxexporter.New(client, log, ctx, blackboxExporterImage, blackboxExporterNamespace),
-		ServiceMonitor:   servicemonitor.NewServiceMonitor(ctx, client),
-		Prom:             alert.NewPrometheusRule(ctx, client),
-		Common:           reconcileCommon.NewMonitorResourceCommon(ctx, client),
+		Client:              client,
+		Ctx:                 ctx,
+		Log:                 log,
+		Scheme:              mgr.GetScheme(),
+		BlackBoxExporter:    blackboxexporter.New(client, log, ctx, blackboxExporterImage, blackboxExporterNamespace, blackboxExporterReplicas),
+		ServiceMonitor:      servicemonitor.NewServiceMonitor(ctx, client),
+		Prom:                alert.NewPrometheusRule(ctx, client),
+		Common:              reconcileCommon.NewMonitorResourceCommon(ctx, client),
+		ProbeStatusReloader: probeStatusReloader,
+		Recorder:            mgr.GetEventRecorderFor("routemonitor-controller"),
 	}
 }
 
 // +kubebuilder:rbac:groups=*,resources=services,verbs=get;list;watch;create;delete
-// +kubebuilder:rbac:groups=*,resources=configmaps,verbs=get;list;watch;create;delete
-// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=*,resources=configmaps,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups=*,resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;delete
 // +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;create;delete;update
 // +kubebuilder:rbac:groups=monitoring.rhobs,resources=servicemonitors,verbs=get;list;watch;create;delete;update
 // +kubebuilder:rbac:groups=monitoring.coreos.com,resources=prometheusrules,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=monitoring.openshift.io,resources=routemonitors,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=monitoring.openshift.io,resources=routemonitors/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=monitoring.openshift.io,resources=blackboxexporterpools,verbs=get;list;watch
 // +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;list;watch
 // +kubebuilder:rbac:groups=operator.openshift.io,resources=ingresscontrollers,verbs=get;list;watch
 // +kubebuilder:rbac:groups=config.openshift.io,resources=clusterversions,verbs=get;list;watch
 // +kubebuilder:rbac:groups=config.openshift.io,resources=infrastructures,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *RouteMonitorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	r.Ctx = ctx
+	r.resolveProbeStatus()
 	log := r.Log.WithName("Reconcile").WithValues("name", req.Name, "namespace", req.Namespace)
 
 	log.V(2).Info("Entering GetRouteMonitor")
@@ -88,17 +111,22 @@ func (r *RouteMonitorReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return utilreconcile.RequeueWith(err)
 	}
 	if res.ShouldStop() {
+		metrics.DeleteMonitorState(metrics.KindRouteMonitor, req.NamespacedName)
 		return utilreconcile.Stop()
 	}
+	// the state is recorded once the steps updated the conditions
+	defer r.recordMonitorState(req)
 
 	// Handle deletion of RouteMonitor Resource
 	shouldDelete := finalizer.WasDeleteRequested(&routeMonitor)
 	log.V(2).Info("Response of WasDeleteRequested", "shouldDelete", shouldDelete)
 
 	if shouldDelete {
-		_, err := r.EnsureMonitorAndDependenciesAbsent(routeMonitor)
+		res, err = r.EnsureMonitorAndDependenciesAbsent(routeMonitor)
+		metrics.RecordReconcileStep(metrics.KindRouteMonitor, "EnsureMonitorAndDependenciesAbsent", res, err)
 		if err != nil {
 			log.Error(err, "Failed to delete RouteMonitor. Requeueing...")
+			controllers.RecordRequeue(r.Recorder, &routeMonitor, "Failed to delete the RouteMonitor", err)
 			return utilreconcile.RequeueWith(err)
 		}
 		log.Info("Successfully deleted RouteMonitor. Finished reconcile.")
@@ -107,8 +135,10 @@ func (r *RouteMonitorReconciler) Reconcile(ctx context.Context, req ctrl.Request
 
 	log.V(2).Info("Entering EnsureFinalizerSet")
 	res, err = r.EnsureFinalizerSet(routeMonitor)
+	metrics.RecordReconcileStep(metrics.KindRouteMonitor, "EnsureFinalizerSet", res, err)
 	if err != nil {
 		log.Error(err, "Failed to set RouteMonitor's finalizer. Requeueing...")
+		controllers.RecordRequeue(r.Recorder, &routeMonitor, "Failed to set the finalizer", err)
 		return utilreconcile.RequeueWith(err)
 	}
 	if res.ShouldStop() {
@@ -116,26 +146,19 @@ func (r *RouteMonitorReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return utilreconcile.Stop()
 	}
 
-	log.V(2).Info("Entering EnsureBlackBoxExporterResourcesExist")
-	// Should happen once but cannot input in main.go
-	err = r.BlackBoxExporter.EnsureBlackBoxExporterResourcesExist()
-	if err != nil {
-		log.Error(err, "Failed to create BlackBoxExporter. Requeueing...")
-		return utilreconcile.RequeueWith(err)
-	}
-
 	log.V(2).Info("Entering GetRoute")
 	route, err := r.GetRoute(routeMonitor)
 	if err != nil {
 		log.Error(err, "Failed to get Route. Requeueing...")
-		return utilreconcile.RequeueWith(err)
+		return r.requeueWithCondition(routeMonitor, monitoringv1alpha1.ConditionRouteResolved, "Failed to get the Route", err)
 	}
 
 	log.V(2).Info("Entering EnsureRouteURLExists")
 	res, err = r.EnsureRouteURLExists(route, routeMonitor)
+	metrics.RecordReconcileStep(metrics.KindRouteMonitor, "EnsureRouteURLExists", res, err)
 	if err != nil {
 		log.Error(err, "Failed to get RouteURL for RouteMonitor. Requeueing...")
-		return utilreconcile.RequeueWith(err)
+		return r.requeueWithCondition(routeMonitor, monitoringv1alpha1.ConditionRouteResolved, "Failed to get the URL of the Route", err)
 	}
 	if res.ShouldStop() {
 		log.Info("Successfully patched RouteMonitor with RouteURL. Stopping...")
@@ -144,9 +167,10 @@ func (r *RouteMonitorReconciler) Reconcile(ctx context.Context, req ctrl.Request
 
 	log.V(2).Info("Entering EnsureServiceMonitorExists")
 	res, err = r.EnsureServiceMonitorExists(routeMonitor)
+	metrics.RecordReconcileStep(metrics.KindRouteMonitor, "EnsureServiceMonitorExists", res, err)
 	if err != nil {
 		log.Error(err, "Failed to set ServiceMonitor. Requeueing...")
-		return utilreconcile.RequeueWith(err)
+		return r.requeueWithCondition(routeMonitor, monitoringv1alpha1.ConditionServiceMonitorReady, "Failed to set the ServiceMonitor", err)
 	}
 	if res.ShouldStop() {
 		log.Info("Successfully patched RouteMonitor with ServiceMonitorRef. Stopping...")
@@ -156,25 +180,182 @@ func (r *RouteMonitorReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	log.V(2).Info("Entering EnsurePrometheusRuleResourceExists")
 	// result is silenced as it's the end of the function, if this moves add it back
 	res, err = r.EnsurePrometheusRuleExists(routeMonitor)
+	metrics.RecordReconcileStep(metrics.KindRouteMonitor, "EnsurePrometheusRuleExists", res, err)
 	if err != nil {
 		log.Error(err, "Failed to set PrometheusRule. Requeueing...")
-		return utilreconcile.RequeueWith(err)
+		return r.requeueWithCondition(routeMonitor, monitoringv1alpha1.ConditionPrometheusRuleReady, "Failed to set the PrometheusRule", err)
 	}
 	if res.ShouldStop() {
 		log.Info("Successfully patched RouteMonitor with PrometheusRuleRef. Stopping...")
 		return utilreconcile.Stop()
 	}
 
+	log.V(2).Info("Entering EnsureProbeStatusUpdated")
+	res, err = r.EnsureProbeStatusUpdated(routeMonitor)
+	metrics.RecordReconcileStep(metrics.KindRouteMonitor, "EnsureProbeStatusUpdated", res, err)
+	if err != nil {
+		log.Error(err, "Failed to update the probe status. Requeueing...")
+		controllers.RecordRequeue(r.Recorder, &routeMonitor, "Failed to update the probe status", err)
+		return utilreconcile.RequeueWith(err)
+	}
+	if res.ShouldStop() {
+		log.Info("Successfully patched RouteMonitor with the probe status. Stopping...")
+		return utilreconcile.Stop()
+	}
+
+	log.V(2).Info("Entering EnsureMaintenanceStatusUpdated")
+	maintenanceRes, err := r.EnsureMaintenanceStatusUpdated(routeMonitor)
+	metrics.RecordReconcileStep(metrics.KindRouteMonitor, "EnsureMaintenanceStatusUpdated", maintenanceRes, err)
+	if err != nil {
+		log.Error(err, "Failed to update the active maintenance window. Requeueing...")
+		controllers.RecordRequeue(r.Recorder, &routeMonitor, "Failed to update the active maintenance window", err)
+		return utilreconcile.RequeueWith(err)
+	}
+	if maintenanceRes.ShouldStop() {
+		log.Info("Successfully patched RouteMonitor with the active maintenance window. Stopping...")
+		return utilreconcile.Stop()
+	}
+
 	log.Info("All operations for RouteMonitor completed. Finished Reconcile.")
-	return utilreconcile.Stop()
+	return res.RequeueSooner(maintenanceRes.RequeueAfter).Convert(), nil
+}
+
+// recordMonitorState records the state of the RouteMonitor derived from the conditions set by the reconcile steps.
+// The RouteMonitor is read again, as every step updates the status of its own copy
+func (r *RouteMonitorReconciler) recordMonitorState(req ctrl.Request) {
+	routeMonitor, res, err := r.GetRouteMonitor(req)
+	if err != nil {
+		r.Log.V(2).Info("Failed to read the RouteMonitor to record its state", "error", err.Error())
+		return
+	}
+	if res.ShouldStop() {
+		metrics.DeleteMonitorState(metrics.KindRouteMonitor, req.NamespacedName)
+		return
+	}
+	serviceMonitorType := routeMonitor.Spec.ServiceMonitorType
+	if serviceMonitorType == "" {
+		serviceMonitorType = monitoringv1alpha1.ServiceMonitorTypeCoreOS
+	}
+	metrics.SetMonitorState(metrics.KindRouteMonitor, req.NamespacedName, serviceMonitorType, routeMonitor.Status.Conditions)
+}
+
+// requeueWithCondition records the error of a reconcile step in the condition of the step and as warning event before requeueing.
+// Updating the status is best effort, as the step is retried anyways
+func (r *RouteMonitorReconciler) requeueWithCondition(routeMonitor monitoringv1alpha1.RouteMonitor, conditionType, message string, err error) (ctrl.Result, error) {
+	controllers.RecordRequeue(r.Recorder, &routeMonitor, message, err)
+	if conditions.MarkFalse(&routeMonitor, conditionType, monitoringv1alpha1.ReasonReconcileFailed, err) {
+		if _, updateErr := r.Common.UpdateMonitorResourceStatus(&routeMonitor); updateErr != nil {
+			r.Log.V(2).Info("Failed to record the error in the RouteMonitor conditions", "error", updateErr.Error())
+		}
+	}
+	return utilreconcile.RequeueWith(err)
 }
 
+// routeIndexKey indexes RouteMonitors by the namespace/name of the Route they monitor
+const routeIndexKey = "spec.route"
+
 func (r *RouteMonitorReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &monitoringv1alpha1.RouteMonitor{}, routeIndexKey, indexByRoute); err != nil {
+		return err
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&monitoringv1alpha1.RouteMonitor{}).
+		Watches(
+			&routev1.Route{},
+			handler.EnqueueRequestsFromMapFunc(r.routeMonitorsForRoute),
+			builder.WithPredicates(predicate.Funcs{UpdateFunc: routeURLChanged}),
+		).
 		Watches(
 			&monitoringv1.ServiceMonitor{},
 			handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &monitoringv1alpha1.RouteMonitor{}, handler.OnlyControllerOwner()),
 		).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.routeMonitorsForSecret),
+			builder.OnlyMetadata,
+		).
+		Watches(
+			&monitoringv1alpha1.BlackboxExporterPool{},
+			handler.EnqueueRequestsFromMapFunc(r.routeMonitorsForPool),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
 		Complete(r)
 }
+
+// routeMonitorsForPool returns the RouteMonitors selecting the BlackboxExporterPool,
+// so their ServiceMonitor is created once the pool exists
+func (r *RouteMonitorReconciler) routeMonitorsForPool(ctx context.Context, pool client.Object) []reconcile.Request {
+	routeMonitors := &monitoringv1alpha1.RouteMonitorList{}
+	if err := r.Client.List(ctx, routeMonitors); err != nil {
+		r.Log.Error(err, "Failed to list RouteMonitors selecting BlackboxExporterPool", "name", pool.GetName())
+		return nil
+	}
+	requests := []reconcile.Request{}
+	for _, routeMonitor := range routeMonitors.Items {
+		if routeMonitor.Spec.BlackboxExporterPool == pool.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: routeMonitor.Name, Namespace: routeMonitor.Namespace}})
+		}
+	}
+	return requests
+}
+
+// indexByRoute returns the namespace/name of the Route monitored by the RouteMonitor
+func indexByRoute(obj client.Object) []string {
+	routeMonitor, ok := obj.(*monitoringv1alpha1.RouteMonitor)
+	if !ok || routeMonitor.Spec.Route.Name == "" || routeMonitor.Spec.Route.Namespace == "" {
+		return nil
+	}
+	return []string{types.NamespacedName{Name: routeMonitor.Spec.Route.Name, Namespace: routeMonitor.Spec.Route.Namespace}.String()}
+}
+
+// routeMonitorsForRoute returns the RouteMonitors monitoring the Route, so changes of its URL are propagated
+// to the ServiceMonitor and PrometheusRule right away
+func (r *RouteMonitorReconciler) routeMonitorsForRoute(ctx context.Context, route client.Object) []reconcile.Request {
+	routeMonitors := &monitoringv1alpha1.RouteMonitorList{}
+	if err := r.Client.List(ctx, routeMonitors, client.MatchingFields{routeIndexKey: client.ObjectKeyFromObject(route).String()}); err != nil {
+		r.Log.Error(err, "Failed to list RouteMonitors referencing Route", "name", route.GetName(), "namespace", route.GetNamespace())
+		return nil
+	}
+	requests := []reconcile.Request{}
+	for _, routeMonitor := range routeMonitors.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: routeMonitor.Name, Namespace: routeMonitor.Namespace}})
+	}
+	return requests
+}
+
+// routeURLChanged filters Route updates to those changing the inputs of the RouteURL: TLS and the hosts,
+// routers and admission of the ingresses
+func routeURLChanged(e event.UpdateEvent) bool {
+	oldRoute, okOld := e.ObjectOld.(*routev1.Route)
+	newRoute, okNew := e.ObjectNew.(*routev1.Route)
+	if !okOld || !okNew {
+		return true
+	}
+	if (oldRoute.Spec.TLS == nil) != (newRoute.Spec.TLS == nil) || len(oldRoute.Status.Ingress) != len(newRoute.Status.Ingress) {
+		return true
+	}
+	for i := range oldRoute.Status.Ingress {
+		oldIngress, newIngress := oldRoute.Status.Ingress[i], newRoute.Status.Ingress[i]
+		if oldIngress.Host != newIngress.Host || oldIngress.RouterName != newIngress.RouterName || isAdmitted(oldIngress) != isAdmitted(newIngress) {
+			return true
+		}
+	}
+	return false
+}
+
+// routeMonitorsForSecret returns the RouteMonitors authenticating their probe with the Secret,
+// so the validation of their probe is updated when it changes
+func (r *RouteMonitorReconciler) routeMonitorsForSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	routeMonitors := &monitoringv1alpha1.RouteMonitorList{}
+	if err := r.Client.List(ctx, routeMonitors, client.InNamespace(secret.GetNamespace())); err != nil {
+		r.Log.Error(err, "Failed to list RouteMonitors referencing Secret", "name", secret.GetName(), "namespace", secret.GetNamespace())
+		return nil
+	}
+	requests := []reconcile.Request{}
+	for _, routeMonitor := range routeMonitors.Items {
+		if auth := routeMonitor.Spec.Probe.Auth; auth != nil && auth.SecretName == secret.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: routeMonitor.Name, Namespace: routeMonitor.Namespace}})
+		}
+	}
+	return requests
+}
@@ -5,16 +5,24 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"time"
 
 	routev1 "github.com/openshift/api/route/v1"
 	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	"github.com/openshift/route-monitor-operator/controllers"
 
 	"github.com/openshift/route-monitor-operator/pkg/alert"
+	"github.com/openshift/route-monitor-operator/pkg/blackboxexporter"
 	"github.com/openshift/route-monitor-operator/pkg/consts"
+	"github.com/openshift/route-monitor-operator/pkg/maintenance"
+	"github.com/openshift/route-monitor-operator/pkg/probestatus"
+	"github.com/openshift/route-monitor-operator/pkg/servicemonitor"
+	"github.com/openshift/route-monitor-operator/pkg/util/conditions"
 	customerrors "github.com/openshift/route-monitor-operator/pkg/util/errors"
 	utilreconcile "github.com/openshift/route-monitor-operator/pkg/util/reconcile"
 
 	hypershiftv1beta1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -27,10 +35,13 @@ func (r *RouteMonitorReconciler) EnsurePrometheusRuleExists(routeMonitor v1alpha
 	// If .spec.skipPrometheusRule is true, ensure that the PrometheusRule does NOT exist
 	if routeMonitor.Spec.SkipPrometheusRule {
 		// Cleanup any existing PrometheusRules and update the status
-		if err := r.Prom.DeletePrometheusRuleDeployment(routeMonitor.Status.PrometheusRuleRef); err != nil {
+		if err := r.deletePrometheusRule(routeMonitor); err != nil {
 			return utilreconcile.RequeueReconcileWith(err)
 		}
-		updated, _ := r.Common.SetResourceReference(&routeMonitor.Status.PrometheusRuleRef, types.NamespacedName{})
+		updated := r.Common.SetResourceReference(&routeMonitor.Status.PrometheusRuleRef, types.NamespacedName{})
+		if conditions.MarkTrue(&routeMonitor, v1alpha1.ConditionPrometheusRuleReady, v1alpha1.ReasonNotRequired) {
+			updated = true
+		}
 		if updated {
 			return r.Common.UpdateMonitorResourceStatus(&routeMonitor)
 		}
@@ -39,16 +50,33 @@ func (r *RouteMonitorReconciler) EnsurePrometheusRuleExists(routeMonitor v1alpha
 	}
 
 	parsedSlo, err := r.Common.ParseMonitorSLOSpecs(routeMonitor.Status.RouteURL, routeMonitor.Spec.Slo)
-	if r.Common.SetErrorStatus(&routeMonitor.Status.ErrorStatus, err) {
+	if err == nil && routeMonitor.Spec.Slo.Latency != nil && !routeMonitor.Spec.Slo.Latency.IsValidFor(routeMonitor.Spec.Probe) {
+		err = customerrors.ErrInvalidLatencySLO
+	}
+	if err == nil && routeMonitor.Spec.CertificateExpiry != nil && !routeMonitor.Spec.CertificateExpiry.IsValid() {
+		err = customerrors.ErrInvalidCertificateExpiry
+	}
+	if err == nil && maintenance.Validate(routeMonitor.Spec.MaintenanceWindows) != nil {
+		err = customerrors.ErrInvalidMaintenanceWindow
+	}
+	errorStatusUpdated := r.Common.SetErrorStatus(&routeMonitor.Status.ErrorStatus, err)
+	if err != nil && conditions.MarkFalse(&routeMonitor, v1alpha1.ConditionPrometheusRuleReady, v1alpha1.ReasonInvalidSpec, err) {
+		errorStatusUpdated = true
+	}
+	if errorStatusUpdated {
 		return r.Common.UpdateMonitorResourceStatus(&routeMonitor)
 	}
-	if parsedSlo == "" {
+	// an invalid spec never renders alerts, its PrometheusRule is removed until it is fixed
+	if err != nil || (parsedSlo == "" && routeMonitor.Spec.CertificateExpiry == nil) {
 		// Delete existing PrometheusRules if required
-		err = r.Prom.DeletePrometheusRuleDeployment(routeMonitor.Status.PrometheusRuleRef)
-		if err != nil {
+		// the error of the spec is kept, so the condition is only set when the PrometheusRule is not required
+		if err := r.deletePrometheusRule(routeMonitor); err != nil {
 			return utilreconcile.RequeueReconcileWith(err)
 		}
-		updated, _ := r.Common.SetResourceReference(&routeMonitor.Status.PrometheusRuleRef, types.NamespacedName{})
+		updated := r.Common.SetResourceReference(&routeMonitor.Status.PrometheusRuleRef, types.NamespacedName{})
+		if err == nil && conditions.MarkTrue(&routeMonitor, v1alpha1.ConditionPrometheusRuleReady, v1alpha1.ReasonNotRequired) {
+			updated = true
+		}
 		if updated {
 			return r.Common.UpdateMonitorResourceStatus(&routeMonitor)
 		}
@@ -57,14 +85,22 @@ func (r *RouteMonitorReconciler) EnsurePrometheusRuleExists(routeMonitor v1alpha
 
 	// Update PrometheusRule from templates
 	namespacedName := types.NamespacedName{Namespace: routeMonitor.Namespace, Name: routeMonitor.Name}
-	template := alert.TemplateForPrometheusRuleResource(routeMonitor.Status.RouteURL, parsedSlo, namespacedName)
-	err = r.Prom.UpdatePrometheusRuleDeployment(template)
+	template := alert.TemplateForPrometheusRuleResource(routeMonitor.Status.RouteURL, parsedSlo, routeMonitor.Spec.Slo, routeMonitor.Spec.CertificateExpiry, routeMonitor.Spec.ProbeLocations, maintenance.Upcoming(routeMonitor.Spec.MaintenanceWindows, time.Now()), namespacedName)
+	result, err := r.Prom.UpdatePrometheusRuleDeployment(template)
 	if err != nil {
 		return utilreconcile.RequeueReconcileWith(err)
 	}
+	controllers.RecordOperation(r.Recorder, &routeMonitor, result, controllers.KindPrometheusRule, namespacedName.String())
 
+	// replace the previous PrometheusRule once the new one exists
+	if err := controllers.MigratePrometheusRule(r.Prom, r.Recorder, &routeMonitor, routeMonitor.Status.PrometheusRuleRef, namespacedName); err != nil {
+		return utilreconcile.RequeueReconcileWith(err)
+	}
 	// Update PrometheusRuleReference in RouteMonitor if necessary
-	updated, _ := r.Common.SetResourceReference(&routeMonitor.Status.PrometheusRuleRef, namespacedName)
+	updated := r.Common.SetResourceReference(&routeMonitor.Status.PrometheusRuleRef, namespacedName)
+	if conditions.MarkTrue(&routeMonitor, v1alpha1.ConditionPrometheusRuleReady, v1alpha1.ReasonReconciled) {
+		updated = true
+	}
 	if updated {
 		return r.Common.UpdateMonitorResourceStatus(&routeMonitor)
 	}
@@ -78,8 +114,42 @@ func (r *RouteMonitorReconciler) EnsureServiceMonitorExists(routeMonitor v1alpha
 		return utilreconcile.RequeueReconcileWith(customerrors.ErrNoHost)
 	}
 
+	// An invalid probe has no module in the blackbox exporter configuration
+	err := blackboxexporter.ValidateProbe(r.Ctx, r.Client, routeMonitor.Namespace, routeMonitor.Spec.Probe)
+	var blackBoxExporter types.NamespacedName
+	if err == nil {
+		// Monitors selecting a pool which does not exist are not probed until it is created
+		blackBoxExporter, err = r.BlackBoxExporter.GetBlackBoxExporterForPool(routeMonitor.Spec.BlackboxExporterPool)
+	}
+	if blackboxexporter.IsInvalidProbe(err) || errors.Is(err, customerrors.ErrBlackboxExporterPoolNotFound) {
+		errorStatusUpdated := r.Common.SetErrorStatus(&routeMonitor.Status.ErrorStatus, err)
+		if conditions.MarkFalse(&routeMonitor, v1alpha1.ConditionServiceMonitorReady, v1alpha1.ReasonInvalidSpec, err) {
+			errorStatusUpdated = true
+		}
+		if errorStatusUpdated {
+			return r.Common.UpdateMonitorResourceStatus(&routeMonitor)
+		}
+		return utilreconcile.StopReconcile()
+	}
+	if err != nil {
+		return utilreconcile.RequeueReconcileWith(err)
+	}
+	// every admitted ingress is probed as a separate target in the all ingressSelector mode,
+	// unless the probe has a target of its own
+	ingressURLs := routeMonitor.Status.IngressURLs
+	if len(ingressURLs) == 0 || routeMonitor.Spec.Probe.Target != "" {
+		ingressURLs = []v1alpha1.IngressURL{{URL: routeMonitor.Status.RouteURL}}
+	}
+	targets := []servicemonitor.Target{}
+	for _, ingressURL := range ingressURLs {
+		target, err := blackboxexporter.ProbeTarget(routeMonitor.Spec.Probe, ingressURL.URL)
+		if err != nil {
+			return utilreconcile.RequeueReconcileWith(err)
+		}
+		targets = append(targets, servicemonitor.Target{URL: target, Router: ingressURL.RouterName})
+	}
+
 	var id string
-	var err error
 	useRHOBS := (routeMonitor.Spec.ServiceMonitorType == v1alpha1.ServiceMonitorTypeRHOBS)
 
 	if useRHOBS {
@@ -98,14 +168,20 @@ func (r *RouteMonitorReconciler) EnsureServiceMonitorExists(routeMonitor v1alpha
 	// update ServiceMonitor if requiredctrl
 	namespacedName := types.NamespacedName{Name: routeMonitor.Name, Namespace: routeMonitor.Namespace}
 	owner := metav1.NewControllerRef(&routeMonitor.ObjectMeta, routeMonitor.GroupVersionKind())
-	if err := r.ServiceMonitor.TemplateAndUpdateServiceMonitorDeployment(routeMonitor.Status.RouteURL, r.BlackBoxExporter.GetBlackBoxExporterNamespace(), namespacedName, id, useRHOBS, routeMonitor.Spec.InsecureSkipTLSVerify, owner); err != nil {
+	module := blackboxexporter.ModuleName(routeMonitor.Namespace, routeMonitor.Status.RouteURL, routeMonitor.Spec.Probe, routeMonitor.Spec.InsecureSkipTLSVerify)
+	result, err := r.ServiceMonitor.TemplateAndUpdateServiceMonitorDeployment(routeMonitor.Status.RouteURL, servicemonitor.WithLocations(targets, routeMonitor.Spec.ProbeLocations), blackBoxExporter, namespacedName, id, useRHOBS, module, owner)
+	if err != nil {
 		return utilreconcile.RequeueReconcileWith(err)
 	}
-	// update ServiceMonitorRef if required
-	updated, err := r.Common.SetResourceReference(&routeMonitor.Status.ServiceMonitorRef, namespacedName)
-	if err != nil {
+	controllers.RecordOperation(r.Recorder, &routeMonitor, result, controllers.KindServiceMonitor, namespacedName.String())
+	// replace the previous ServiceMonitor once the new one exists and update the ServiceMonitorRef
+	if err := controllers.MigrateServiceMonitor(r.ServiceMonitor, r.Recorder, &routeMonitor, routeMonitor.Status.ServiceMonitorRef, namespacedName, useRHOBS); err != nil {
 		return utilreconcile.RequeueReconcileWith(err)
 	}
+	updated := r.Common.SetResourceReference(&routeMonitor.Status.ServiceMonitorRef, namespacedName)
+	if conditions.MarkTrue(&routeMonitor, v1alpha1.ConditionServiceMonitorReady, v1alpha1.ReasonReconciled) {
+		updated = true
+	}
 	if updated {
 		return r.Common.UpdateMonitorResourceStatus(&routeMonitor)
 	}
@@ -116,29 +192,18 @@ func (r *RouteMonitorReconciler) EnsureServiceMonitorExists(routeMonitor v1alpha
 func (r *RouteMonitorReconciler) EnsureMonitorAndDependenciesAbsent(routeMonitor v1alpha1.RouteMonitor) (utilreconcile.Result, error) {
 	log := r.Log.WithName("Delete")
 
-	shouldDeleteBlackBoxResources, err := r.BlackBoxExporter.ShouldDeleteBlackBoxExporterResources()
-	if err != nil {
-		return utilreconcile.RequeueReconcileWith(err)
-	}
-	log.V(2).Info("Response of ShouldDeleteBlackBoxExporterResources", "shouldDeleteBlackBoxResources", shouldDeleteBlackBoxResources)
-
-	if shouldDeleteBlackBoxResources {
-		log.V(2).Info("Entering ensureBlackBoxExporterResourcesAbsent")
-		err := r.BlackBoxExporter.EnsureBlackBoxExporterResourcesAbsent()
-		if err != nil {
-			return utilreconcile.RequeueReconcileWith(err)
-		}
-	}
-
 	log.V(2).Info("Entering ensureServiceMonitorResourceAbsent")
 	isHCP := false
-	if err = r.ServiceMonitor.DeleteServiceMonitorDeployment(routeMonitor.Status.ServiceMonitorRef, isHCP); err != nil {
+	deleted, err := r.ServiceMonitor.DeleteServiceMonitorDeployment(routeMonitor.Status.ServiceMonitorRef, isHCP)
+	if err != nil {
 		return utilreconcile.RequeueReconcileWith(err)
 	}
+	if deleted {
+		controllers.RecordDeletion(r.Recorder, &routeMonitor, controllers.KindServiceMonitor, routeMonitor.Status.ServiceMonitorRef.String())
+	}
 
 	log.V(2).Info("Entering ensurePrometheusRuleResourceAbsent")
-	err = r.Prom.DeletePrometheusRuleDeployment(routeMonitor.Status.PrometheusRuleRef)
-	if err != nil {
+	if err := r.deletePrometheusRule(routeMonitor); err != nil {
 		return utilreconcile.RequeueReconcileWith(err)
 	}
 
@@ -151,6 +216,18 @@ func (r *RouteMonitorReconciler) EnsureMonitorAndDependenciesAbsent(routeMonitor
 	return utilreconcile.StopReconcile()
 }
 
+// deletePrometheusRule deletes the PrometheusRule referenced in the status and records the deletion on the RouteMonitor
+func (r *RouteMonitorReconciler) deletePrometheusRule(routeMonitor v1alpha1.RouteMonitor) error {
+	deleted, err := r.Prom.DeletePrometheusRuleDeployment(routeMonitor.Status.PrometheusRuleRef)
+	if err != nil {
+		return err
+	}
+	if deleted {
+		controllers.RecordDeletion(r.Recorder, &routeMonitor, controllers.KindPrometheusRule, routeMonitor.Status.PrometheusRuleRef.String())
+	}
+	return nil
+}
+
 func (s *RouteMonitorReconciler) EnsureFinalizerSet(routeMonitor v1alpha1.RouteMonitor) (utilreconcile.Result, error) {
 	if s.Common.SetFinalizer(&routeMonitor, consts.FinalizerKey) {
 		// ignore the output as we want to remove the PrevFinalizerKey anyways
@@ -201,46 +278,154 @@ func (r *RouteMonitorReconciler) GetRoute(routeMonitor v1alpha1.RouteMonitor) (r
 
 // EnsureRouteURLExists verifies that the .spec.RouteURL has the Route URL inside
 func (r *RouteMonitorReconciler) EnsureRouteURLExists(route routev1.Route, routeMonitor v1alpha1.RouteMonitor) (utilreconcile.Result, error) {
+	ingresses, err := r.selectIngresses(route, routeMonitor.Spec.Route.IngressSelector)
+	if err != nil {
+		return utilreconcile.RequeueReconcileWith(err)
+	}
+
+	ingressURLs := []v1alpha1.IngressURL{}
+	for _, ingress := range ingresses {
+		if ingress.Host == "" {
+			return utilreconcile.RequeueReconcileWith(customerrors.ErrNoHost)
+		}
+		ingressURLs = append(ingressURLs, v1alpha1.IngressURL{RouterName: ingress.RouterName, URL: r.routeURL(ingress.Host, route, routeMonitor)})
+	}
+	extractedRouteURL := ingressURLs[0].URL
+	// the urls of the ingresses are only tracked if they are probed separately
+	if selector := routeMonitor.Spec.Route.IngressSelector; selector == nil || selector.Mode != v1alpha1.IngressSelectorModeAll {
+		ingressURLs = nil
+	}
+
+	currentRouteURL := routeMonitor.Status.RouteURL
+	routeResolved := conditions.MarkTrue(&routeMonitor, v1alpha1.ConditionRouteResolved, v1alpha1.ReasonReconciled)
+	if currentRouteURL == extractedRouteURL && reflect.DeepEqual(routeMonitor.Status.IngressURLs, ingressURLs) {
+		if routeResolved {
+			return r.Common.UpdateMonitorResourceStatus(&routeMonitor)
+		}
+		r.Log.V(3).Info("Same RouteURL: currentRouteURL and extractedRouteURL are equal, update not required")
+		return utilreconcile.ContinueReconcile()
+	}
+
+	if currentRouteURL != "" && extractedRouteURL != currentRouteURL {
+		r.Log.V(3).Info("RouteURL mismatch: currentRouteURL and extractedRouteURL are not equal, taking extractedRouteURL as source of truth")
+	}
+
+	routeMonitor.Status.RouteURL = extractedRouteURL
+	routeMonitor.Status.IngressURLs = ingressURLs
+	return r.Common.UpdateMonitorResourceStatus(&routeMonitor)
+}
+
+// selectIngresses returns the ingresses of the Route probed according to the selector
+func (r *RouteMonitorReconciler) selectIngresses(route routev1.Route, selector *v1alpha1.RouteIngressSelector) ([]routev1.RouteIngress, error) {
 	amountOfIngress := len(route.Status.Ingress)
 	if amountOfIngress == 0 {
-		err := errors.New("no Ingress: cannot extract route url from the Route resource")
-		return utilreconcile.RequeueReconcileWith(err)
+		return nil, errors.New("no Ingress: cannot extract route url from the Route resource")
+	}
+
+	mode := v1alpha1.IngressSelectorModeFirst
+	if selector != nil && selector.Mode != "" {
+		mode = selector.Mode
+	}
+	switch mode {
+	case v1alpha1.IngressSelectorModeByRouterName:
+		for _, ingress := range route.Status.Ingress {
+			if ingress.RouterName == selector.RouterName {
+				return []routev1.RouteIngress{ingress}, nil
+			}
+		}
+		return nil, fmt.Errorf("no Ingress: the Route has no ingress of the router '%s'", selector.RouterName)
+	case v1alpha1.IngressSelectorModeAll:
+		admitted := []routev1.RouteIngress{}
+		for _, ingress := range route.Status.Ingress {
+			if isAdmitted(ingress) {
+				admitted = append(admitted, ingress)
+			}
+		}
+		if len(admitted) == 0 {
+			return nil, errors.New("no Ingress: the Route is not admitted by any router")
+		}
+		return admitted, nil
 	}
-	extractedRouteURL := route.Status.Ingress[0].Host
+
 	if amountOfIngress > 1 {
-		r.Log.V(1).Info(fmt.Sprintf("Too many Ingress: assuming first ingress is the correct, chosen ingress '%s'", extractedRouteURL))
+		r.Log.V(1).Info(fmt.Sprintf("Too many Ingress: assuming first ingress is the correct, chosen ingress '%s'", route.Status.Ingress[0].Host))
 	}
+	return route.Status.Ingress[:1], nil
+}
 
-	if extractedRouteURL == "" {
-		return utilreconcile.RequeueReconcileWith(customerrors.ErrNoHost)
+// isAdmitted returns whether the router admitted the ingress
+func isAdmitted(ingress routev1.RouteIngress) bool {
+	for _, condition := range ingress.Conditions {
+		if condition.Type == routev1.RouteAdmitted {
+			return condition.Status == corev1.ConditionTrue
+		}
 	}
+	return false
+}
 
+// routeURL returns the url probed for the host of an ingress of the Route
+func (r *RouteMonitorReconciler) routeURL(host string, route routev1.Route, routeMonitor v1alpha1.RouteMonitor) string {
+	routeURL := host
 	if routeMonitor.Spec.Route.Port != 0 {
-		extractedRouteURL = fmt.Sprintf("%s:%d", extractedRouteURL, routeMonitor.Spec.Route.Port)
+		routeURL = fmt.Sprintf("%s:%d", routeURL, routeMonitor.Spec.Route.Port)
 	}
 	if routeMonitor.Spec.Route.Suffix != "" {
-		extractedRouteURL = fmt.Sprintf("%s%s", extractedRouteURL, routeMonitor.Spec.Route.Suffix)
+		routeURL = fmt.Sprintf("%s%s", routeURL, routeMonitor.Spec.Route.Suffix)
 	}
-
-	currentRouteURL := routeMonitor.Status.RouteURL
 	if route.Spec.TLS != nil {
 		r.Log.V(3).Info("TLS detected: adding https to extractedRouteURL as the url ")
-		extractedRouteURL = fmt.Sprintf("https://%s", extractedRouteURL)
+		routeURL = fmt.Sprintf("https://%s", routeURL)
 	}
+	return routeURL
+}
 
-	if currentRouteURL == extractedRouteURL {
-		r.Log.V(3).Info("Same RouteURL: currentRouteURL and extractedRouteURL are equal, update not required")
-		return utilreconcile.ContinueReconcile()
+// resolveProbeStatus picks up the Prometheus endpoints of the current operator configuration
+func (r *RouteMonitorReconciler) resolveProbeStatus() {
+	if r.ProbeStatusReloader == nil {
+		return
+	}
+	// the client of the previous endpoints is returned along with the errors of the configuration
+	probeStatus, err := r.ProbeStatusReloader.Get(r.Ctx)
+	if err != nil {
+		r.Log.Error(err, "Failed to resolve the Prometheus endpoints of the probe status")
+	}
+	r.ProbeStatus = nil
+	if probeStatus != nil {
+		r.ProbeStatus = probeStatus
 	}
+}
 
-	if currentRouteURL != "" && extractedRouteURL != currentRouteURL {
-		r.Log.V(3).Info("RouteURL mismatch: currentRouteURL and extractedRouteURL are not equal, taking extractedRouteURL as source of truth")
+// EnsureProbeStatusUpdated records the health of the probes queried from Prometheus in the status.
+// Queries are throttled to the refresh interval, as every status update triggers another reconcile
+func (r *RouteMonitorReconciler) EnsureProbeStatusUpdated(routeMonitor v1alpha1.RouteMonitor) (utilreconcile.Result, error) {
+	if r.ProbeStatus == nil {
+		return utilreconcile.ContinueReconcile()
+	}
+	if wait := probestatus.NextRefresh(routeMonitor.Status.ProbeStatus, r.ProbeStatus.RefreshInterval()); wait > 0 {
+		return utilreconcile.Result{Continue: true, RequeueAfter: wait}, nil
 	}
 
-	routeMonitor.Status.RouteURL = extractedRouteURL
+	useRHOBS := (routeMonitor.Spec.ServiceMonitorType == v1alpha1.ServiceMonitorTypeRHOBS)
+	status, err := r.ProbeStatus.GetProbeStatus(routeMonitor.Status.RouteURL, useRHOBS, routeMonitor.Spec.Slo)
+	if err != nil {
+		r.Log.V(2).Info("Failed to query the probe status", "error", err.Error())
+	}
+	routeMonitor.Status.ProbeStatus = probestatus.Record(&routeMonitor, routeMonitor.Status.ProbeStatus, status, err)
 	return r.Common.UpdateMonitorResourceStatus(&routeMonitor)
 }
 
+// EnsureMaintenanceStatusUpdated reports the maintenance window in progress in the status. It requeues when the next
+// window starts or ends, so the status and the windows of the PrometheusRule follow the schedule
+func (r *RouteMonitorReconciler) EnsureMaintenanceStatusUpdated(routeMonitor v1alpha1.RouteMonitor) (utilreconcile.Result, error) {
+	now := time.Now()
+	windows := maintenance.Upcoming(routeMonitor.Spec.MaintenanceWindows, now)
+	if active := maintenance.Active(windows, now); !maintenance.Equal(active, routeMonitor.Status.ActiveMaintenanceWindow) {
+		routeMonitor.Status.ActiveMaintenanceWindow = active
+		return r.Common.UpdateMonitorResourceStatus(&routeMonitor)
+	}
+	return utilreconcile.Result{Continue: true, RequeueAfter: maintenance.NextTransition(windows, now)}, nil
+}
+
 // getHostedControlPlane retrieves the HostedControlPlane object from the provided namespace. It's expected that only a single HCP object is present in the namespace,
 // if multiple are found, an error is returned instead.
 func (r *RouteMonitorReconciler) getHostedControlPlane(namespace string) (hypershiftv1beta1.HostedControlPlane, error) {
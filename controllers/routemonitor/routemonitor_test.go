@@ -7,25 +7,38 @@ import (
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
 
+	"context"
+	"strings"
 	"time"
 
 	// tested package
+	"github.com/openshift/route-monitor-operator/controllers"
 	"github.com/openshift/route-monitor-operator/controllers/routemonitor"
 
 	routev1 "github.com/openshift/api/route/v1"
+	corev1 "k8s.io/api/core/v1"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/openshift/route-monitor-operator/api/v1alpha1"
 	routemonitorconst "github.com/openshift/route-monitor-operator/pkg/consts"
-	"github.com/openshift/route-monitor-operator/pkg/consts/blackboxexporter"
 	consterror "github.com/openshift/route-monitor-operator/pkg/consts/test/error"
 	constinit "github.com/openshift/route-monitor-operator/pkg/consts/test/init"
+	reconcileCommon "github.com/openshift/route-monitor-operator/pkg/reconcile"
+	"github.com/openshift/route-monitor-operator/pkg/util/conditions"
 	customerrors "github.com/openshift/route-monitor-operator/pkg/util/errors"
 	utilreconcile "github.com/openshift/route-monitor-operator/pkg/util/reconcile"
 	clientmocks "github.com/openshift/route-monitor-operator/pkg/util/test/generated/mocks/client"
@@ -42,6 +55,7 @@ var _ = Describe("Routemonitor", func() {
 		mockUtils            *controllermocks.MockMonitorResourceHandler
 		mockPrometheusRule   *controllermocks.MockPrometheusRuleHandler
 		mockServiceMonitor   *controllermocks.MockServiceMonitorHandler
+		recorder             *record.FakeRecorder
 
 		update helper.MockHelper
 		delete helper.MockHelper
@@ -61,6 +75,7 @@ var _ = Describe("Routemonitor", func() {
 		mockUtils = controllermocks.NewMockMonitorResourceHandler(mockCtrl)
 		mockServiceMonitor = controllermocks.NewMockServiceMonitorHandler(mockCtrl)
 		mockPrometheusRule = controllermocks.NewMockPrometheusRuleHandler(mockCtrl)
+		recorder = record.NewFakeRecorder(10)
 
 		routeMonitorReconciler = routemonitor.RouteMonitorReconciler{
 			Log:              logr.Discard(),
@@ -70,6 +85,7 @@ var _ = Describe("Routemonitor", func() {
 			Common:           mockUtils,
 			ServiceMonitor:   mockServiceMonitor,
 			Prom:             mockPrometheusRule,
+			Recorder:         recorder,
 		}
 
 		update = helper.MockHelper{}
@@ -124,200 +140,93 @@ var _ = Describe("Routemonitor", func() {
 	//--------------------------------------------------------------------------------------
 	Describe("EnsureMonitorAndDependenciesAbsent", func() {
 		var (
-			shouldDeleteBlackBoxExporterResources         helper.MockHelper
-			ensureBlackBoxExporterResourcesAbsent         helper.MockHelper
-			ensureBlackBoxExporterResourcesExist          helper.MockHelper
-			deleteServiceMonitorDeployment                helper.MockHelper
-			deletePrometheusRuleDeployment                helper.MockHelper
-			deleteFinalizer                               helper.MockHelper
-			shouldDeleteBlackBoxExporterResourcesResponse blackboxexporter.ShouldDeleteBlackBoxExporter
+			deleteServiceMonitorDeployment helper.MockHelper
+			deletePrometheusRuleDeployment helper.MockHelper
+			deleteFinalizer                helper.MockHelper
 
 			res utilreconcile.Result
 			err error
 		)
 		BeforeEach(func() {
-			shouldDeleteBlackBoxExporterResources = helper.MockHelper{}
-			ensureBlackBoxExporterResourcesAbsent = helper.MockHelper{}
-			ensureBlackBoxExporterResourcesExist = helper.MockHelper{}
-			deleteServiceMonitorDeployment = helper.MockHelper{}
-			deletePrometheusRuleDeployment = helper.MockHelper{}
+			deleteServiceMonitorDeployment = helper.MockHelper{CalledTimes: 1}
+			deletePrometheusRuleDeployment = helper.MockHelper{CalledTimes: 1}
 			deleteFinalizer = helper.MockHelper{}
-			shouldDeleteBlackBoxExporterResourcesResponse = blackboxexporter.KeepBlackBoxExporter
 		})
 		JustBeforeEach(func() {
-
-			shouldDeleteBlackBoxExporterResources.CalledTimes = 1
-
-			mockBlackboxExporter.EXPECT().EnsureBlackBoxExporterResourcesAbsent().
-				Times(ensureBlackBoxExporterResourcesAbsent.CalledTimes).
-				Return(ensureBlackBoxExporterResourcesAbsent.ErrorResponse)
-
-			mockBlackboxExporter.EXPECT().ShouldDeleteBlackBoxExporterResources().
-				Times(shouldDeleteBlackBoxExporterResources.CalledTimes).
-				Return(shouldDeleteBlackBoxExporterResourcesResponse, shouldDeleteBlackBoxExporterResources.ErrorResponse)
-
-			mockBlackboxExporter.EXPECT().EnsureBlackBoxExporterResourcesExist().
-				Times(ensureBlackBoxExporterResourcesExist.CalledTimes).
-				Return(ensureBlackBoxExporterResourcesExist.ErrorResponse)
-
 			mockServiceMonitor.EXPECT().DeleteServiceMonitorDeployment(gomock.Any(), gomock.Any()).
 				Times(deleteServiceMonitorDeployment.CalledTimes).
-				Return(deleteServiceMonitorDeployment.ErrorResponse)
+				Return(deleteServiceMonitorDeployment.ErrorResponse == nil, deleteServiceMonitorDeployment.ErrorResponse)
 
 			mockPrometheusRule.EXPECT().DeletePrometheusRuleDeployment(gomock.Any()).
 				Times(deletePrometheusRuleDeployment.CalledTimes).
-				Return(deletePrometheusRuleDeployment.ErrorResponse)
+				Return(deletePrometheusRuleDeployment.ErrorResponse == nil, deletePrometheusRuleDeployment.ErrorResponse)
 
 			// act
 			res, err = routeMonitorReconciler.EnsureMonitorAndDependenciesAbsent(routeMonitor)
 		})
-		When("func ShouldDeleteBlackBoxExporterResources fails unexpectedly", func() {
+		When("func EnsureServiceMonitorResourceAbsent fails unexpectedly", func() {
 			BeforeEach(func() {
-				shouldDeleteBlackBoxExporterResources.ErrorResponse = consterror.ErrCustomError
+				deleteServiceMonitorDeployment.ErrorResponse = consterror.ErrCustomError
+				deletePrometheusRuleDeployment.CalledTimes = 0
 			})
 			It("should bubble up the error", func() {
 				Expect(err).To(HaveOccurred())
 				Expect(err).To(MatchError(consterror.ErrCustomError))
 			})
 		})
-		Describe("ShouldDeleteBlackBoxExporterResources instructs to delete", func() {
+		When("func EnsurePrometheusRuleResourceAbsent fails unexpectedly", func() {
 			BeforeEach(func() {
-				shouldDeleteBlackBoxExporterResourcesResponse = blackboxexporter.DeleteBlackBoxExporter
-				ensureBlackBoxExporterResourcesAbsent.CalledTimes = 1
+				deletePrometheusRuleDeployment.ErrorResponse = consterror.ErrCustomError
 			})
-			When("func EnsureBlackBoxExporterServiceAbsent fails unexpectedly", func() {
-				BeforeEach(func() {
-					ensureBlackBoxExporterResourcesAbsent.ErrorResponse = consterror.ErrCustomError
-				})
-				It("should bubble up the error", func() {
-					Expect(err).To(HaveOccurred())
-					Expect(err).To(MatchError(consterror.ErrCustomError))
-				})
-			})
-			When("func EnsureBlackBoxExporterDeploymentAbsent fails unexpectedly", func() {
-				BeforeEach(func() {
-					ensureBlackBoxExporterResourcesAbsent = helper.CustomErrorHappensOnce()
-				})
-				It("should bubble up the error", func() {
-					Expect(err).To(HaveOccurred())
-					Expect(err).To(MatchError(consterror.ErrCustomError))
-				})
-			})
-			When("func deleteServiceMonitorDeployment fails unexpectedly", func() {
-				BeforeEach(func() {
-					ensureBlackBoxExporterResourcesAbsent.CalledTimes = 1
-					deleteServiceMonitorDeployment = helper.CustomErrorHappensOnce()
-				})
-				It("should bubble up the error", func() {
-					Expect(err).To(HaveOccurred())
-					Expect(err).To(MatchError(consterror.ErrCustomError))
-				})
-			})
-			When("func DeletePrometheusRuleDeployment fails unexpectedly", func() {
-				BeforeEach(func() {
-					ensureBlackBoxExporterResourcesAbsent.CalledTimes = 1
-					deleteServiceMonitorDeployment.CalledTimes = 1
-					deletePrometheusRuleDeployment = helper.CustomErrorHappensOnce()
-				})
-				It("should bubble up the error", func() {
-					Expect(err).To(HaveOccurred())
-					Expect(err).To(MatchError(consterror.ErrCustomError))
-				})
+			It("should bubble up the error", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(err).To(MatchError(consterror.ErrCustomError))
 			})
-			When("func EnsureFinalizerAbsent fails unexpectedly", func() {
-				BeforeEach(func() {
-					ensureBlackBoxExporterResourcesAbsent.CalledTimes = 1
-					deleteServiceMonitorDeployment.CalledTimes = 1
-					deletePrometheusRuleDeployment.CalledTimes = 1
-					mockUtils.EXPECT().DeleteFinalizer(gomock.Any(), gomock.Any()).Return(true).Times(2)
-					mockUtils.EXPECT().UpdateMonitorResource(gomock.Any()).Return(utilreconcile.RequeueOperation(), consterror.ErrCustomError)
-				})
-				It("should bubble up the error", func() {
-					Expect(err).To(HaveOccurred())
-					Expect(err).To(MatchError(consterror.ErrCustomError))
-				})
+		})
+		When("the resource has a finalizer but 'Update' failed", func() {
+			BeforeEach(func() {
+				mockUtils.EXPECT().DeleteFinalizer(gomock.Any(), gomock.Any()).Return(true).Times(2)
+				mockUtils.EXPECT().UpdateMonitorResource(gomock.Any()).Return(utilreconcile.RequeueOperation(), consterror.ErrCustomError)
 			})
-			When("all deletions happened successfully", func() {
-				BeforeEach(func() {
-					ensureBlackBoxExporterResourcesAbsent.CalledTimes = 1
-					deleteServiceMonitorDeployment.CalledTimes = 1
-					deletePrometheusRuleDeployment.CalledTimes = 1
-					deleteFinalizer.CalledTimes = 1
-					mockUtils.EXPECT().DeleteFinalizer(gomock.Any(), gomock.Any()).Return(true).Times(2)
-					mockUtils.EXPECT().UpdateMonitorResource(gomock.Any())
-				})
-				It("should reconcile", func() {
-					Expect(err).NotTo(HaveOccurred())
-					Expect(res).To(Equal(utilreconcile.StopOperation()))
-				})
+			It("Should bubble up the failure", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(err).To(MatchError(consterror.ErrCustomError))
 			})
 		})
-		When("ShouldDeleteBlackBoxExporterResources instructs to keep the BlackBoxExporter", func() {
+		When("the resource has a finalizer but 'Update' succeeds", func() {
 			BeforeEach(func() {
-				shouldDeleteBlackBoxExporterResources.CalledTimes = 1
-				shouldDeleteBlackBoxExporterResourcesResponse = blackboxexporter.KeepBlackBoxExporter
-				deleteServiceMonitorDeployment.CalledTimes = 1
-				deletePrometheusRuleDeployment.CalledTimes = 1
+				mockUtils.EXPECT().DeleteFinalizer(gomock.Any(), gomock.Any()).Return(true).Times(2)
+				mockUtils.EXPECT().UpdateMonitorResource(gomock.Any()).Return(utilreconcile.StopOperation(), nil)
 			})
-			When("func EnsureServiceMonitorResourceAbsent fails unexpectedly", func() {
-				BeforeEach(func() {
-					deleteServiceMonitorDeployment.ErrorResponse = consterror.ErrCustomError
-					deletePrometheusRuleDeployment.CalledTimes = 0
-				})
-				It("should bubble up the error", func() {
-					Expect(err).To(HaveOccurred())
-					Expect(err).To(MatchError(consterror.ErrCustomError))
-				})
+			It("Should succeed and call for a requeue", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res).NotTo(BeNil())
+				Expect(res).To(Equal(utilreconcile.StopOperation()))
 			})
-			When("func EnsurePrometheusRuleResourceAbsent fails unexpectedly", func() {
-				BeforeEach(func() {
-					deletePrometheusRuleDeployment.ErrorResponse = consterror.ErrCustomError
-				})
-				It("should bubble up the error", func() {
-					Expect(err).To(HaveOccurred())
-					Expect(err).To(MatchError(consterror.ErrCustomError))
-				})
+			It("records the deletions on the RouteMonitor and leaves the blackbox exporter to its controller", func() {
+				Expect(recorder.Events).To(Receive(ContainSubstring("ServiceMonitorDeleted")))
+				Expect(recorder.Events).To(Receive(ContainSubstring("PrometheusRuleDeleted")))
+				Expect(recorder.Events).NotTo(Receive(ContainSubstring("BlackBoxExporterDeleted")))
 			})
-			When("the resource has a finalizer but 'Update' failed", func() {
-				BeforeEach(func() {
-					mockUtils.EXPECT().DeleteFinalizer(gomock.Any(), gomock.Any()).Return(true).Times(2)
-					mockUtils.EXPECT().UpdateMonitorResource(gomock.Any()).Return(utilreconcile.RequeueOperation(), consterror.ErrCustomError)
-				})
-				It("Should bubble up the failure", func() {
-					Expect(err).To(HaveOccurred())
-					Expect(err).To(MatchError(consterror.ErrCustomError))
-				})
+		})
+		When("resorce has no finalizer", func() {
+			BeforeEach(func() {
+				routeMonitorFinalizers = []string{}
+				routeMonitorDeletionTimestamp = &metav1.Time{Time: time.Unix(0, 0)}
+				delete.CalledTimes = 1
+				mockUtils.EXPECT().DeleteFinalizer(gomock.Any(), gomock.Any()).Return(false)
 			})
-			When("the resource has a finalizer but 'Update' succeeds", func() {
+			When("no deletion was requested", func() {
 				BeforeEach(func() {
-					mockUtils.EXPECT().DeleteFinalizer(gomock.Any(), gomock.Any()).Return(true).Times(2)
-					mockUtils.EXPECT().UpdateMonitorResource(gomock.Any()).Return(utilreconcile.StopOperation(), nil)
+					routeMonitorDeletionTimestamp = nil
+					delete.CalledTimes = 0
+					deleteFinalizer.CalledTimes = 1
 				})
-				It("Should succeed and call for a requeue", func() {
+				It("should skip next steps and stop processing", func() {
 					Expect(err).NotTo(HaveOccurred())
-					Expect(res).NotTo(BeNil())
 					Expect(res).To(Equal(utilreconcile.StopOperation()))
 				})
 			})
-			When("resorce has no finalizer", func() {
-				BeforeEach(func() {
-					routeMonitorFinalizers = []string{}
-					routeMonitorDeletionTimestamp = &metav1.Time{Time: time.Unix(0, 0)}
-					delete.CalledTimes = 1
-					mockUtils.EXPECT().DeleteFinalizer(gomock.Any(), gomock.Any()).Return(false)
-				})
-				When("no deletion was requested", func() {
-					BeforeEach(func() {
-						routeMonitorDeletionTimestamp = nil
-						delete.CalledTimes = 0
-						deleteFinalizer.CalledTimes = 1
-					})
-					It("should skip next steps and stop processing", func() {
-						Expect(err).NotTo(HaveOccurred())
-						Expect(res).To(Equal(utilreconcile.StopOperation()))
-					})
-				})
-			})
 		})
 	})
 
@@ -505,6 +414,8 @@ var _ = Describe("Routemonitor", func() {
 			res       utilreconcile.Result
 			err       error
 			ingresses []string
+			// routeIngresses replace the ingresses built from the hosts if set
+			routeIngresses []routev1.RouteIngress
 		)
 
 		// Start Fuzz testing for values
@@ -517,6 +428,7 @@ var _ = Describe("Routemonitor", func() {
 				Namespace: routeMonitorNamespace,
 			}
 			expectedRouteMonitor = routeMonitor
+			routeIngresses = nil
 		})
 
 		JustBeforeEach(func() {
@@ -525,6 +437,9 @@ var _ = Describe("Routemonitor", func() {
 					Ingress: ConvertToIngressHosts(ingresses),
 				},
 			}
+			if routeIngresses != nil {
+				route.Status.Ingress = routeIngresses
+			}
 
 			// act
 			res, err = routeMonitorReconciler.EnsureRouteURLExists(route, routeMonitor)
@@ -612,6 +527,60 @@ var _ = Describe("Routemonitor", func() {
 			})
 		})
 
+		When("the Route is admitted by several routers", func() {
+			var updated *v1alpha1.RouteMonitor
+			BeforeEach(func() {
+				admitted := []routev1.RouteIngressCondition{{Type: routev1.RouteAdmitted, Status: corev1.ConditionTrue}}
+				routeIngresses = []routev1.RouteIngress{
+					{Host: "default.example.com", RouterName: "default", Conditions: admitted},
+					{Host: "rejected.example.com", RouterName: "rejected"},
+					{Host: "private.example.com", RouterName: "private", Conditions: admitted},
+				}
+				updated = nil
+			})
+			When("the ingress is selected by router name", func() {
+				BeforeEach(func() {
+					routeMonitor.Spec.Route.IngressSelector = &v1alpha1.RouteIngressSelector{Mode: v1alpha1.IngressSelectorModeByRouterName, RouterName: "private"}
+					mockUtils.EXPECT().UpdateMonitorResourceStatus(gomock.Any()).DoAndReturn(func(monitor *v1alpha1.RouteMonitor) (utilreconcile.Result, error) {
+						updated = monitor
+						return utilreconcile.StopReconcile()
+					})
+				})
+				It("probes the ingress of the router", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(updated.Status.RouteURL).To(Equal("private.example.com"))
+					Expect(updated.Status.IngressURLs).To(BeEmpty())
+				})
+			})
+			When("the selected router has no ingress", func() {
+				BeforeEach(func() {
+					routeMonitor.Spec.Route.IngressSelector = &v1alpha1.RouteIngressSelector{Mode: v1alpha1.IngressSelectorModeByRouterName, RouterName: "unknown"}
+				})
+				It("requeues with an error", func() {
+					Expect(res).To(Equal(utilreconcile.RequeueOperation()))
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(HavePrefix("no Ingress:"))
+				})
+			})
+			When("all ingresses are selected", func() {
+				BeforeEach(func() {
+					routeMonitor.Spec.Route.IngressSelector = &v1alpha1.RouteIngressSelector{Mode: v1alpha1.IngressSelectorModeAll}
+					mockUtils.EXPECT().UpdateMonitorResourceStatus(gomock.Any()).DoAndReturn(func(monitor *v1alpha1.RouteMonitor) (utilreconcile.Result, error) {
+						updated = monitor
+						return utilreconcile.StopReconcile()
+					})
+				})
+				It("tracks the urls of the admitted ingresses", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(updated.Status.RouteURL).To(Equal("default.example.com"))
+					Expect(updated.Status.IngressURLs).To(Equal([]v1alpha1.IngressURL{
+						{RouterName: "default", URL: "default.example.com"},
+						{RouterName: "private", URL: "private.example.com"},
+					}))
+				})
+			})
+		})
+
 		When("the Route has the same RouteURL as the extracted one", func() {
 			BeforeEach(func() {
 				ingresses = []string{
@@ -626,9 +595,27 @@ var _ = Describe("Routemonitor", func() {
 			JustBeforeEach(func() {
 				expectedRouteMonitor.Status.RouteURL = "fake-route-url"
 			})
-			It("should skip this operation", func() {
-				Expect(err).NotTo(HaveOccurred())
-				Expect(res).To(Equal(utilreconcile.ContinueOperation()))
+			When("the RouteResolved condition is already set", func() {
+				BeforeEach(func() {
+					conditions.MarkTrue(&routeMonitor, v1alpha1.ConditionRouteResolved, v1alpha1.ReasonReconciled)
+				})
+				It("should skip this operation", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(res).To(Equal(utilreconcile.ContinueOperation()))
+				})
+			})
+			When("the RouteResolved condition is not set yet", func() {
+				BeforeEach(func() {
+					mockUtils.EXPECT().UpdateMonitorResourceStatus(gomock.Any()).Times(1).DoAndReturn(
+						func(monitor *v1alpha1.RouteMonitor) (utilreconcile.Result, error) {
+							Expect(meta.IsStatusConditionTrue(monitor.Status.Conditions, v1alpha1.ConditionRouteResolved)).To(BeTrue())
+							return utilreconcile.StopReconcile()
+						})
+				})
+				It("should set the condition", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(res).To(Equal(utilreconcile.StopOperation()))
+				})
 			})
 		})
 	})
@@ -674,11 +661,12 @@ var _ = Describe("Routemonitor", func() {
 			Describe("It deletes existing PrometheusRules", func() {
 				BeforeEach(func() {
 					routeMonitor.Status.PrometheusRuleRef = v1alpha1.NamespacedName{Name: "test", Namespace: "test2"}
+					conditions.MarkFalse(&routeMonitor, v1alpha1.ConditionPrometheusRuleReady, v1alpha1.ReasonInvalidSpec, customerrors.ErrNoHost)
 					mockUtils.EXPECT().SetErrorStatus(gomock.Any(), customerrors.ErrNoHost).Return(false)
 				})
 				When("the PrometheusRule deletion fails", func() {
 					BeforeEach(func() {
-						mockPrometheusRule.EXPECT().DeletePrometheusRuleDeployment(routeMonitor.Status.PrometheusRuleRef).Times(1).Return(consterror.ErrCustomError)
+						mockPrometheusRule.EXPECT().DeletePrometheusRuleDeployment(routeMonitor.Status.PrometheusRuleRef).Times(1).Return(false, consterror.ErrCustomError)
 					})
 					It("should reconcile with the particular error", func() {
 						Expect(err).To(Equal(consterror.ErrCustomError))
@@ -691,7 +679,7 @@ var _ = Describe("Routemonitor", func() {
 					})
 					When("updating PrometheusRuleRef in the RouteMonitor fails", func() {
 						BeforeEach(func() {
-							mockUtils.EXPECT().SetResourceReference(gomock.Any(), gomock.Any()).Return(true, nil)
+							mockUtils.EXPECT().SetResourceReference(gomock.Any(), gomock.Any()).Return(true)
 							mockUtils.EXPECT().UpdateMonitorResourceStatus(gomock.Any()).Return(utilreconcile.RequeueOperation(), consterror.ErrCustomError)
 						})
 						It("should reconcile with the particular error", func() {
@@ -701,7 +689,7 @@ var _ = Describe("Routemonitor", func() {
 					})
 					When("updating PrometheusRuleRef in the RouteMonitor was successful", func() {
 						BeforeEach(func() {
-							mockUtils.EXPECT().SetResourceReference(gomock.Any(), gomock.Any()).Return(true, nil)
+							mockUtils.EXPECT().SetResourceReference(gomock.Any(), gomock.Any()).Return(true)
 							mockUtils.EXPECT().UpdateMonitorResourceStatus(gomock.Any()).Return(utilreconcile.StopOperation(), nil)
 						})
 						It("stops reconciling", func() {
@@ -712,6 +700,79 @@ var _ = Describe("Routemonitor", func() {
 				})
 			})
 		})
+		When("the certificate expiry thresholds are INVALID", func() {
+			BeforeEach(func() {
+				routeMonitor.Spec.CertificateExpiry = &v1alpha1.CertificateExpirySpec{WarningDays: 7, CriticalDays: 30}
+				mockUtils.EXPECT().ParseMonitorSLOSpecs(routeMonitor.Status.RouteURL, routeMonitor.Spec.Slo).Return("", nil).Times(1)
+				mockUtils.EXPECT().SetErrorStatus(gomock.Any(), customerrors.ErrInvalidCertificateExpiry).Return(true)
+				mockUtils.EXPECT().UpdateMonitorResourceStatus(gomock.Any()).Return(utilreconcile.StopOperation(), nil)
+			})
+			It("sets the error and stops reconciling", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp).To(Equal(utilreconcile.StopOperation()))
+			})
+		})
+		When("an invalid spec is reconciled twice", func() {
+			BeforeEach(func() {
+				routeMonitor.DeletionTimestamp = nil
+				routeMonitor.Spec.CertificateExpiry = &v1alpha1.CertificateExpirySpec{WarningDays: 7, CriticalDays: 30}
+				fakeClient := fake.NewClientBuilder().
+					WithScheme(constinit.Scheme).
+					WithObjects(&routeMonitor).
+					WithStatusSubresource(&v1alpha1.RouteMonitor{}).
+					Build()
+				routeMonitorReconciler.Client = fakeClient
+				routeMonitorReconciler.Common = reconcileCommon.NewMonitorResourceCommon(context.TODO(), fakeClient)
+				// any call of UpdatePrometheusRuleDeployment fails the test
+				mockPrometheusRule.EXPECT().DeletePrometheusRuleDeployment(gomock.Any()).AnyTimes()
+			})
+			It("keeps the condition false and applies no PrometheusRule", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp).To(Equal(utilreconcile.StopOperation()))
+
+				key := types.NamespacedName{Name: routeMonitor.Name, Namespace: routeMonitor.Namespace}
+				stored := v1alpha1.RouteMonitor{}
+				Expect(routeMonitorReconciler.Client.Get(context.TODO(), key, &stored)).To(Succeed())
+				resp, err = routeMonitorReconciler.EnsurePrometheusRuleExists(stored)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp).To(Equal(utilreconcile.StopOperation()))
+
+				Expect(routeMonitorReconciler.Client.Get(context.TODO(), key, &stored)).To(Succeed())
+				Expect(stored.Status.ErrorStatus).To(Equal(customerrors.ErrInvalidCertificateExpiry.Error()))
+				Expect(meta.IsStatusConditionFalse(stored.Status.Conditions, v1alpha1.ConditionPrometheusRuleReady)).To(BeTrue())
+			})
+		})
+		When("the latency objective sets a phase for a tcp probe", func() {
+			BeforeEach(func() {
+				routeMonitor.Spec.Probe = v1alpha1.ProbeSpec{Type: v1alpha1.ProbeTypeTCP, Target: "db.example.com:5432"}
+				routeMonitor.Spec.Slo.Latency = &v1alpha1.LatencySloSpec{TargetPercent: "99", Threshold: "800ms", Phase: "connect"}
+				mockUtils.EXPECT().ParseMonitorSLOSpecs(routeMonitor.Status.RouteURL, routeMonitor.Spec.Slo).Return("0.995", nil).Times(1)
+				mockUtils.EXPECT().SetErrorStatus(gomock.Any(), customerrors.ErrInvalidLatencySLO).Return(true)
+				mockUtils.EXPECT().UpdateMonitorResourceStatus(gomock.Any()).Return(utilreconcile.StopOperation(), nil)
+			})
+			It("sets the error and stops reconciling", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp).To(Equal(utilreconcile.StopOperation()))
+			})
+		})
+		When("only the certificate expiry is monitored", func() {
+			BeforeEach(func() {
+				routeMonitor.Spec.CertificateExpiry = &v1alpha1.CertificateExpirySpec{WarningDays: 30, CriticalDays: 7}
+				conditions.MarkTrue(&routeMonitor, v1alpha1.ConditionPrometheusRuleReady, v1alpha1.ReasonReconciled)
+				mockUtils.EXPECT().ParseMonitorSLOSpecs(routeMonitor.Status.RouteURL, routeMonitor.Spec.Slo).Return("", nil).Times(1)
+				mockUtils.EXPECT().SetErrorStatus(gomock.Any(), nil).Return(false)
+				mockPrometheusRule.EXPECT().UpdatePrometheusRuleDeployment(gomock.Any()).DoAndReturn(func(template monitoringv1.PrometheusRule) (controllerutil.OperationResult, error) {
+					Expect(template.Spec.Groups).To(HaveLen(1))
+					Expect(template.Spec.Groups[0].Name).To(Equal("certificate-expiry"))
+					return controllerutil.OperationResultNone, nil
+				})
+				mockUtils.EXPECT().SetResourceReference(gomock.Any(), gomock.Any()).Return(false)
+			})
+			It("creates a PrometheusRule with the certificate expiry alerts", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp).To(Equal(utilreconcile.ContinueOperation()))
+			})
+		})
 		Describe("The RouteMonitor settings are VALID", func() {
 			BeforeEach(func() {
 				mockUtils.EXPECT().ParseMonitorSLOSpecs(routeMonitor.Status.RouteURL, routeMonitor.Spec.Slo).Return("99.5", nil).Times(1)
@@ -719,7 +780,7 @@ var _ = Describe("Routemonitor", func() {
 			})
 			When("the update the PrometheusRule failed", func() {
 				BeforeEach(func() {
-					mockPrometheusRule.EXPECT().UpdatePrometheusRuleDeployment(gomock.Any()).Return(consterror.ErrCustomError)
+					mockPrometheusRule.EXPECT().UpdatePrometheusRuleDeployment(gomock.Any()).Return(controllerutil.OperationResultNone, consterror.ErrCustomError)
 				})
 				It("requeues with the error", func() {
 					Expect(err).To(Equal(consterror.ErrCustomError))
@@ -732,7 +793,7 @@ var _ = Describe("Routemonitor", func() {
 				})
 				When("a new PrometheusRule was created", func() {
 					BeforeEach(func() {
-						mockUtils.EXPECT().SetResourceReference(gomock.Any(), gomock.Any()).Return(true, nil)
+						mockUtils.EXPECT().SetResourceReference(gomock.Any(), gomock.Any()).Return(true)
 					})
 					When("the ServiceMonitor is updated successfully", func() {
 						BeforeEach(func() {
@@ -776,11 +837,34 @@ var _ = Describe("Routemonitor", func() {
 				Expect(resp).To(Equal(utilreconcile.RequeueOperation()))
 			})
 		})
+		When("the probe is invalid", func() {
+			BeforeEach(func() {
+				routeMonitor.Spec.Probe.HTTP = &v1alpha1.HTTPProbeSpec{FailIfBodyMatchesRegexp: []string{"("}}
+				mockUtils.EXPECT().SetErrorStatus(gomock.Any(), customerrors.ErrInvalidProbe).Return(true)
+				mockUtils.EXPECT().UpdateMonitorResourceStatus(gomock.Any()).Return(utilreconcile.StopOperation(), nil)
+			})
+			It("sets the error status and does not update the ServiceMonitor", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp).To(Equal(utilreconcile.StopOperation()))
+			})
+		})
+		When("the Secret referenced by the probe does not exist", func() {
+			BeforeEach(func() {
+				routeMonitor.Spec.Probe.Auth = &v1alpha1.ProbeAuthSpec{Type: v1alpha1.ProbeAuthBearerToken, SecretName: "token"}
+				get = helper.NotFoundErrorHappensOnce()
+				mockUtils.EXPECT().SetErrorStatus(gomock.Any(), customerrors.ErrInvalidProbeSecret).Return(true)
+				mockUtils.EXPECT().UpdateMonitorResourceStatus(gomock.Any()).Return(utilreconcile.StopOperation(), nil)
+			})
+			It("sets the error status and does not update the ServiceMonitor", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp).To(Equal(utilreconcile.StopOperation()))
+			})
+		})
 		Describe("It updates the ServiceMonitor targeting the blackbox Exporter Namespace", func() {
 			When("the update of the ServiceMonitor fails", func() {
 				BeforeEach(func() {
-					mockServiceMonitor.EXPECT().TemplateAndUpdateServiceMonitorDeployment(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(consterror.ErrCustomError)
-					mockBlackboxExporter.EXPECT().GetBlackBoxExporterNamespace().Return("bla")
+					mockServiceMonitor.EXPECT().TemplateAndUpdateServiceMonitorDeployment(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(controllerutil.OperationResultNone, consterror.ErrCustomError)
+					mockBlackboxExporter.EXPECT().GetBlackBoxExporterForPool("").Return(types.NamespacedName{Name: "blackbox-exporter", Namespace: "bla"}, nil)
 					mockUtils.EXPECT().GetOSDClusterID().Return("test-cluster-id", nil)
 				})
 				It("will requeue with the error", func() {
@@ -790,22 +874,55 @@ var _ = Describe("Routemonitor", func() {
 			})
 			When("the update of the ServiceMonitor is successful", func() {
 				BeforeEach(func() {
-					mockServiceMonitor.EXPECT().TemplateAndUpdateServiceMonitorDeployment(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
-					mockBlackboxExporter.EXPECT().GetBlackBoxExporterNamespace().Return("bla")
+					mockServiceMonitor.EXPECT().TemplateAndUpdateServiceMonitorDeployment(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+					mockBlackboxExporter.EXPECT().GetBlackBoxExporterForPool("").Return(types.NamespacedName{Name: "blackbox-exporter", Namespace: "bla"}, nil)
 					mockUtils.EXPECT().GetOSDClusterID().Return("test-cluster-id", nil)
 				})
-				When("the update of the ServiceMonitorRef fails", func() {
+				When("the ServiceMonitorRef points to a previous ServiceMonitor", func() {
+					previousRef := v1alpha1.NamespacedName{Name: "previous", Namespace: "the-world"}
+					newRef := v1alpha1.NamespacedName{Name: "scott-pilgrim", Namespace: "the-world"}
 					BeforeEach(func() {
-						mockUtils.EXPECT().SetResourceReference(gomock.Any(), gomock.Any()).Return(false, consterror.ErrCustomError)
+						routeMonitor.Status.ServiceMonitorRef = previousRef
 					})
-					It("will requeue with the error", func() {
-						Expect(err).To(Equal(consterror.ErrCustomError))
-						Expect(resp).To(Equal(utilreconcile.RequeueOperation()))
+					When("the new ServiceMonitor does not exist yet", func() {
+						BeforeEach(func() {
+							mockServiceMonitor.EXPECT().ServiceMonitorExists(newRef, false).Return(false, nil)
+						})
+						It("keeps the previous ServiceMonitor and requeues", func() {
+							Expect(err).To(Equal(customerrors.ErrReferenceMigrationPending))
+							Expect(resp).To(Equal(utilreconcile.RequeueOperation()))
+						})
+					})
+					When("the new ServiceMonitor exists", func() {
+						BeforeEach(func() {
+							gomock.InOrder(
+								mockServiceMonitor.EXPECT().ServiceMonitorExists(newRef, false).Return(true, nil),
+								mockServiceMonitor.EXPECT().DeleteServiceMonitorDeployment(previousRef, false).Return(true, nil),
+							)
+							mockUtils.EXPECT().SetResourceReference(gomock.Any(), gomock.Any()).Return(true)
+							mockUtils.EXPECT().UpdateMonitorResourceStatus(gomock.Any()).Return(utilreconcile.StopOperation(), nil)
+						})
+						It("deletes the previous ServiceMonitor and records the migration", func() {
+							Expect(err).NotTo(HaveOccurred())
+							Expect(resp).To(Equal(utilreconcile.StopOperation()))
+							Expect(recorder.Events).To(Receive(ContainSubstring(controllers.ReasonServiceMonitorMigrated)))
+						})
+					})
+					When("the previous ServiceMonitor cannot be deleted", func() {
+						BeforeEach(func() {
+							mockServiceMonitor.EXPECT().ServiceMonitorExists(newRef, false).Return(true, nil)
+							mockServiceMonitor.EXPECT().DeleteServiceMonitorDeployment(previousRef, false).Return(false, consterror.ErrCustomError)
+						})
+						It("will requeue with the error", func() {
+							Expect(err).To(Equal(consterror.ErrCustomError))
+							Expect(resp).To(Equal(utilreconcile.RequeueOperation()))
+							Expect(recorder.Events).To(BeEmpty())
+						})
 					})
 				})
 				When("the update of the ServiceMonitorRef is successful", func() {
 					BeforeEach(func() {
-						mockUtils.EXPECT().SetResourceReference(gomock.Any(), gomock.Any()).Return(true, nil)
+						mockUtils.EXPECT().SetResourceReference(gomock.Any(), gomock.Any()).Return(true)
 					})
 					When("it updates the RouteMonitor", func() {
 						BeforeEach(func() {
@@ -818,6 +935,278 @@ var _ = Describe("Routemonitor", func() {
 					})
 				})
 			})
+			When("the ServiceMonitor is created", func() {
+				BeforeEach(func() {
+					mockServiceMonitor.EXPECT().TemplateAndUpdateServiceMonitorDeployment(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(controllerutil.OperationResultCreated, nil)
+					mockBlackboxExporter.EXPECT().GetBlackBoxExporterForPool("").Return(types.NamespacedName{Name: "blackbox-exporter", Namespace: "bla"}, nil)
+					mockUtils.EXPECT().GetOSDClusterID().Return("test-cluster-id", nil)
+					mockUtils.EXPECT().SetResourceReference(gomock.Any(), gomock.Any()).Return(true)
+					mockUtils.EXPECT().UpdateMonitorResourceStatus(gomock.Any()).Return(utilreconcile.StopOperation(), nil)
+				})
+				It("records the creation on the RouteMonitor", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(recorder.Events).To(Receive(Equal("Normal ServiceMonitorCreated Created the ServiceMonitor the-world/scott-pilgrim")))
+				})
+			})
+		})
+	})
+
+	//--------------------------------------------------------------------------------------
+	// 		EnsureProbeStatusUpdated
+	//--------------------------------------------------------------------------------------
+	Describe("EnsureProbeStatusUpdated", func() {
+		var (
+			mockProbeStatus *controllermocks.MockProbeStatusHandler
+			updated         *v1alpha1.RouteMonitor
+
+			res utilreconcile.Result
+			err error
+		)
+		BeforeEach(func() {
+			mockProbeStatus = controllermocks.NewMockProbeStatusHandler(mockCtrl)
+			routeMonitorReconciler.ProbeStatus = mockProbeStatus
+			updated = nil
+		})
+		JustBeforeEach(func() {
+			res, err = routeMonitorReconciler.EnsureProbeStatusUpdated(routeMonitor)
+		})
+		When("no Prometheus endpoint is configured", func() {
+			BeforeEach(func() {
+				routeMonitorReconciler.ProbeStatus = nil
+			})
+			It("continues without querying", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res).To(Equal(utilreconcile.ContinueOperation()))
+			})
+		})
+		When("the probe status was queried within the interval", func() {
+			BeforeEach(func() {
+				routeMonitor.Status.ProbeStatus = &v1alpha1.ProbeStatus{LastQueryTime: metav1.NewTime(time.Now().Add(-time.Minute))}
+				mockProbeStatus.EXPECT().RefreshInterval().Return(5 * time.Minute)
+			})
+			It("requeues once the refresh is due", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res.ShouldStop()).To(BeFalse())
+				Expect(res.RequeueAfter).To(BeNumerically("~", 4*time.Minute, time.Second))
+			})
+		})
+		When("the probe status is due", func() {
+			BeforeEach(func() {
+				mockProbeStatus.EXPECT().RefreshInterval().Return(5 * time.Minute)
+				mockUtils.EXPECT().UpdateMonitorResourceStatus(gomock.Any()).DoAndReturn(func(cr *v1alpha1.RouteMonitor) (utilreconcile.Result, error) {
+					updated = cr
+					return utilreconcile.StopOperation(), nil
+				})
+			})
+			When("the last probe failed", func() {
+				BeforeEach(func() {
+					lastProbeSuccess := false
+					mockProbeStatus.EXPECT().GetProbeStatus("fake-route-url", false, routeMonitor.Spec.Slo).Return(v1alpha1.ProbeStatus{
+						LastProbeSuccess:    &lastProbeSuccess,
+						AvailabilityPercent: "99.9",
+						LastQueryTime:       metav1.Now(),
+					}, nil)
+				})
+				It("records the probe status and marks the target unhealthy", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(res).To(Equal(utilreconcile.StopOperation()))
+					Expect(updated.Status.ProbeStatus.AvailabilityPercent).To(Equal("99.9"))
+					condition := meta.FindStatusCondition(updated.Status.Conditions, v1alpha1.ConditionTargetHealthy)
+					Expect(condition).NotTo(BeNil())
+					Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+					Expect(condition.Reason).To(Equal(v1alpha1.ReasonProbeFailed))
+				})
+			})
+			When("the RouteMonitor uses RHOBS ServiceMonitors and the query fails", func() {
+				BeforeEach(func() {
+					routeMonitor.Spec.ServiceMonitorType = v1alpha1.ServiceMonitorTypeRHOBS
+					routeMonitor.Status.ProbeStatus = &v1alpha1.ProbeStatus{AvailabilityPercent: "99.9"}
+					mockProbeStatus.EXPECT().GetProbeStatus("fake-route-url", true, routeMonitor.Spec.Slo).Return(v1alpha1.ProbeStatus{}, consterror.ErrCustomError)
+				})
+				It("keeps the previous results and marks the target health unknown", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(updated.Status.ProbeStatus.AvailabilityPercent).To(Equal("99.9"))
+					Expect(updated.Status.ProbeStatus.LastQueryTime.IsZero()).To(BeFalse())
+					condition := meta.FindStatusCondition(updated.Status.Conditions, v1alpha1.ConditionTargetHealthy)
+					Expect(condition).NotTo(BeNil())
+					Expect(condition.Status).To(Equal(metav1.ConditionUnknown))
+					Expect(condition.Reason).To(Equal(v1alpha1.ReasonQueryFailed))
+				})
+			})
+		})
+	})
+
+	//--------------------------------------------------------------------------------------
+	// 		EnsureMaintenanceStatusUpdated
+	//--------------------------------------------------------------------------------------
+	Describe("EnsureMaintenanceStatusUpdated", func() {
+		var (
+			updated *v1alpha1.RouteMonitor
+
+			res utilreconcile.Result
+			err error
+		)
+		BeforeEach(func() {
+			updated = nil
+			routeMonitor.Spec.MaintenanceWindows = []v1alpha1.MaintenanceWindow{{
+				Name:  "upgrade",
+				Start: &metav1.Time{Time: time.Now().Add(-time.Hour).Truncate(time.Second)},
+				End:   &metav1.Time{Time: time.Now().Add(time.Hour).Truncate(time.Second)},
+			}}
+		})
+		JustBeforeEach(func() {
+			res, err = routeMonitorReconciler.EnsureMaintenanceStatusUpdated(routeMonitor)
+		})
+		When("a maintenance window starts", func() {
+			BeforeEach(func() {
+				mockUtils.EXPECT().UpdateMonitorResourceStatus(gomock.Any()).DoAndReturn(func(cr *v1alpha1.RouteMonitor) (utilreconcile.Result, error) {
+					updated = cr
+					return utilreconcile.StopOperation(), nil
+				})
+			})
+			It("reports the active window", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res).To(Equal(utilreconcile.StopOperation()))
+				Expect(updated.Status.ActiveMaintenanceWindow.Name).To(Equal("upgrade"))
+			})
+		})
+		When("the active window is reported", func() {
+			BeforeEach(func() {
+				window := routeMonitor.Spec.MaintenanceWindows[0]
+				routeMonitor.Status.ActiveMaintenanceWindow = &v1alpha1.ActiveMaintenanceWindow{Name: window.Name, Start: *window.Start, End: *window.End}
+			})
+			It("requeues once the window ends", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res.ShouldStop()).To(BeFalse())
+				Expect(res.RequeueAfter).To(BeNumerically("~", time.Hour, time.Second))
+			})
+		})
+		When("the maintenance window ended", func() {
+			BeforeEach(func() {
+				routeMonitor.Status.ActiveMaintenanceWindow = &v1alpha1.ActiveMaintenanceWindow{Name: "upgrade"}
+				routeMonitor.Spec.MaintenanceWindows[0].End = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+				mockUtils.EXPECT().UpdateMonitorResourceStatus(gomock.Any()).DoAndReturn(func(cr *v1alpha1.RouteMonitor) (utilreconcile.Result, error) {
+					updated = cr
+					return utilreconcile.StopOperation(), nil
+				})
+			})
+			It("clears the active window", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(updated.Status.ActiveMaintenanceWindow).To(BeNil())
+			})
+		})
+	})
+
+	//--------------------------------------------------------------------------------------
+	// 		Route watch
+	//--------------------------------------------------------------------------------------
+	Describe("Route watch", func() {
+		routeMonitorFor := func(name, routeName, routeNamespace string) *v1alpha1.RouteMonitor {
+			return &v1alpha1.RouteMonitor{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "monitoring"},
+				Spec:       v1alpha1.RouteMonitorSpec{Route: v1alpha1.RouteMonitorRouteSpec{Name: routeName, Namespace: routeNamespace}},
+			}
+		}
+
+		It("indexes RouteMonitors by the Route they monitor", func() {
+			for _, tt := range []struct {
+				name         string
+				routeMonitor *v1alpha1.RouteMonitor
+				want         []string
+			}{
+				{name: "without a route", routeMonitor: routeMonitorFor("none", "", "")},
+				{name: "without a route namespace", routeMonitor: routeMonitorFor("partial", "console", "")},
+				{name: "with a route", routeMonitor: routeMonitorFor("console", "console", "openshift-console"), want: []string{"openshift-console/console"}},
+			} {
+				Expect(routemonitor.IndexByRoute(tt.routeMonitor)).To(Equal(tt.want), tt.name)
+			}
+		})
+
+		It("maps a Route to the RouteMonitors monitoring it", func() {
+			routeMonitorReconciler.Client = fake.NewClientBuilder().
+				WithScheme(constinit.Scheme).
+				WithObjects(
+					routeMonitorFor("console", "console", "openshift-console"),
+					routeMonitorFor("downloads", "downloads", "openshift-console"),
+					routeMonitorFor("other-namespace", "console", "other"),
+					routeMonitorFor("none", "", ""),
+				).
+				WithIndex(&v1alpha1.RouteMonitor{}, routemonitor.RouteIndexKey, routemonitor.IndexByRoute).
+				Build()
+			for _, tt := range []struct {
+				name  string
+				route *routev1.Route
+				want  []reconcile.Request
+			}{
+				{
+					name:  "monitored route",
+					route: &routev1.Route{ObjectMeta: metav1.ObjectMeta{Name: "console", Namespace: "openshift-console"}},
+					want:  []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "console", Namespace: "monitoring"}}},
+				},
+				{
+					name:  "unmonitored route",
+					route: &routev1.Route{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "openshift-console"}},
+					want:  []reconcile.Request{},
+				},
+			} {
+				Expect(routeMonitorReconciler.RouteMonitorsForRoute(context.TODO(), tt.route)).To(Equal(tt.want), tt.name)
+			}
+		})
+
+		It("only passes Route updates changing the URL", func() {
+			admitted := func(status corev1.ConditionStatus) []routev1.RouteIngressCondition {
+				return []routev1.RouteIngressCondition{{Type: routev1.RouteAdmitted, Status: status}}
+			}
+			route := routev1.Route{
+				ObjectMeta: metav1.ObjectMeta{Name: "console", Namespace: "openshift-console"},
+				Spec:       routev1.RouteSpec{Host: "console.apps.example.com", TLS: &routev1.TLSConfig{Termination: routev1.TLSTerminationEdge}},
+				Status: routev1.RouteStatus{Ingress: []routev1.RouteIngress{
+					{Host: "console.apps.example.com", RouterName: "default", Conditions: admitted(corev1.ConditionTrue)},
+				}},
+			}
+			for _, tt := range []struct {
+				name   string
+				update func(route *routev1.Route)
+				want   bool
+			}{
+				{name: "labels", update: func(route *routev1.Route) { route.Labels = map[string]string{"team": "console"} }},
+				{name: "TLS termination", update: func(route *routev1.Route) { route.Spec.TLS.Termination = routev1.TLSTerminationReencrypt }},
+				{name: "TLS removed", update: func(route *routev1.Route) { route.Spec.TLS = nil }, want: true},
+				{name: "host", update: func(route *routev1.Route) { route.Status.Ingress[0].Host = "console.apps2.example.com" }, want: true},
+				{name: "router", update: func(route *routev1.Route) { route.Status.Ingress[0].RouterName = "sharded" }, want: true},
+				{name: "admission", update: func(route *routev1.Route) { route.Status.Ingress[0].Conditions = admitted(corev1.ConditionFalse) }, want: true},
+				{name: "ingress added", update: func(route *routev1.Route) {
+					route.Status.Ingress = append(route.Status.Ingress, routev1.RouteIngress{Host: "console.apps.example.com", RouterName: "sharded"})
+				}, want: true},
+			} {
+				updated := route.DeepCopy()
+				tt.update(updated)
+				Expect(routemonitor.RouteURLChanged(event.UpdateEvent{ObjectOld: route.DeepCopy(), ObjectNew: updated})).To(Equal(tt.want), tt.name)
+			}
+		})
+	})
+
+	Describe("recordMonitorState", func() {
+		It("records the conditions stored by the reconcile steps and forgets deleted RouteMonitors", func() {
+			stored := &v1alpha1.RouteMonitor{
+				ObjectMeta: metav1.ObjectMeta{Name: "state", Namespace: "monitoring"},
+				Status: v1alpha1.RouteMonitorStatus{Conditions: []metav1.Condition{
+					{Type: v1alpha1.ConditionReady, Status: metav1.ConditionTrue, Reason: "Reconciled", LastTransitionTime: metav1.Now()},
+				}},
+			}
+			routeMonitorReconciler.Client = fake.NewClientBuilder().WithScheme(constinit.Scheme).WithObjects(stored).Build()
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "state", Namespace: "monitoring"}}
+
+			routeMonitorReconciler.RecordMonitorState(req)
+			Expect(testutil.GatherAndCompare(crmetrics.Registry, strings.NewReader(`
+# HELP route_monitor_operator_monitors Number of monitors by kind, type, state and the reason of the error
+# TYPE route_monitor_operator_monitors gauge
+route_monitor_operator_monitors{error="",kind="RouteMonitor",state="ready",type="monitoring.coreos.com"} 1
+`), "route_monitor_operator_monitors")).To(Succeed())
+
+			Expect(routeMonitorReconciler.Client.Delete(context.TODO(), stored)).To(Succeed())
+			routeMonitorReconciler.RecordMonitorState(req)
+			Expect(testutil.GatherAndCompare(crmetrics.Registry, strings.NewReader(""), "route_monitor_operator_monitors")).To(Succeed())
 		})
 	})
 })
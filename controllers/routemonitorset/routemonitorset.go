@@ -0,0 +1,304 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routemonitorset
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	utilreconcile "github.com/openshift/route-monitor-operator/pkg/util/reconcile"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// OwnerIndexKey indexes RouteMonitors by the name of the RouteMonitorSet controlling them
+const OwnerIndexKey = "metadata.ownerReferences.routemonitorset"
+
+// RouteMonitorSetReconciler creates a RouteMonitor for every Route matching a RouteMonitorSet.
+// The RouteMonitors are reconciled by the RouteMonitorReconciler and garbage collected with the RouteMonitorSet
+type RouteMonitorSetReconciler struct {
+	Client client.Client
+	Ctx    context.Context
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+}
+
+func NewReconciler(mgr manager.Manager) *RouteMonitorSetReconciler {
+	return &RouteMonitorSetReconciler{
+		Client: mgr.GetClient(),
+		Ctx:    context.Background(),
+		Log:    ctrl.Log.WithName("controllers").WithName("RouteMonitorSet"),
+		Scheme: mgr.GetScheme(),
+	}
+}
+
+// +kubebuilder:rbac:groups=monitoring.openshift.io,resources=routemonitorsets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=monitoring.openshift.io,resources=routemonitorsets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=monitoring.openshift.io,resources=routemonitorsets/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
+func (r *RouteMonitorSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	r.Ctx = ctx
+	log := r.Log.WithName("Reconcile").WithValues("name", req.Name)
+
+	routeMonitorSet := v1alpha1.RouteMonitorSet{}
+	if err := r.Client.Get(ctx, req.NamespacedName, &routeMonitorSet); err != nil {
+		if k8serrors.IsNotFound(err) {
+			// the RouteMonitors are garbage collected through their owner references
+			log.V(2).Info("RouteMonitorSet not found, stopping")
+			return utilreconcile.Stop()
+		}
+		return utilreconcile.RequeueWith(err)
+	}
+	if routeMonitorSet.DeletionTimestamp != nil {
+		return utilreconcile.Stop()
+	}
+
+	log.V(2).Info("Entering GetMatchingRoutes")
+	routes, err := r.GetMatchingRoutes(routeMonitorSet)
+	if err != nil {
+		log.Error(err, "Failed to list the matching Routes. Requeueing...")
+		return utilreconcile.RequeueWith(err)
+	}
+
+	log.V(2).Info("Entering EnsureRouteMonitors")
+	routeMonitors, err := r.EnsureRouteMonitors(routeMonitorSet, routes)
+	if err != nil {
+		log.Error(err, "Failed to reconcile the RouteMonitors. Requeueing...")
+		return utilreconcile.RequeueWith(err)
+	}
+
+	if !reflect.DeepEqual(routeMonitorSet.Status.RouteMonitors, routeMonitors) || routeMonitorSet.Status.ObservedGeneration != routeMonitorSet.Generation {
+		routeMonitorSet.Status.RouteMonitors = routeMonitors
+		routeMonitorSet.Status.ObservedGeneration = routeMonitorSet.Generation
+		if err := r.Client.Status().Update(ctx, &routeMonitorSet); err != nil {
+			log.Error(err, "Failed to update the RouteMonitorSet status. Requeueing...")
+			return utilreconcile.RequeueWith(err)
+		}
+	}
+
+	log.Info("All operations for RouteMonitorSet completed. Finished Reconcile.")
+	return utilreconcile.Stop()
+}
+
+// GetMatchingRoutes returns the Routes matching the route selector in the namespaces matching the namespace selector
+func (r *RouteMonitorSetReconciler) GetMatchingRoutes(routeMonitorSet v1alpha1.RouteMonitorSet) ([]routev1.Route, error) {
+	namespaceSelector, err := metav1.LabelSelectorAsSelector(&routeMonitorSet.Spec.NamespaceSelector)
+	if err != nil {
+		return nil, err
+	}
+	routeSelector, err := metav1.LabelSelectorAsSelector(&routeMonitorSet.Spec.RouteSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := corev1.NamespaceList{}
+	if err := r.Client.List(r.Ctx, &namespaces, client.MatchingLabelsSelector{Selector: namespaceSelector}); err != nil {
+		return nil, err
+	}
+	matchingNamespaces := map[string]bool{}
+	for _, namespace := range namespaces.Items {
+		matchingNamespaces[namespace.Name] = true
+	}
+
+	routes := routev1.RouteList{}
+	if err := r.Client.List(r.Ctx, &routes, client.MatchingLabelsSelector{Selector: routeSelector}); err != nil {
+		return nil, err
+	}
+	matchingRoutes := []routev1.Route{}
+	for _, route := range routes.Items {
+		if matchingNamespaces[route.Namespace] && route.DeletionTimestamp == nil {
+			matchingRoutes = append(matchingRoutes, route)
+		}
+	}
+	return matchingRoutes, nil
+}
+
+// EnsureRouteMonitors creates or updates a RouteMonitor for every Route and deletes the RouteMonitors of Routes which
+// stopped matching. It returns the RouteMonitors of the RouteMonitorSet
+func (r *RouteMonitorSetReconciler) EnsureRouteMonitors(routeMonitorSet v1alpha1.RouteMonitorSet, routes []routev1.Route) ([]v1alpha1.NamespacedName, error) {
+	desired := map[types.NamespacedName]v1alpha1.RouteMonitor{}
+	for _, route := range routes {
+		routeMonitor, err := r.templateForRouteMonitor(routeMonitorSet, route)
+		if err != nil {
+			r.Log.Error(err, "Failed to template the RouteMonitor of the Route, skipping", "route", route.Name, "namespace", route.Namespace)
+			continue
+		}
+		desired[client.ObjectKeyFromObject(&routeMonitor)] = routeMonitor
+	}
+
+	existing := v1alpha1.RouteMonitorList{}
+	if err := r.Client.List(r.Ctx, &existing, client.MatchingFields{OwnerIndexKey: routeMonitorSet.Name}); err != nil {
+		return nil, err
+	}
+	routeMonitors := []v1alpha1.NamespacedName{}
+	for i := range existing.Items {
+		routeMonitor := &existing.Items[i]
+		template, ok := desired[client.ObjectKeyFromObject(routeMonitor)]
+		if !ok {
+			r.Log.V(2).Info("Deleting RouteMonitor of a Route which stopped matching", "name", routeMonitor.Name, "namespace", routeMonitor.Namespace)
+			if err := r.Client.Delete(r.Ctx, routeMonitor); err != nil && !k8serrors.IsNotFound(err) {
+				return nil, err
+			}
+			continue
+		}
+		delete(desired, client.ObjectKeyFromObject(routeMonitor))
+		if routeMonitor.Spec.Route != template.Spec.Route || !reflect.DeepEqual(routeMonitor.Spec.Slo, template.Spec.Slo) {
+			routeMonitor.Spec.Route = template.Spec.Route
+			routeMonitor.Spec.Slo = template.Spec.Slo
+			if err := r.Client.Update(r.Ctx, routeMonitor); err != nil {
+				return nil, err
+			}
+		}
+		routeMonitors = append(routeMonitors, v1alpha1.NamespacedName{Name: routeMonitor.Name, Namespace: routeMonitor.Namespace})
+	}
+
+	for _, template := range desired {
+		r.Log.V(2).Info("Creating RouteMonitor for matching Route", "name", template.Name, "namespace", template.Namespace)
+		if err := r.Client.Create(r.Ctx, &template); err != nil {
+			if !k8serrors.IsAlreadyExists(err) {
+				// the other Routes are monitored regardless, the Route is retried with the next reconcile of the set
+				r.Log.Error(err, "Failed to create the RouteMonitor of the Route, skipping", "name", template.Name, "namespace", template.Namespace)
+				continue
+			}
+			// a RouteMonitor not controlled by the RouteMonitorSet uses the name, it is left alone
+			r.Log.Info("RouteMonitor already exists and is not controlled by the RouteMonitorSet, skipping", "name", template.Name, "namespace", template.Namespace)
+			continue
+		}
+		routeMonitors = append(routeMonitors, v1alpha1.NamespacedName{Name: template.Name, Namespace: template.Namespace})
+	}
+
+	sort.Slice(routeMonitors, func(i, j int) bool {
+		if routeMonitors[i].Namespace != routeMonitors[j].Namespace {
+			return routeMonitors[i].Namespace < routeMonitors[j].Namespace
+		}
+		return routeMonitors[i].Name < routeMonitors[j].Name
+	})
+	return routeMonitors, nil
+}
+
+// templateForRouteMonitor returns the RouteMonitor of the Route, controlled by the RouteMonitorSet
+func (r *RouteMonitorSetReconciler) templateForRouteMonitor(routeMonitorSet v1alpha1.RouteMonitorSet, route routev1.Route) (v1alpha1.RouteMonitor, error) {
+	routeMonitor := v1alpha1.RouteMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      routeMonitorName(routeMonitorSet, route),
+			Namespace: route.Namespace,
+		},
+		Spec: v1alpha1.RouteMonitorSpec{
+			Route: v1alpha1.RouteMonitorRouteSpec{
+				Name:      route.Name,
+				Namespace: route.Namespace,
+			},
+			Slo: routeMonitorSet.Spec.Slo,
+		},
+	}
+	err := controllerutil.SetControllerReference(&routeMonitorSet, &routeMonitor, r.Scheme)
+	return routeMonitor, err
+}
+
+// routeMonitorName returns the name of the RouteMonitor of the Route. Names longer than allowed are truncated
+// and suffixed with a hash of the full name, so the RouteMonitors of different Routes keep different names
+func routeMonitorName(routeMonitorSet v1alpha1.RouteMonitorSet, route routev1.Route) string {
+	name := routeMonitorSet.Name + "-" + route.Name
+	if len(name) <= validation.DNS1123SubdomainMaxLength {
+		return name
+	}
+	hash := sha256.Sum256([]byte(name))
+	suffix := "-" + hex.EncodeToString(hash[:])[:10]
+	return strings.TrimRight(name[:validation.DNS1123SubdomainMaxLength-len(suffix)], "-.") + suffix
+}
+
+// IndexByOwner returns the name of the RouteMonitorSet controlling the RouteMonitor
+func IndexByOwner(obj client.Object) []string {
+	owner := metav1.GetControllerOf(obj)
+	if owner == nil || owner.APIVersion != v1alpha1.GroupVersion.String() || owner.Kind != "RouteMonitorSet" {
+		return nil
+	}
+	return []string{owner.Name}
+}
+
+func (r *RouteMonitorSetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &v1alpha1.RouteMonitor{}, OwnerIndexKey, IndexByOwner); err != nil {
+		return err
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.RouteMonitorSet{}).
+		Owns(&v1alpha1.RouteMonitor{}).
+		Watches(
+			&routev1.Route{},
+			handler.EnqueueRequestsFromMapFunc(r.routeMonitorSetsForRoute),
+		).
+		Watches(
+			&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.allRouteMonitorSets),
+			builder.WithPredicates(predicate.LabelChangedPredicate{}),
+		).
+		Complete(r)
+}
+
+// routeMonitorSetsForRoute returns the RouteMonitorSets whose route selector matches the Route.
+// Updates are mapped for the old and the new Route, so Routes which stopped matching are covered
+func (r *RouteMonitorSetReconciler) routeMonitorSetsForRoute(ctx context.Context, route client.Object) []reconcile.Request {
+	routeMonitorSets := v1alpha1.RouteMonitorSetList{}
+	if err := r.Client.List(ctx, &routeMonitorSets); err != nil {
+		r.Log.Error(err, "Failed to list RouteMonitorSets for Route", "name", route.GetName(), "namespace", route.GetNamespace())
+		return nil
+	}
+	requests := []reconcile.Request{}
+	for _, routeMonitorSet := range routeMonitorSets.Items {
+		selector, err := metav1.LabelSelectorAsSelector(&routeMonitorSet.Spec.RouteSelector)
+		if err != nil || !selector.Matches(labels.Set(route.GetLabels())) {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: routeMonitorSet.Name}})
+	}
+	return requests
+}
+
+// allRouteMonitorSets returns every RouteMonitorSet, as the labels of a namespace changed
+func (r *RouteMonitorSetReconciler) allRouteMonitorSets(ctx context.Context, namespace client.Object) []reconcile.Request {
+	routeMonitorSets := v1alpha1.RouteMonitorSetList{}
+	if err := r.Client.List(ctx, &routeMonitorSets); err != nil {
+		r.Log.Error(err, "Failed to list RouteMonitorSets for Namespace", "name", namespace.GetName())
+		return nil
+	}
+	requests := []reconcile.Request{}
+	for _, routeMonitorSet := range routeMonitorSets.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: routeMonitorSet.Name}})
+	}
+	return requests
+}
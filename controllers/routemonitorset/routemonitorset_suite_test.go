@@ -0,0 +1,13 @@
+package routemonitorset_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestRouteMonitorSet(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "RouteMonitorSet Suite")
+}
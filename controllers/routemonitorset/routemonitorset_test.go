@@ -0,0 +1,189 @@
+package routemonitorset_test
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	"github.com/openshift/route-monitor-operator/controllers/routemonitorset"
+	constinit "github.com/openshift/route-monitor-operator/pkg/consts/test/init"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var _ = Describe("RouteMonitorSet", func() {
+	var (
+		objects    []client.Object
+		fakeClient client.Client
+		reconciler routemonitorset.RouteMonitorSetReconciler
+
+		routeMonitorSet v1alpha1.RouteMonitorSet
+		err             error
+		// createErr is returned when creating the RouteMonitor with the name
+		createErr map[string]error
+	)
+
+	namespace := func(name string, labels map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	route := func(name, namespace string, labels map[string]string) *routev1.Route {
+		return &routev1.Route{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels}}
+	}
+	getRouteMonitor := func(name, namespace string) (v1alpha1.RouteMonitor, error) {
+		routeMonitor := v1alpha1.RouteMonitor{}
+		err := fakeClient.Get(context.Background(), types.NamespacedName{Name: name, Namespace: namespace}, &routeMonitor)
+		return routeMonitor, err
+	}
+
+	BeforeEach(func() {
+		createErr = map[string]error{}
+		routeMonitorSet = v1alpha1.RouteMonitorSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "apps", UID: "set-uid"},
+			Spec: v1alpha1.RouteMonitorSetSpec{
+				NamespaceSelector: metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+				RouteSelector:     metav1.LabelSelector{MatchLabels: map[string]string{"monitored": "true"}},
+				Slo:               v1alpha1.SloSpec{TargetAvailabilityPercent: "99.9"},
+			},
+		}
+		objects = []client.Object{
+			namespace("team-a", map[string]string{"team": "a"}),
+			namespace("team-b", map[string]string{"team": "b"}),
+			route("frontend", "team-a", map[string]string{"monitored": "true"}),
+			route("internal", "team-a", nil),
+			route("frontend", "team-b", map[string]string{"monitored": "true"}),
+		}
+	})
+	JustBeforeEach(func() {
+		fakeClient = fake.NewClientBuilder().
+			WithScheme(constinit.Scheme).
+			WithObjects(append(objects, &routeMonitorSet)...).
+			WithStatusSubresource(&v1alpha1.RouteMonitorSet{}).
+			WithIndex(&v1alpha1.RouteMonitor{}, routemonitorset.OwnerIndexKey, routemonitorset.IndexByOwner).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					if err, ok := createErr[obj.GetName()]; ok {
+						return err
+					}
+					return c.Create(ctx, obj, opts...)
+				},
+			}).
+			Build()
+		reconciler = routemonitorset.RouteMonitorSetReconciler{
+			Client: fakeClient,
+			Log:    logr.Discard(),
+			Scheme: constinit.Scheme,
+		}
+		_, err = reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: routeMonitorSet.Name}})
+	})
+
+	It("creates a RouteMonitor for the matching Routes only", func() {
+		Expect(err).NotTo(HaveOccurred())
+		routeMonitor, err := getRouteMonitor("apps-frontend", "team-a")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(routeMonitor.Spec.Route).To(Equal(v1alpha1.RouteMonitorRouteSpec{Name: "frontend", Namespace: "team-a"}))
+		Expect(routeMonitor.Spec.Slo.TargetAvailabilityPercent).To(Equal("99.9"))
+		Expect(metav1.GetControllerOf(&routeMonitor).Name).To(Equal("apps"))
+
+		_, err = getRouteMonitor("apps-internal", "team-a")
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		_, err = getRouteMonitor("apps-frontend", "team-b")
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("records the RouteMonitors in the status", func() {
+		updated := v1alpha1.RouteMonitorSet{}
+		Expect(fakeClient.Get(context.Background(), types.NamespacedName{Name: "apps"}, &updated)).To(Succeed())
+		Expect(updated.Status.RouteMonitors).To(Equal([]v1alpha1.NamespacedName{{Name: "apps-frontend", Namespace: "team-a"}}))
+	})
+
+	When("a Route stopped matching", func() {
+		BeforeEach(func() {
+			stale := &v1alpha1.RouteMonitor{ObjectMeta: metav1.ObjectMeta{Name: "apps-removed", Namespace: "team-a"}}
+			Expect(ctrl.SetControllerReference(&routeMonitorSet, stale, constinit.Scheme)).To(Succeed())
+			objects = append(objects, stale)
+		})
+		It("deletes its RouteMonitor", func() {
+			Expect(err).NotTo(HaveOccurred())
+			_, err := getRouteMonitor("apps-removed", "team-a")
+			Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	When("the SLO of the RouteMonitorSet changed", func() {
+		BeforeEach(func() {
+			existing := &v1alpha1.RouteMonitor{
+				ObjectMeta: metav1.ObjectMeta{Name: "apps-frontend", Namespace: "team-a"},
+				Spec: v1alpha1.RouteMonitorSpec{
+					Route: v1alpha1.RouteMonitorRouteSpec{Name: "frontend", Namespace: "team-a"},
+					Slo:   v1alpha1.SloSpec{TargetAvailabilityPercent: "99"},
+				},
+			}
+			Expect(ctrl.SetControllerReference(&routeMonitorSet, existing, constinit.Scheme)).To(Succeed())
+			objects = append(objects, existing)
+		})
+		It("updates the RouteMonitor", func() {
+			Expect(err).NotTo(HaveOccurred())
+			routeMonitor, err := getRouteMonitor("apps-frontend", "team-a")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(routeMonitor.Spec.Slo.TargetAvailabilityPercent).To(Equal("99.9"))
+		})
+	})
+
+	When("a RouteMonitor not controlled by the RouteMonitorSet uses the name", func() {
+		BeforeEach(func() {
+			objects = append(objects, &v1alpha1.RouteMonitor{ObjectMeta: metav1.ObjectMeta{Name: "apps-frontend", Namespace: "team-a"}})
+		})
+		It("leaves it alone", func() {
+			Expect(err).NotTo(HaveOccurred())
+			routeMonitor, err := getRouteMonitor("apps-frontend", "team-a")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(metav1.GetControllerOf(&routeMonitor)).To(BeNil())
+		})
+	})
+
+	When("the name of the RouteMonitor would exceed the limit of object names", func() {
+		longName := strings.Repeat("a", 250)
+		BeforeEach(func() {
+			objects = append(objects,
+				route(longName, "team-a", map[string]string{"monitored": "true"}),
+				route(longName+"b", "team-a", map[string]string{"monitored": "true"}),
+			)
+		})
+		It("truncates the names and keeps them apart with a hash", func() {
+			Expect(err).NotTo(HaveOccurred())
+			updated := v1alpha1.RouteMonitorSet{}
+			Expect(fakeClient.Get(context.Background(), types.NamespacedName{Name: "apps"}, &updated)).To(Succeed())
+			Expect(updated.Status.RouteMonitors).To(HaveLen(3))
+			for _, routeMonitor := range updated.Status.RouteMonitors {
+				Expect(validation.IsDNS1123Subdomain(routeMonitor.Name)).To(BeEmpty())
+			}
+		})
+	})
+
+	When("the RouteMonitor of a Route cannot be created", func() {
+		BeforeEach(func() {
+			objects = append(objects, route("backend", "team-a", map[string]string{"monitored": "true"}))
+			createErr["apps-backend"] = k8serrors.NewInvalid(v1alpha1.GroupVersion.WithKind("RouteMonitor").GroupKind(), "apps-backend", nil)
+		})
+		It("skips the Route and monitors the other ones", func() {
+			Expect(err).NotTo(HaveOccurred())
+			_, err := getRouteMonitor("apps-frontend", "team-a")
+			Expect(err).NotTo(HaveOccurred())
+			updated := v1alpha1.RouteMonitorSet{}
+			Expect(fakeClient.Get(context.Background(), types.NamespacedName{Name: "apps"}, &updated)).To(Succeed())
+			Expect(updated.Status.RouteMonitors).To(Equal([]v1alpha1.NamespacedName{{Name: "apps-frontend", Namespace: "team-a"}}))
+		})
+	})
+})
@@ -0,0 +1,17 @@
+#!/bin/bash
+
+# controller-gen does not generate the conversion webhook of CRDs served in several versions.
+# Adds it to the generated CRDs, the CA bundle is injected by the OpenShift service CA.
+
+set -e
+
+REPO_ROOT=$(git rev-parse --show-toplevel)
+
+for crd in routemonitors clusterurlmonitors; do
+    file="$REPO_ROOT/deploy/crds/monitoring.openshift.io_$crd.yaml"
+    grep -q "^  conversion:" "$file" && continue
+    sed -i \
+        -e '/^    controller-gen.kubebuilder.io\/version:/a\    service.beta.openshift.io/inject-cabundle: "true"' \
+        -e '/^spec:$/a\  conversion:\n    strategy: Webhook\n    webhook:\n      clientConfig:\n        service:\n          name: route-monitor-operator-webhook-service\n          namespace: openshift-route-monitor-operator\n          path: /convert\n      conversionReviewVersions:\n      - v1' \
+        "$file"
+done
@@ -12,6 +12,7 @@ import (
 	routev1 "github.com/openshift/api/route/v1"
 	hypershiftv1beta1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
 	"github.com/openshift/route-monitor-operator/pkg/alert"
+	"github.com/openshift/route-monitor-operator/pkg/maintenance"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	rhobsv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
@@ -233,7 +234,7 @@ func (i *Integration) RouteMonitorWaitForPrometheusRuleCorrectSLO(name types.Nam
 		return err
 	}
 
-	template := alert.TemplateForPrometheusRuleResource(routeMonitor.Status.RouteURL, targetSlo, name)
+	template := alert.TemplateForPrometheusRuleResource(routeMonitor.Status.RouteURL, targetSlo, routeMonitor.Spec.Slo, routeMonitor.Spec.CertificateExpiry, routeMonitor.Spec.ProbeLocations, maintenance.Upcoming(routeMonitor.Spec.MaintenanceWindows, time.Now()), name)
 	t := 0
 	for ; t < seconds; t++ {
 		err := i.Client.Get(context.TODO(), name, &prometheusRule)
@@ -270,7 +271,7 @@ func (i *Integration) ClusterUrlMonitorWaitForPrometheusRuleCorrectSLO(name type
 		return err
 	}
 
-	template := alert.TemplateForPrometheusRuleResource(expectedUrl, targetSlo, name)
+	template := alert.TemplateForPrometheusRuleResource(expectedUrl, targetSlo, clusterUrlMonitor.Spec.Slo, clusterUrlMonitor.Spec.CertificateExpiry, clusterUrlMonitor.Spec.ProbeLocations, maintenance.Upcoming(clusterUrlMonitor.Spec.MaintenanceWindows, time.Now()), name)
 	t := 0
 	for ; t < seconds; t++ {
 		err := i.Client.Get(context.TODO(), name, &prometheusRule)
@@ -20,10 +20,11 @@ import (
 	"context"
 	"flag"
 	"os"
-	"strings"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -34,6 +35,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	configv1 "github.com/openshift/api/config/v1"
 	operatorv1 "github.com/openshift/api/operator/v1"
@@ -41,16 +43,27 @@ import (
 	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
 	hypershiftv1beta1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 
 	rmov1alpha1 "github.com/openshift/route-monitor-operator/api/v1alpha1"
+	rmov1beta1 "github.com/openshift/route-monitor-operator/api/v1beta1"
 	"github.com/openshift/route-monitor-operator/config"
+	blackboxexportercontroller "github.com/openshift/route-monitor-operator/controllers/blackboxexporter"
 	"github.com/openshift/route-monitor-operator/controllers/clusterurlmonitor"
 	"github.com/openshift/route-monitor-operator/controllers/hostedcontrolplane"
+	operatorconfigcontroller "github.com/openshift/route-monitor-operator/controllers/operatorconfig"
+	"github.com/openshift/route-monitor-operator/controllers/routeannotation"
 	"github.com/openshift/route-monitor-operator/controllers/routemonitor"
+	"github.com/openshift/route-monitor-operator/controllers/routemonitorset"
+	blackboxexporterpkg "github.com/openshift/route-monitor-operator/pkg/blackboxexporter"
+	"github.com/openshift/route-monitor-operator/pkg/consts/blackboxexporter"
+	"github.com/openshift/route-monitor-operator/pkg/operatorconfig"
+	"github.com/openshift/route-monitor-operator/pkg/probestatus"
 	"github.com/openshift/route-monitor-operator/pkg/rhobs"
-	"github.com/openshift/route-monitor-operator/pkg/util"
+	monitorwebhook "github.com/openshift/route-monitor-operator/pkg/webhook"
 	rhobsv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
 	// +kubebuilder:scaffold:imports
 )
@@ -66,6 +79,7 @@ var (
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(rmov1alpha1.AddToScheme(scheme))
+	utilruntime.Must(rmov1beta1.AddToScheme(scheme))
 	utilruntime.Must(monitoringv1.AddToScheme(scheme))
 	utilruntime.Must(routev1.AddToScheme(scheme))
 	utilruntime.Must(configv1.AddToScheme(scheme))
@@ -93,6 +107,7 @@ func main() {
 
 	var blackboxExporterImage string
 	var blackboxExporterNamespace string
+	var blackboxExporterReplicas int
 	var probeAPIURL string
 	var probeTenant string
 	var oidcClientID string
@@ -100,9 +115,14 @@ func main() {
 	var oidcIssuerURL string
 	var onlyPublicClusters bool
 	var skipInfrastructureHealthCheck bool
+	var probeStatusConfig probestatus.Config
+	var enableWebhooks bool
+	var webhookPort int
+	var webhookCertDir string
 
 	flag.StringVar(&blackboxExporterImage, "blackbox-image", "quay.io/prometheus/blackbox-exporter@sha256:b04a9fef4fa086a02fc7fcd8dcdbc4b7b35cc30cdee860fdc6a19dd8b208d63e", "The image that will be used for the blackbox-exporter deployment")
 	flag.StringVar(&blackboxExporterNamespace, "blackbox-namespace", config.OperatorNamespace, "Blackbox-exporter deployment will reside on this Namespace")
+	flag.IntVar(&blackboxExporterReplicas, "blackbox-replicas", blackboxexporter.DefaultReplicas, "The number of blackbox-exporter pods. Every replica probes all targets, more than one keeps the targets probed while a node is drained.")
 	flag.StringVar(&probeAPIURL, "probe-api-url", "", "The fully qualified API URL for RHOBS synthetics probe management (for HostedCluster monitoring). When empty, uses default blackbox exporter behavior.")
 	flag.StringVar(&probeTenant, "probe-tenant", "hcp", "RHOBS tenant name used in API URLs. Defaults to 'hcp'.")
 	flag.StringVar(&oidcClientID, "oidc-client-id", "", "OIDC client ID for RHOBS API authentication. When empty, no OIDC authentication is used.")
@@ -111,96 +131,43 @@ func main() {
 	flag.BoolVar(&onlyPublicClusters, "only-public-clusters", false, "When true, only create RHOBS probes for public (non-private) HostedClusters. Defaults to false (process all clusters).")
 	flag.BoolVar(&skipInfrastructureHealthCheck, "skip-infrastructure-health-check", false, "When true, skip infrastructure health checks (HCP ready, VPC endpoint ready) for test environments. Defaults to false.")
 
+	flag.StringVar(&probeStatusConfig.URL, "prometheus-url", "", "URL of the Prometheus or Thanos Querier the probe status of monitors using monitoring.coreos.com ServiceMonitors is queried from. When empty, the probe status is not reported for them.")
+	flag.StringVar(&probeStatusConfig.RHOBSURL, "rhobs-prometheus-url", "", "URL of the Prometheus or Thanos Querier the probe status of monitors using monitoring.rhobs ServiceMonitors is queried from. When empty, the probe status is not reported for them.")
+	flag.StringVar(&probeStatusConfig.BearerTokenFile, "prometheus-bearer-token-file", "", "File holding the bearer token sent to the Prometheus endpoints, e.g. /var/run/secrets/kubernetes.io/serviceaccount/token. When empty, no token is sent.")
+	flag.StringVar(&probeStatusConfig.CAFile, "prometheus-ca-file", "", "File holding the CA verifying the Prometheus endpoints. When empty, the system CAs are used.")
+	flag.DurationVar(&probeStatusConfig.Interval, "probe-status-interval", probestatus.DefaultInterval, "How often the probe status of a monitor is queried from Prometheus.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false, "Serve the defaulting and validating webhooks of RouteMonitors and ClusterUrlMonitors. The conversion webhook required by the CRDs is always served.")
+	flag.IntVar(&webhookPort, "webhook-port", 9443, "The port the webhook server binds to.")
+	flag.StringVar(&webhookCertDir, "webhook-cert-dir", "", "The directory holding tls.crt and tls.key of the webhook server. When empty, the controller-runtime default is used.")
+
 	opts := zap.Options{}
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
-	// Try to read configuration from ConfigMap, fallback to command-line flags
-	configData, err := getConfigFromConfigMap()
-	if err != nil {
-		setupLog.Error(err, "Failed to read ConfigMap, using command-line flags for all parameters")
-	} else {
-		configMapParams := []string{}
-		flagParams := []string{}
-
-		// Use ConfigMap values if they exist, otherwise keep command-line flag values
-		if configData.ProbeAPIURL != "" {
-			setupLog.V(1).Info("Using probe API URL from ConfigMap", "probeAPIURL", configData.ProbeAPIURL)
-			probeAPIURL = configData.ProbeAPIURL
-			configMapParams = append(configMapParams, "probe-api-url")
-		} else {
-			flagParams = append(flagParams, "probe-api-url")
-		}
-		if configData.ProbeTenant != "" {
-			setupLog.V(1).Info("Using probe tenant from ConfigMap", "probeTenant", configData.ProbeTenant)
-			probeTenant = configData.ProbeTenant
-			configMapParams = append(configMapParams, "probe-tenant")
-		} else {
-			flagParams = append(flagParams, "probe-tenant")
-		}
-
-		if configData.OIDCClientID != "" {
-			setupLog.V(1).Info("Using OIDC client ID from ConfigMap")
-			oidcClientID = configData.OIDCClientID
-			configMapParams = append(configMapParams, "oidc-client-id")
-		} else {
-			flagParams = append(flagParams, "oidc-client-id")
-		}
-
-		if configData.OIDCClientSecret != "" {
-			setupLog.V(1).Info("Using OIDC client secret from ConfigMap")
-			oidcClientSecret = configData.OIDCClientSecret
-			configMapParams = append(configMapParams, "oidc-client-secret")
-		} else {
-			flagParams = append(flagParams, "oidc-client-secret")
-		}
-
-		if configData.OIDCIssuerURL != "" {
-			setupLog.V(1).Info("Using OIDC issuer URL from ConfigMap", "oidcIssuerURL", configData.OIDCIssuerURL)
-			oidcIssuerURL = configData.OIDCIssuerURL
-			configMapParams = append(configMapParams, "oidc-issuer-url")
-		} else {
-			flagParams = append(flagParams, "oidc-issuer-url")
-		}
-
-		// Note: Boolean values from ConfigMap override command-line flags when present
-		if configData.OnlyPublicClusters {
-			setupLog.V(1).Info("Using only-public-clusters from ConfigMap", "onlyPublicClusters", configData.OnlyPublicClusters)
-			onlyPublicClusters = configData.OnlyPublicClusters
-			configMapParams = append(configMapParams, "only-public-clusters")
-		} else {
-			flagParams = append(flagParams, "only-public-clusters")
-		}
-
-		if configData.SkipInfrastructureHealthCheck {
-			setupLog.V(1).Info("Using skip-infrastructure-health-check from ConfigMap", "skipInfrastructureHealthCheck", configData.SkipInfrastructureHealthCheck)
-			skipInfrastructureHealthCheck = configData.SkipInfrastructureHealthCheck
-			configMapParams = append(configMapParams, "skip-infrastructure-health-check")
-		} else {
-			flagParams = append(flagParams, "skip-infrastructure-health-check")
-		}
-
-		// Summarize configuration sources
-		if len(configMapParams) > 0 && len(flagParams) > 0 {
-			setupLog.Info("Using mixed configuration sources",
-				"from_configmap", configMapParams,
-				"from_flags", flagParams)
-		} else if len(configMapParams) > 0 {
-			setupLog.Info("Using ConfigMap for all parameters", "parameters", configMapParams)
-		} else {
-			setupLog.Info("ConfigMap found but all parameters empty, using command-line flags for all parameters")
-		}
+	// The HostedControlPlane reconciler resolves its settings on every reconcile and the probe status at most once a minute, the other settings are resolved once
+	// The HostedControlPlane reconciler and the probe status resolve their settings on every reconcile, the other settings are resolved once
+	flagConfig := operatorconfig.Config{
+		ProbeAPIURL:                   probeAPIURL,
+		ProbeTenant:                   probeTenant,
+		OIDCClientID:                  oidcClientID,
+		OIDCClientSecret:              oidcClientSecret,
+		OIDCIssuerURL:                 oidcIssuerURL,
+		OnlyPublicClusters:            onlyPublicClusters,
+		SkipInfrastructureHealthCheck: skipInfrastructureHealthCheck,
+		PrometheusURL:                 probeStatusConfig.URL,
+		RHOBSPrometheusURL:            probeStatusConfig.RHOBSURL,
 	}
+	startupConfig := loadOperatorConfig(flagConfig)
+	probeAPIURL = startupConfig.ProbeAPIURL
 
-	// Validate probe API URL format (if provided). A malformed URL disables
-	// RHOBS probe operations rather than crashing the operator, so RMO can
-	// still reconcile RouteMonitor and ClusterUrlMonitor resources.
-	if probeAPIURL != "" && !util.ValidURL(probeAPIURL) {
-		setupLog.Info("probe-api-url is malformed, disabling RHOBS probe operations. URL must start with 'http://' or 'https://' and include a host.", "probeAPIURL", probeAPIURL)
-		probeAPIURL = ""
+	poolRequirement, err := labels.NewRequirement(blackboxexporter.PoolLabel, selection.Exists, nil)
+	if err != nil {
+		setupLog.Error(err, "unable to select the BlackboxExporterPool resources")
+		os.Exit(1)
 	}
+	poolSelector := labels.NewSelector().Add(*poolRequirement)
 
 	enableHCP, err := shouldEnableHCP()
 	if err != nil {
@@ -251,8 +218,27 @@ func main() {
 						cache.AllNamespaces: {},
 					},
 				},
+				// Secrets referenced by spec.probe.auth are watched to copy rotated credentials,
+				// only their metadata is cached as the client reads Secrets from the API server
+				&corev1.Secret{}: {
+					Namespaces: map[string]cache.Config{
+						cache.AllNamespaces: {},
+					},
+				},
 			},
 		}
+		// the blackbox exporter resources are watched to revert changes made to them
+		if blackboxExporterNamespace != config.OperatorNamespace {
+			cacheOptions.DefaultNamespaces[blackboxExporterNamespace] = cache.Config{}
+		}
+		// the exporters of BlackboxExporterPools may run in any namespace, only their resources are cached there
+		poolResources := cache.ByObject{Namespaces: map[string]cache.Config{cache.AllNamespaces: {LabelSelector: poolSelector}}}
+		for namespace := range cacheOptions.DefaultNamespaces {
+			poolResources.Namespaces[namespace] = cache.Config{}
+		}
+		cacheOptions.ByObject[&appsv1.Deployment{}] = poolResources
+		cacheOptions.ByObject[&corev1.ConfigMap{}] = poolResources
+		cacheOptions.ByObject[&policyv1.PodDisruptionBudget{}] = poolResources
 	}
 
 	options := ctrl.Options{
@@ -264,6 +250,14 @@ func main() {
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "2793210b.openshift.io",
 		Cache:                  cacheOptions,
+		// the data of Secrets is read when it is needed instead of caching every Secret of the cluster
+		Client: client.Options{
+			Cache: &client.CacheOptions{DisableFor: []client.Object{&corev1.Secret{}}},
+		},
+		WebhookServer: webhook.NewServer(webhook.Options{
+			Port:    webhookPort,
+			CertDir: webhookCertDir,
+		}),
 	}
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), options)
@@ -272,27 +266,85 @@ func main() {
 		os.Exit(1)
 	}
 
-	routeMonitorReconciler := routemonitor.NewReconciler(mgr, blackboxExporterImage, blackboxExporterNamespace, enablehypershift, probeAPIURL)
+	// the Prometheus endpoints of the probe status are resolved by the reconciles of the monitors, at most once a minute
+	probeStatus := &probestatus.Reloader{
+		Ctx:            context.Background(),
+		Loader:         operatorconfig.Loader{Client: mgr.GetClient(), Flags: flagConfig},
+		Config:         probeStatusConfig,
+		ReloadInterval: probestatus.ReloadInterval,
+	}
+
+	// the blackbox exporters count the monitors referencing them through the index
+	if err := blackboxexporterpkg.SetupIndexes(context.Background(), mgr.GetFieldIndexer()); err != nil {
+		setupLog.Error(err, "unable to create the blackbox exporter indexes")
+		os.Exit(1)
+	}
+
+	routeMonitorReconciler := routemonitor.NewReconciler(mgr, blackboxExporterImage, blackboxExporterNamespace, int32(blackboxExporterReplicas), enablehypershift, probeAPIURL, probeStatus)
 	if err := routeMonitorReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "RouteMonitor")
 		os.Exit(1)
 	}
 
-	clusterUrlMonitorReconciler := clusterurlmonitor.NewReconciler(mgr, blackboxExporterImage, blackboxExporterNamespace, enablehypershift, probeAPIURL)
+	clusterUrlMonitorReconciler := clusterurlmonitor.NewReconciler(mgr, blackboxExporterImage, blackboxExporterNamespace, int32(blackboxExporterReplicas), enablehypershift, probeAPIURL, probeStatus)
 	if err := clusterUrlMonitorReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "clusterUrlMonitorReconciler")
 		os.Exit(1)
 	}
 
+	blackBoxExporterReconciler := blackboxexportercontroller.NewReconciler(mgr, blackboxExporterImage, blackboxExporterNamespace, int32(blackboxExporterReplicas))
+	if err := blackBoxExporterReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "BlackBoxExporter")
+		os.Exit(1)
+	}
+
+	blackboxExporterPoolReconciler := blackboxexportercontroller.NewPoolReconciler(mgr, blackboxExporterImage, blackboxExporterNamespace, int32(blackboxExporterReplicas))
+	if err := blackboxExporterPoolReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "BlackboxExporterPool")
+		os.Exit(1)
+	}
+
+	routeMonitorSetReconciler := routemonitorset.NewReconciler(mgr)
+	if err := routeMonitorSetReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "RouteMonitorSet")
+		os.Exit(1)
+	}
+
+	routeAnnotationReconciler := routeannotation.NewReconciler(mgr)
+	if err := routeAnnotationReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "RouteAnnotation")
+		os.Exit(1)
+	}
+
+	// the CRDs convert between v1alpha1 and the v1beta1 storage version through the webhook server,
+	// which therefore requires a serving certificate in the webhook cert dir
+	if err := monitorwebhook.SetupConversionWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "conversion")
+		os.Exit(1)
+	}
+	if enableWebhooks {
+		if err := monitorwebhook.SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhooks", "webhook", "RouteMonitor, ClusterUrlMonitor")
+			os.Exit(1)
+		}
+	}
+
+	operatorConfigReconciler := operatorconfigcontroller.NewReconciler(mgr, flagConfig)
+	if err := operatorConfigReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "RouteMonitorOperatorConfig")
+		os.Exit(1)
+	}
+
 	if enableHCP {
+		// the flags are the fallback of the configuration resolved on every reconcile
 		rhobsConfig := hostedcontrolplane.RHOBSConfig{
-			ProbeAPIURL:                   probeAPIURL,
-			Tenant:                        probeTenant,
-			OIDCClientID:                  oidcClientID,
-			OIDCClientSecret:              oidcClientSecret,
-			OIDCIssuerURL:                 oidcIssuerURL,
-			OnlyPublicClusters:            onlyPublicClusters,
-			SkipInfrastructureHealthCheck: skipInfrastructureHealthCheck,
+			ProbeAPIURL:                   flagConfig.ProbeAPIURL,
+			Tenant:                        flagConfig.ProbeTenant,
+			OIDCClientID:                  flagConfig.OIDCClientID,
+			OIDCClientSecret:              flagConfig.OIDCClientSecret,
+			OIDCIssuerURL:                 flagConfig.OIDCIssuerURL,
+			OnlyPublicClusters:            flagConfig.OnlyPublicClusters,
+			SkipInfrastructureHealthCheck: flagConfig.SkipInfrastructureHealthCheck,
 		}
 		hostedControlPlaneReconciler := hostedcontrolplane.NewHostedControlPlaneReconciler(mgr, rhobsConfig)
 		if err = hostedControlPlaneReconciler.SetupWithManager(mgr); err != nil {
@@ -343,106 +395,31 @@ func shouldEnableHCP() (bool, error) {
 	return true, nil
 }
 
-// OperatorConfig holds configuration values from ConfigMap
-type OperatorConfig struct {
-	ProbeAPIURL                   string
-	ProbeTenant                   string
-	OIDCClientID                  string
-	OIDCClientSecret              string
-	OIDCIssuerURL                 string
-	OnlyPublicClusters            bool
-	SkipInfrastructureHealthCheck bool
-}
-
-// getConfigFromConfigMap reads configuration from the route-monitor-operator-config ConfigMap
-func getConfigFromConfigMap() (*OperatorConfig, error) {
+// loadOperatorConfig resolves the configuration read at startup and logs the source of every value.
+// Rejected values are logged and fall back to the value of the previous source
+func loadOperatorConfig(flags operatorconfig.Config) operatorconfig.Config {
 	c, err := client.New(ctrl.GetConfigOrDie(), client.Options{Scheme: scheme})
 	if err != nil {
-		return nil, err
+		setupLog.Error(err, "unable to create a client reading the configuration")
+		os.Exit(1)
 	}
 
-	configMapName := config.OperatorName + "-config"
-	configMap := &corev1.ConfigMap{}
-	err = c.Get(context.TODO(), types.NamespacedName{
-		Name:      configMapName,
-		Namespace: config.OperatorNamespace,
-	}, configMap)
-
+	loader := operatorconfig.Loader{Client: c, Flags: flags}
+	result, err := loader.Load(context.TODO())
 	if err != nil {
-		if errors.IsNotFound(err) {
-			// ConfigMap doesn't exist, return empty config (no error)
-			// This is a normal case and not an error condition
-			setupLog.Info("ConfigMap not found, using command-line flags for all parameters",
-				"configmap", configMapName,
-				"namespace", config.OperatorNamespace)
-			return &OperatorConfig{}, nil
-		}
-		// This is an actual error (network, permissions, etc.)
-		return nil, err
-	}
-
-	// Extract configuration values, trimming whitespace
-	cfg := &OperatorConfig{
-		ProbeAPIURL:                   strings.TrimSpace(configMap.Data["probe-api-url"]),
-		ProbeTenant:                   strings.TrimSpace(configMap.Data["probe-tenant"]),
-		OIDCClientID:                  strings.TrimSpace(configMap.Data["oidc-client-id"]),
-		OIDCClientSecret:              strings.TrimSpace(configMap.Data["oidc-client-secret"]),
-		OIDCIssuerURL:                 strings.TrimSpace(configMap.Data["oidc-issuer-url"]),
-		OnlyPublicClusters:            strings.TrimSpace(configMap.Data["only-public-clusters"]) == "true",
-		SkipInfrastructureHealthCheck: strings.TrimSpace(configMap.Data["skip-infrastructure-health-check"]) == "true",
-	}
-
-	// Log detailed information about what was found in the ConfigMap
-	foundParams := []string{}
-	missingParams := []string{}
-
-	if cfg.ProbeAPIURL != "" {
-		foundParams = append(foundParams, "probe-api-url")
-	} else {
-		missingParams = append(missingParams, "probe-api-url")
-	}
-
-	if cfg.ProbeTenant != "" {
-		foundParams = append(foundParams, "probe-tenant")
-	} else {
-		missingParams = append(missingParams, "probe-tenant")
+		setupLog.Error(err, "Failed to read the configuration, using the readable sources")
 	}
-
-	if cfg.OIDCClientID != "" {
-		foundParams = append(foundParams, "oidc-client-id")
-	} else {
-		missingParams = append(missingParams, "oidc-client-id")
-	}
-
-	if cfg.OIDCClientSecret != "" {
-		foundParams = append(foundParams, "oidc-client-secret")
-	} else {
-		missingParams = append(missingParams, "oidc-client-secret")
-	}
-
-	if cfg.OIDCIssuerURL != "" {
-		foundParams = append(foundParams, "oidc-issuer-url")
-	} else {
-		missingParams = append(missingParams, "oidc-issuer-url")
+	for _, invalid := range result.Errors {
+		setupLog.Info("Ignoring invalid configuration value", "error", invalid.Error())
 	}
 
-	if configMap.Data["only-public-clusters"] != "" {
-		foundParams = append(foundParams, "only-public-clusters")
-	} else {
-		missingParams = append(missingParams, "only-public-clusters")
+	sources := map[rmov1alpha1.ConfigSource][]string{}
+	for _, value := range result.Values {
+		sources[value.Source] = append(sources[value.Source], value.Name)
 	}
-
-	if configMap.Data["skip-infrastructure-health-check"] != "" {
-		foundParams = append(foundParams, "skip-infrastructure-health-check")
-	} else {
-		missingParams = append(missingParams, "skip-infrastructure-health-check")
-	}
-
-	setupLog.Info("ConfigMap found and processed",
-		"configmap", configMapName,
-		"namespace", config.OperatorNamespace,
-		"found_parameters", foundParams,
-		"missing_or_empty_parameters", missingParams)
-
-	return cfg, nil
+	setupLog.Info("Resolved the configuration",
+		"from_routemonitoroperatorconfig", sources[rmov1alpha1.ConfigSourceRouteMonitorOperatorConfig],
+		"from_configmap", sources[rmov1alpha1.ConfigSourceConfigMap],
+		"from_flags", sources[rmov1alpha1.ConfigSourceFlag])
+	return result.Config
 }
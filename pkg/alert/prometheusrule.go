@@ -14,12 +14,14 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	"github.com/openshift/route-monitor-operator/pkg/maintenance"
 	util "github.com/openshift/route-monitor-operator/pkg/reconcile"
 	"github.com/openshift/route-monitor-operator/pkg/servicemonitor"
 
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 type PrometheusRule struct {
@@ -36,30 +38,37 @@ func NewPrometheusRule(ctx context.Context, c client.Client) *PrometheusRule {
 	}
 }
 
-// Creates or Updates PrometheusRule Deployment according to the template
-func (u *PrometheusRule) UpdatePrometheusRuleDeployment(template monitoringv1.PrometheusRule) error {
+// Creates or Updates PrometheusRule Deployment according to the template and returns whether it was created or updated
+func (u *PrometheusRule) UpdatePrometheusRuleDeployment(template monitoringv1.PrometheusRule) (controllerutil.OperationResult, error) {
 	namespacedName := types.NamespacedName{Name: template.Name, Namespace: template.Namespace}
 	deployedPrometheusRule := &monitoringv1.PrometheusRule{}
 	err := u.Client.Get(u.Ctx, namespacedName, deployedPrometheusRule)
 	if err != nil {
 		// No similar Prometheus Rule exists
 		if !k8serrors.IsNotFound(err) {
-			return err
+			return controllerutil.OperationResultNone, err
 		}
-		return u.Client.Create(u.Ctx, &template)
+		if err := u.Client.Create(u.Ctx, &template); err != nil {
+			return controllerutil.OperationResultNone, err
+		}
+		return controllerutil.OperationResultCreated, nil
 	}
 	if !u.Comparer.DeepEqual(template.Spec, deployedPrometheusRule.Spec) {
 		// Update existing PrometheuesRule for the case that the template changed
 		deployedPrometheusRule.Spec = template.Spec
-		return u.Client.Update(u.Ctx, deployedPrometheusRule)
+		if err := u.Client.Update(u.Ctx, deployedPrometheusRule); err != nil {
+			return controllerutil.OperationResultNone, err
+		}
+		return controllerutil.OperationResultUpdated, nil
 	}
-	return nil
+	return controllerutil.OperationResultNone, nil
 }
 
-func (u *PrometheusRule) DeletePrometheusRuleDeployment(prometheusRuleRef v1alpha1.NamespacedName) error {
+// DeletePrometheusRuleDeployment deletes the PrometheusRule and returns whether it existed
+func (u *PrometheusRule) DeletePrometheusRuleDeployment(prometheusRuleRef v1alpha1.NamespacedName) (bool, error) {
 	// nothing to delete, stopping early
 	if prometheusRuleRef == (v1alpha1.NamespacedName{}) {
-		return nil
+		return false, nil
 	}
 	namespacedName := types.NamespacedName{Name: prometheusRuleRef.Name, Namespace: prometheusRuleRef.Namespace}
 	resource := &monitoringv1.PrometheusRule{}
@@ -68,14 +77,30 @@ func (u *PrometheusRule) DeletePrometheusRuleDeployment(prometheusRuleRef v1alph
 	if err != nil {
 		if !k8serrors.IsNotFound(err) {
 			// If this is an unknown error
-			return err
+			return false, err
 		}
 		// Resource doesn't exist, nothing to do
-		return nil
+		return false, nil
+	}
+	if err := u.Client.Delete(u.Ctx, resource); err != nil {
+		return false, err
 	}
-	return u.Client.Delete(u.Ctx, resource)
+	return true, nil
 }
 
+// PrometheusRuleExists returns whether the PrometheusRule referenced by a namespaced name exists
+func (u *PrometheusRule) PrometheusRuleExists(prometheusRuleRef v1alpha1.NamespacedName) (bool, error) {
+	namespacedName := types.NamespacedName{Name: prometheusRuleRef.Name, Namespace: prometheusRuleRef.Namespace}
+	err := u.Client.Get(u.Ctx, namespacedName, &monitoringv1.PrometheusRule{})
+	if k8serrors.IsNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// MaintenanceLabelName labels the alerts firing during the maintenance windows of a monitor with the name of the window
+const MaintenanceLabelName = "maintenance_window"
+
 type multiWindowMultiBurnAlertRule struct {
 	duration    string
 	severity    string
@@ -84,58 +109,192 @@ type multiWindowMultiBurnAlertRule struct {
 	burnRate    string
 }
 
-func alertThreshold(windowSize, percent, label, burnRate string) string {
+// groupBy keeps the probes of every location apart in the aggregations of a rule waiting for a quorum of locations
+func groupBy(locations *v1alpha1.ProbeLocationsSpec) string {
+	if locations == nil {
+		return ""
+	}
+	return " by (" + servicemonitor.LocationLabelName + ")"
+}
 
-	rule := "1-(sum(sum_over_time(probe_success{" + label + "}[" + windowSize + "]))" +
-		"/ sum(count_over_time(probe_success{" + label + "}[" + windowSize + "])))" +
+// withQuorum only lets the alert fire once the condition holds for the quorum of locations
+func withQuorum(alertString string, locations *v1alpha1.ProbeLocationsSpec) string {
+	if locations == nil {
+		return alertString
+	}
+	return "count(\n" + alertString + "\n) >= " + strconv.Itoa(locations.EffectiveQuorum())
+}
+
+func alertThreshold(windowSize, percent, label, burnRate, by string) string {
+
+	rule := "1-(sum" + by + "(" + servicemonitor.OverReplicas("sum_over_time", "probe_success{"+label+"}", windowSize) + ")" +
+		"/ sum" + by + "(" + servicemonitor.OverReplicas("count_over_time", "probe_success{"+label+"}", windowSize) + "))" +
 		"> (" + burnRate + "*(1-" + percent + "))"
 
 	return rule
 }
 
-func sufficientProbes(windowSize, label string) string {
+// latencyAlertThreshold compares the probe durations against the threshold at the quantile left by the burnt latency
+// budget: more probes than the budget allows are slower than the threshold once that quantile exceeds it
+func latencyAlertThreshold(windowSize, percent, metric, label, threshold, burnRate, by string) string {
+
+	rule := "avg" + by + "(" + servicemonitor.OverReplicas("quantile_over_time", metric+"{"+label+"}", windowSize, "1-("+burnRate+"*(1-"+percent+"))") + ")" +
+		" > " + threshold
+
+	return rule
+}
+
+// sufficientProbes requires at least half of the probes expected in the window to have run, counting the
+// samples of the probes rather than the steps of a subquery, so missing probes are not made up for
+func sufficientProbes(windowSize, label, by string) string {
 	window, _ := prometheus.ParseDuration(windowSize)
 	window_duration := time.Duration(window)
 	mPeriod, _ := prometheus.ParseDuration(servicemonitor.ServiceMonitorPeriod)
 	mPeriod_duration := time.Duration(mPeriod)
 	necessaryProbesInWindow := int(window_duration.Minutes() / mPeriod_duration.Minutes() * 0.5)
 
-	rule := "sum(count_over_time(probe_success{" + label + "}[" + windowSize + "]))" +
+	rule := "sum" + by + "(" + servicemonitor.OverReplicas("count_over_time", "probe_success{"+label+"}", windowSize) + ")" +
 		" > " + strconv.Itoa(necessaryProbesInWindow)
 
 	return rule
 }
 
 // render creates a monitoring rule for the defined multiwindow multi-burn rate alert
-func (r *multiWindowMultiBurnAlertRule) render(url string, percent string, namespacedName types.NamespacedName) monitoringv1.Rule {
+func (r *multiWindowMultiBurnAlertRule) render(url string, percent string, locations *v1alpha1.ProbeLocationsSpec, windows []maintenance.Window, namespacedName types.NamespacedName) monitoringv1.Rule {
 	labelSelector := fmt.Sprintf(`%s="%s"`, servicemonitor.UrlLabelName, url)
+	by := groupBy(locations)
 
 	alertString := "" +
-		alertThreshold(r.shortWindow, percent, labelSelector, r.burnRate) +
+		alertThreshold(r.shortWindow, percent, labelSelector, r.burnRate, by) +
 		" and " +
-		sufficientProbes(r.shortWindow, labelSelector) +
+		sufficientProbes(r.shortWindow, labelSelector, by) +
 		"\nand\n" +
-		alertThreshold(r.longWindow, percent, labelSelector, r.burnRate) +
+		alertThreshold(r.longWindow, percent, labelSelector, r.burnRate, by) +
 		" and " +
-		sufficientProbes(r.longWindow, labelSelector)
+		sufficientProbes(r.longWindow, labelSelector, by)
+
+	return monitoringv1.Rule{
+		Alert:  namespacedName.Name + "-ErrorBudgetBurn",
+		Expr:   intstr.FromString(withoutMaintenance(withConsoleIndicator(withQuorum(alertString, locations), url, namespacedName), windows)),
+		Labels: r.renderLabels(url, namespacedName.Namespace),
+		Annotations: map[string]string{
+			"message": fmt.Sprintf("High error budget burn for %s %s", url, currentValue(locations)),
+		},
+		For: monitoringv1.Duration(r.duration),
+	}
+}
+
+// renderLatency creates a monitoring rule for the latency objective of the defined multiwindow multi-burn rate alert
+func (r *multiWindowMultiBurnAlertRule) renderLatency(url string, latency v1alpha1.LatencySloSpec, locations *v1alpha1.ProbeLocationsSpec, windows []maintenance.Window, namespacedName types.NamespacedName) monitoringv1.Rule {
+	_, percent := latency.IsValid()
+	threshold := latency.ThresholdSeconds()
+	labelSelector := fmt.Sprintf(`%s="%s"`, servicemonitor.UrlLabelName, url)
+	by := groupBy(locations)
 
-	if namespacedName.Name == "console" {
-		defaultConsoleURL := strings.TrimSuffix(url, "/health")
-		defaultConsoleIndicator := fmt.Sprintf("count(console_url{url=\"%s\"} == 1) > 0", defaultConsoleURL)
-		alertString = alertString + "\nand\n" + defaultConsoleIndicator
+	metric := "probe_duration_seconds"
+	latencyLabelSelector := labelSelector
+	if latency.Phase != "" {
+		metric = "probe_http_duration_seconds"
+		latencyLabelSelector = fmt.Sprintf(`%s,phase="%s"`, labelSelector, latency.Phase)
 	}
 
+	alertString := "" +
+		latencyAlertThreshold(r.shortWindow, percent, metric, latencyLabelSelector, threshold, r.burnRate, by) +
+		" and " +
+		sufficientProbes(r.shortWindow, labelSelector, by) +
+		"\nand\n" +
+		latencyAlertThreshold(r.longWindow, percent, metric, latencyLabelSelector, threshold, r.burnRate, by) +
+		" and " +
+		sufficientProbes(r.longWindow, labelSelector, by)
+
 	return monitoringv1.Rule{
-		Alert:  namespacedName.Name + "-ErrorBudgetBurn",
-		Expr:   intstr.FromString(alertString),
+		Alert:  namespacedName.Name + "-LatencyBudgetBurn",
+		Expr:   intstr.FromString(withoutMaintenance(withConsoleIndicator(withQuorum(alertString, locations), url, namespacedName), windows)),
 		Labels: r.renderLabels(url, namespacedName.Namespace),
 		Annotations: map[string]string{
-			"message": fmt.Sprintf("High error budget burn for %s (current value: {{ $value }})", url),
+			"message": fmt.Sprintf("High latency budget burn for %s, probes are slower than %s %s", url, latency.Threshold, currentValue(locations)),
 		},
 		For: monitoringv1.Duration(r.duration),
 	}
 }
 
+// renderCertificateExpiry creates a monitoring rule firing when the certificate presented to the probe expires within the given days
+func renderCertificateExpiry(url string, days int, severity string, namespacedName types.NamespacedName) monitoringv1.Rule {
+	labelSelector := fmt.Sprintf(`%s="%s"`, servicemonitor.UrlLabelName, url)
+
+	alertString := "(min(probe_ssl_earliest_cert_expiry{" + labelSelector + "}) - time()) / 86400" +
+		" < " + strconv.Itoa(days)
+
+	return monitoringv1.Rule{
+		Alert: namespacedName.Name + "-CertificateExpiry",
+		Expr:  intstr.FromString(withConsoleIndicator(alertString, url, namespacedName)),
+		Labels: map[string]string{
+			servicemonitor.UrlLabelName: url,
+			"namespace":                 namespacedName.Namespace,
+			"severity":                  severity,
+		},
+		Annotations: map[string]string{
+			"message": fmt.Sprintf("The certificate of %s expires in {{ $value | humanize }} days", url),
+		},
+		For: monitoringv1.Duration("5m"),
+	}
+}
+
+// duringMaintenance selects a sample while one of the maintenance windows is in progress. The windows are compared
+// against the evaluation time, so they start and end on time regardless of when the rule is updated
+func duringMaintenance(windows []maintenance.Window) string {
+	selectors := []string{}
+	for _, window := range windows {
+		selectors = append(selectors, fmt.Sprintf("vector(time()) >= %d < %d", window.Start.Unix(), window.End.Unix()))
+	}
+	return strings.Join(selectors, " or ")
+}
+
+// withoutMaintenance suppresses the alert while one of the maintenance windows is in progress
+func withoutMaintenance(alertString string, windows []maintenance.Window) string {
+	if len(windows) == 0 {
+		return alertString
+	}
+	return "(\n" + alertString + "\n)\nunless on()\n(" + duringMaintenance(windows) + ")"
+}
+
+// renderMaintenance creates an alert firing during the maintenance window, labeled with the URL of the monitor and the
+// name of the window, so Alertmanager inhibit rules can suppress further alerts on the URL
+func renderMaintenance(url string, window maintenance.Window, namespacedName types.NamespacedName) monitoringv1.Rule {
+	return monitoringv1.Rule{
+		Alert: namespacedName.Name + "-MaintenanceWindow",
+		Expr:  intstr.FromString(duringMaintenance([]maintenance.Window{window})),
+		Labels: map[string]string{
+			servicemonitor.UrlLabelName: url,
+			MaintenanceLabelName:        window.Name,
+			"namespace":                 namespacedName.Namespace,
+			"severity":                  "none",
+		},
+		Annotations: map[string]string{
+			"message": fmt.Sprintf("The maintenance window %s of %s is in progress, its burn rate alerts are suppressed", window.Name, url),
+		},
+	}
+}
+
+// currentValue describes the value of a burn rate alert, which is the number of locations observing the failure
+// for alerts waiting for a quorum of locations
+func currentValue(locations *v1alpha1.ProbeLocationsSpec) string {
+	if locations == nil {
+		return "(current value: {{ $value }})"
+	}
+	return fmt.Sprintf("(observed from {{ $value }} of %d locations)", len(locations.Locations))
+}
+
+// withConsoleIndicator only lets alerts for the console fire when the default console URL is in use
+func withConsoleIndicator(alertString, url string, namespacedName types.NamespacedName) string {
+	if namespacedName.Name != "console" {
+		return alertString
+	}
+	defaultConsoleURL := strings.TrimSuffix(url, "/health")
+	defaultConsoleIndicator := fmt.Sprintf("count(console_url{url=\"%s\"} == 1) > 0", defaultConsoleURL)
+	return alertString + "\nand\n" + defaultConsoleIndicator
+}
+
 func (r *multiWindowMultiBurnAlertRule) renderLabels(url, namespace string) map[string]string {
 	return map[string]string{
 		servicemonitor.UrlLabelName: url,
@@ -146,43 +305,105 @@ func (r *multiWindowMultiBurnAlertRule) renderLabels(url, namespace string) map[
 	}
 }
 
-// TemplateForPrometheusRuleResource returns a PrometheusRule
-func TemplateForPrometheusRuleResource(url, percent string, namespacedName types.NamespacedName) monitoringv1.PrometheusRule {
+// defaultAlertRules are the burn rate alerts used when a SLO doesn't define its own
+var defaultAlertRules = []multiWindowMultiBurnAlertRule{
+	{
+		duration:    "2m",
+		severity:    v1alpha1.SeverityCritical,
+		longWindow:  "1h",
+		shortWindow: "5m",
+		burnRate:    "14.40",
+	},
+	{
+		duration:    "15m",
+		severity:    v1alpha1.SeverityCritical,
+		longWindow:  "6h",
+		shortWindow: "30m",
+		burnRate:    "6",
+	},
+	{
+		duration:    "1h",
+		severity:    v1alpha1.SeverityWarning,
+		longWindow:  "1d",
+		shortWindow: "2h",
+		burnRate:    "3",
+	},
+	{
+		duration:    "3h",
+		severity:    v1alpha1.SeverityWarning,
+		longWindow:  "3d",
+		shortWindow: "6h",
+		burnRate:    "1",
+	},
+}
 
-	rules := []monitoringv1.Rule{}
-	alertRules := []multiWindowMultiBurnAlertRule{
-		{
-			duration:    "2m",
-			severity:    "critical",
-			longWindow:  "1h",
-			shortWindow: "5m",
-			burnRate:    "14.40",
-		},
-		{
-			duration:    "15m",
-			severity:    "critical",
-			longWindow:  "6h",
-			shortWindow: "30m",
-			burnRate:    "6",
-		},
-		{
-			duration:    "1h",
-			severity:    "warning",
-			longWindow:  "1d",
-			shortWindow: "2h",
-			burnRate:    "3",
-		},
-		{
-			duration:    "3h",
-			severity:    "warning",
-			longWindow:  "3d",
-			shortWindow: "6h",
-			burnRate:    "1",
-		},
+// alertRulesFor returns the burn rate alerts defined in the alerting spec, or the defaults if none are defined
+func alertRulesFor(alerting *v1alpha1.SloAlertingSpec) []multiWindowMultiBurnAlertRule {
+	if alerting == nil || len(alerting.BurnRateWindows) == 0 {
+		return defaultAlertRules
+	}
+	alertRules := make([]multiWindowMultiBurnAlertRule, 0, len(alerting.BurnRateWindows))
+	for _, w := range alerting.BurnRateWindows {
+		alertRules = append(alertRules, multiWindowMultiBurnAlertRule{
+			duration:    w.For,
+			severity:    w.Severity,
+			longWindow:  w.LongWindow,
+			shortWindow: w.ShortWindow,
+			burnRate:    w.BurnRate,
+		})
+	}
+	return alertRules
+}
+
+// TemplateForPrometheusRuleResource returns a PrometheusRule containing the SLO alerts if a
+// target percent is given, and the certificate expiry alerts if certificateExpiry is set.
+// The SLO alerts of a monitor probed from several locations fire once the quorum of locations burns the budget,
+// and are suppressed during the maintenance windows
+func TemplateForPrometheusRuleResource(url, percent string, slo v1alpha1.SloSpec, certificateExpiry *v1alpha1.CertificateExpirySpec, locations *v1alpha1.ProbeLocationsSpec, windows []maintenance.Window, namespacedName types.NamespacedName) monitoringv1.PrometheusRule {
+
+	groups := []monitoringv1.RuleGroup{}
+	if percent != "" {
+		alertRules := alertRulesFor(slo.Alerting)
+		rules := []monitoringv1.Rule{}
+		for _, alertrule := range alertRules { // Create all the alerts
+			rules = append(rules, alertrule.render(url, percent, locations, windows, namespacedName))
+		}
+		groups = append(groups, monitoringv1.RuleGroup{
+			Name:  "SLOs-probe",
+			Rules: rules,
+		})
+
+		if slo.Latency != nil {
+			latencyRules := []monitoringv1.Rule{}
+			for _, alertrule := range alertRules {
+				latencyRules = append(latencyRules, alertrule.renderLatency(url, *slo.Latency, locations, windows, namespacedName))
+			}
+			groups = append(groups, monitoringv1.RuleGroup{
+				Name:  "SLOs-latency",
+				Rules: latencyRules,
+			})
+		}
 	}
 
-	for _, alertrule := range alertRules { // Create all the alerts
-		rules = append(rules, alertrule.render(url, percent, namespacedName))
+	if certificateExpiry != nil {
+		groups = append(groups, monitoringv1.RuleGroup{
+			Name: "certificate-expiry",
+			Rules: []monitoringv1.Rule{
+				renderCertificateExpiry(url, certificateExpiry.WarningDays, v1alpha1.SeverityWarning, namespacedName),
+				renderCertificateExpiry(url, certificateExpiry.CriticalDays, v1alpha1.SeverityCritical, namespacedName),
+			},
+		})
+	}
+
+	if len(windows) > 0 {
+		maintenanceRules := []monitoringv1.Rule{}
+		for _, window := range windows {
+			maintenanceRules = append(maintenanceRules, renderMaintenance(url, window, namespacedName))
+		}
+		groups = append(groups, monitoringv1.RuleGroup{
+			Name:  "maintenance",
+			Rules: maintenanceRules,
+		})
 	}
 
 	resource := monitoringv1.PrometheusRule{
@@ -191,12 +412,7 @@ func TemplateForPrometheusRuleResource(url, percent string, namespacedName types
 			Namespace: namespacedName.Namespace,
 		},
 		Spec: monitoringv1.PrometheusRuleSpec{
-			Groups: []monitoringv1.RuleGroup{
-				{
-					Name:  "SLOs-probe",
-					Rules: rules,
-				},
-			},
+			Groups: groups,
 		},
 	}
 	return resource
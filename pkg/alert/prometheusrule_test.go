@@ -6,15 +6,19 @@ import (
 	"go.uber.org/mock/gomock"
 
 	"context"
+	"time"
 
 	// tested package
 	"github.com/openshift/route-monitor-operator/api/v1alpha1"
 	"github.com/openshift/route-monitor-operator/pkg/alert"
 	consterror "github.com/openshift/route-monitor-operator/pkg/consts/test/error"
+	"github.com/openshift/route-monitor-operator/pkg/maintenance"
 	clientmocks "github.com/openshift/route-monitor-operator/pkg/util/test/generated/mocks/client"
 	utilmock "github.com/openshift/route-monitor-operator/pkg/util/test/generated/mocks/reconcile"
 	testhelper "github.com/openshift/route-monitor-operator/pkg/util/test/helper"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 type ResourceComparerMockHelper struct {
@@ -37,6 +41,8 @@ var _ = Describe("CR Deployment Handling", func() {
 		prometheusRuleRef v1alpha1.NamespacedName
 		prometheusRule    monitoringv1.PrometheusRule
 		pr                alert.PrometheusRule
+		result            controllerutil.OperationResult
+		deleted           bool
 		err               error
 	)
 	BeforeEach(func() {
@@ -90,7 +96,7 @@ var _ = Describe("CR Deployment Handling", func() {
 			get.CalledTimes = 1
 		})
 		JustBeforeEach(func() {
-			err = pr.UpdatePrometheusRuleDeployment(prometheusRule)
+			result, err = pr.UpdatePrometheusRuleDeployment(prometheusRule)
 		})
 		When("the Client failed to fetch existing deployments", func() {
 			BeforeEach(func() {
@@ -107,6 +113,7 @@ var _ = Describe("CR Deployment Handling", func() {
 			})
 			It("tryies to creates one", func() {
 				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(controllerutil.OperationResultCreated))
 			})
 			When("an error appeared during the creation", func() {
 				BeforeEach(func() {
@@ -128,6 +135,7 @@ var _ = Describe("CR Deployment Handling", func() {
 				})
 				It("updates the existing deployment", func() {
 					Expect(err).NotTo(HaveOccurred())
+					Expect(result).To(Equal(controllerutil.OperationResultUpdated))
 				})
 				When("the client failed to update the existing deployments", func() {
 					BeforeEach(func() {
@@ -144,13 +152,14 @@ var _ = Describe("CR Deployment Handling", func() {
 				})
 				It("does nothing", func() {
 					Expect(err).NotTo(HaveOccurred())
+					Expect(result).To(Equal(controllerutil.OperationResultNone))
 				})
 			})
 		})
 	})
 	Describe("DeletePrometheusRuleDeployment", func() {
 		JustBeforeEach(func() {
-			err = pr.DeletePrometheusRuleDeployment(prometheusRuleRef)
+			deleted, err = pr.DeletePrometheusRuleDeployment(prometheusRuleRef)
 		})
 		When("The PrometheusRuleRef is not set", func() {
 			BeforeEach(func() {
@@ -179,6 +188,7 @@ var _ = Describe("CR Deployment Handling", func() {
 				})
 				It("does nothing", func() {
 					Expect(err).NotTo(HaveOccurred())
+					Expect(deleted).To(BeFalse())
 				})
 			})
 			When("the PrometheusRule Deployment exists", func() {
@@ -187,6 +197,7 @@ var _ = Describe("CR Deployment Handling", func() {
 				})
 				It("deletes the PrometheusRule", func() {
 					Expect(err).NotTo(HaveOccurred())
+					Expect(deleted).To(BeTrue())
 				})
 				When("the client failed to delete the deployment", func() {
 					BeforeEach(func() {
@@ -200,6 +211,216 @@ var _ = Describe("CR Deployment Handling", func() {
 		})
 	})
 
+	Describe("PrometheusRuleExists", func() {
+		var exists bool
+		BeforeEach(func() {
+			prometheusRuleRef = v1alpha1.NamespacedName{Name: "test", Namespace: "test"}
+			get.CalledTimes = 1
+		})
+		JustBeforeEach(func() {
+			exists, err = pr.PrometheusRuleExists(prometheusRuleRef)
+		})
+		When("the client failed to fetch the PrometheusRule", func() {
+			BeforeEach(func() {
+				get.ErrorResponse = consterror.ErrCustomError
+			})
+			It("returns the received error", func() {
+				Expect(err).To(Equal(consterror.ErrCustomError))
+				Expect(exists).To(BeFalse())
+			})
+		})
+		When("the PrometheusRule doesnt exist", func() {
+			BeforeEach(func() {
+				get.ErrorResponse = consterror.NotFoundErr
+			})
+			It("returns false", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(exists).To(BeFalse())
+			})
+		})
+		When("the PrometheusRule exists", func() {
+			It("returns true", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(exists).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("TemplateForPrometheusRuleResource", func() {
+		var (
+			percent           string
+			slo               v1alpha1.SloSpec
+			certificateExpiry *v1alpha1.CertificateExpirySpec
+			locations         *v1alpha1.ProbeLocationsSpec
+			windows           []maintenance.Window
+			template          monitoringv1.PrometheusRule
+		)
+		BeforeEach(func() {
+			percent = "0.995"
+			slo = v1alpha1.SloSpec{TargetAvailabilityPercent: "99.5"}
+			certificateExpiry = nil
+			locations = nil
+			windows = nil
+		})
+		JustBeforeEach(func() {
+			template = alert.TemplateForPrometheusRuleResource("https://fake-url", percent, slo, certificateExpiry, locations, windows, types.NamespacedName{Name: "test", Namespace: "test"})
+		})
+		When("the SLO doesn't define burn rate windows", func() {
+			It("renders the default four windows", func() {
+				Expect(template.Spec.Groups).To(HaveLen(1))
+				rules := template.Spec.Groups[0].Rules
+				Expect(rules).To(HaveLen(4))
+				Expect(rules[0].Labels).To(HaveKeyWithValue("long_window", "1h"))
+				Expect(rules[0].Labels).To(HaveKeyWithValue("severity", "critical"))
+				Expect(rules[3].Labels).To(HaveKeyWithValue("long_window", "3d"))
+				Expect(rules[3].Labels).To(HaveKeyWithValue("severity", "warning"))
+			})
+			It("counts every probe once regardless of the blackbox exporter replicas", func() {
+				expr := template.Spec.Groups[0].Rules[0].Expr.String()
+				Expect(expr).To(ContainSubstring(`1-(sum(avg without (instance,pod) (sum_over_time(probe_success{probe_url="https://fake-url"}[5m])))` +
+					`/ sum(avg without (instance,pod) (count_over_time(probe_success{probe_url="https://fake-url"}[5m]))))`))
+				// one probe every 30s, half of the 120 probes of the hour are required
+				Expect(expr).To(ContainSubstring(`sum(avg without (instance,pod) (count_over_time(probe_success{probe_url="https://fake-url"}[1h]))) > 60`))
+			})
+			It("counts the samples of the probes rather than the steps of a subquery", func() {
+				// a subquery repeats the last sample at every step, so probes which did not run would be counted
+				expr := template.Spec.Groups[0].Rules[0].Expr.String()
+				Expect(expr).NotTo(MatchRegexp(`\[[0-9]+[smhdw]:`))
+				Expect(expr).NotTo(ContainSubstring("max without"))
+			})
+		})
+		When("the SLO defines its own burn rate windows", func() {
+			BeforeEach(func() {
+				slo.Alerting = &v1alpha1.SloAlertingSpec{
+					BurnRateWindows: []v1alpha1.BurnRateWindow{
+						{LongWindow: "2h", ShortWindow: "10m", BurnRate: "10", For: "5m", Severity: v1alpha1.SeverityWarning},
+						{LongWindow: "12h", ShortWindow: "1h", BurnRate: "2", Severity: v1alpha1.SeverityInfo},
+					},
+				}
+			})
+			It("renders only the defined windows", func() {
+				rules := template.Spec.Groups[0].Rules
+				Expect(rules).To(HaveLen(2))
+				Expect(rules[0].Labels).To(HaveKeyWithValue("long_window", "2h"))
+				Expect(rules[0].Labels).To(HaveKeyWithValue("short_window", "10m"))
+				Expect(rules[0].Labels).To(HaveKeyWithValue("severity", "warning"))
+				Expect(rules[0].For).To(Equal(monitoringv1.Duration("5m")))
+				Expect(rules[0].Expr.String()).To(ContainSubstring("> (10*(1-0.995))"))
+				Expect(rules[1].Labels).To(HaveKeyWithValue("severity", "info"))
+				Expect(rules[1].For).To(BeEmpty())
+			})
+		})
+		When("the SLO defines a latency objective", func() {
+			BeforeEach(func() {
+				slo.Latency = &v1alpha1.LatencySloSpec{TargetPercent: "99", Threshold: "800ms"}
+			})
+			It("renders a second group alerting on the probe duration", func() {
+				Expect(template.Spec.Groups).To(HaveLen(2))
+				Expect(template.Spec.Groups[1].Name).To(Equal("SLOs-latency"))
+				rules := template.Spec.Groups[1].Rules
+				Expect(rules).To(HaveLen(4))
+				Expect(rules[0].Alert).To(Equal("test-LatencyBudgetBurn"))
+				Expect(rules[0].Expr.String()).To(ContainSubstring(`avg(avg without (instance,pod) (quantile_over_time(1-(14.40*(1-0.99)),probe_duration_seconds{probe_url="https://fake-url"}[5m]))) > 0.8`))
+				Expect(rules[0].Expr.String()).NotTo(MatchRegexp(`\[[0-9]+[smhdw]:`))
+			})
+			When("the latency objective is restricted to a phase", func() {
+				BeforeEach(func() {
+					slo.Latency.Phase = "connect"
+				})
+				It("uses the http phase durations", func() {
+					rules := template.Spec.Groups[1].Rules
+					Expect(rules[0].Expr.String()).To(ContainSubstring(`probe_http_duration_seconds{probe_url="https://fake-url",phase="connect"}[5m]))) > 0.8`))
+				})
+			})
+		})
+		When("the monitor is probed from several locations", func() {
+			BeforeEach(func() {
+				locations = &v1alpha1.ProbeLocationsSpec{
+					Locations: []v1alpha1.ProbeLocation{
+						{Name: "cluster", Type: v1alpha1.ProbeLocationInCluster},
+						{Name: "eu-west-1", Type: v1alpha1.ProbeLocationRemoteExporter, URL: "https://blackbox.eu-west-1.example.com"},
+						{Name: "us-east-1", Type: v1alpha1.ProbeLocationRemoteExporter, URL: "https://blackbox.us-east-1.example.com"},
+					},
+				}
+				slo.Latency = &v1alpha1.LatencySloSpec{TargetPercent: "99", Threshold: "800ms"}
+			})
+			It("evaluates every location on its own and fires on a majority of them", func() {
+				expr := template.Spec.Groups[0].Rules[0].Expr.String()
+				Expect(expr).To(HavePrefix("count(\n"))
+				Expect(expr).To(HaveSuffix("\n) >= 2"))
+				Expect(expr).To(ContainSubstring(`1-(sum by (location)(avg without (instance,pod) (sum_over_time(probe_success{probe_url="https://fake-url"}[5m])))`))
+				Expect(expr).To(ContainSubstring(`sum by (location)(avg without (instance,pod) (count_over_time(probe_success{probe_url="https://fake-url"}[1h]))) > 60`))
+				Expect(expr).NotTo(ContainSubstring("sum("))
+				Expect(template.Spec.Groups[0].Rules[0].Annotations["message"]).To(ContainSubstring("of 3 locations"))
+				Expect(template.Spec.Groups[1].Rules[0].Expr.String()).To(ContainSubstring("avg by (location)(avg without (instance,pod) (quantile_over_time("))
+				Expect(template.Spec.Groups[1].Rules[0].Expr.String()).To(HaveSuffix("\n) >= 2"))
+			})
+			When("the quorum is set", func() {
+				BeforeEach(func() {
+					quorum := int32(3)
+					locations.Quorum = &quorum
+				})
+				It("fires once the quorum of locations agrees", func() {
+					Expect(template.Spec.Groups[0].Rules[0].Expr.String()).To(HaveSuffix("\n) >= 3"))
+				})
+			})
+		})
+		When("maintenance windows are upcoming", func() {
+			BeforeEach(func() {
+				slo.Latency = &v1alpha1.LatencySloSpec{TargetPercent: "99", Threshold: "800ms"}
+				certificateExpiry = &v1alpha1.CertificateExpirySpec{WarningDays: 30, CriticalDays: 7}
+				windows = []maintenance.Window{
+					{Name: "upgrade", Start: time.Unix(1700000000, 0), End: time.Unix(1700003600, 0)},
+					{Name: "weekly", Start: time.Unix(1700600000, 0), End: time.Unix(1700607200, 0)},
+				}
+			})
+			It("suppresses the burn rate alerts during the windows", func() {
+				for _, group := range template.Spec.Groups[:2] {
+					Expect(group.Rules[0].Expr.String()).To(HavePrefix("(\n"))
+					Expect(group.Rules[0].Expr.String()).To(HaveSuffix("\n)\nunless on()\n(vector(time()) >= 1700000000 < 1700003600 or vector(time()) >= 1700600000 < 1700607200)"))
+				}
+				Expect(template.Spec.Groups[2].Rules[0].Expr.String()).NotTo(ContainSubstring("unless"))
+			})
+			It("renders an alert labeled with the window while it is in progress", func() {
+				Expect(template.Spec.Groups).To(HaveLen(4))
+				Expect(template.Spec.Groups[3].Name).To(Equal("maintenance"))
+				rules := template.Spec.Groups[3].Rules
+				Expect(rules).To(HaveLen(2))
+				Expect(rules[0].Alert).To(Equal("test-MaintenanceWindow"))
+				Expect(rules[0].Expr.String()).To(Equal("vector(time()) >= 1700000000 < 1700003600"))
+				Expect(rules[0].Labels).To(HaveKeyWithValue(alert.MaintenanceLabelName, "upgrade"))
+				Expect(rules[0].Labels).To(HaveKeyWithValue("probe_url", "https://fake-url"))
+				Expect(rules[1].Labels).To(HaveKeyWithValue(alert.MaintenanceLabelName, "weekly"))
+			})
+		})
+		When("the certificate expiry is monitored", func() {
+			BeforeEach(func() {
+				certificateExpiry = &v1alpha1.CertificateExpirySpec{WarningDays: 30, CriticalDays: 7}
+			})
+			It("renders a warning and a critical alert on the certificate expiry", func() {
+				Expect(template.Spec.Groups).To(HaveLen(2))
+				Expect(template.Spec.Groups[1].Name).To(Equal("certificate-expiry"))
+				rules := template.Spec.Groups[1].Rules
+				Expect(rules).To(HaveLen(2))
+				Expect(rules[0].Alert).To(Equal("test-CertificateExpiry"))
+				Expect(rules[0].Expr.String()).To(Equal(`(min(probe_ssl_earliest_cert_expiry{probe_url="https://fake-url"}) - time()) / 86400 < 30`))
+				Expect(rules[0].Labels).To(HaveKeyWithValue("severity", "warning"))
+				Expect(rules[0].Labels).To(HaveKeyWithValue("probe_url", "https://fake-url"))
+				Expect(rules[1].Expr.String()).To(HaveSuffix("< 7"))
+				Expect(rules[1].Labels).To(HaveKeyWithValue("severity", "critical"))
+			})
+			When("no SLO is defined", func() {
+				BeforeEach(func() {
+					percent = ""
+				})
+				It("renders only the certificate expiry alerts", func() {
+					Expect(template.Spec.Groups).To(HaveLen(1))
+					Expect(template.Spec.Groups[0].Name).To(Equal("certificate-expiry"))
+				})
+			})
+		})
+	})
+
 	Describe("NewPrometheusRule", func() {
 		It("should create a PrometheusRule with correct properties", func() {
 			client := mockClient
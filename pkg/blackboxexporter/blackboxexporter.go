@@ -1,12 +1,16 @@
 package blackboxexporter
 
 import (
+	"errors"
 	"fmt"
-	"reflect"
+	"maps"
+	"slices"
 
 	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	"github.com/openshift/route-monitor-operator/config"
 	"github.com/openshift/route-monitor-operator/pkg/consts/blackboxexporter"
 	"github.com/openshift/route-monitor-operator/pkg/util"
+	customerrors "github.com/openshift/route-monitor-operator/pkg/util/errors"
 	"github.com/openshift/route-monitor-operator/pkg/util/finalizer"
 
 	"context"
@@ -15,188 +19,428 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// pingGroupRangeSysctl lets unprivileged processes send icmp echo requests
+	pingGroupRangeSysctl = "net.ipv4.ping_group_range"
+	// fieldOwner is the field manager the blackbox exporter resources are applied as
+	fieldOwner = config.OperatorName
+)
+
 type BlackBoxExporter struct {
 	Client         client.Client
 	Log            logr.Logger
 	Ctx            context.Context
 	Image          string
 	NamespacedName types.NamespacedName
+	// Replicas is the number of blackbox exporter pods, every replica probes all targets
+	Replicas int32
+	// Pool is the BlackboxExporterPool the exporter is dedicated to, nil for the default exporter
+	// probing the monitors which do not select a pool
+	Pool *v1alpha1.BlackboxExporterPool
 }
 
-func New(client client.Client, log logr.Logger, ctx context.Context, blackBoxImage string, blackBoxExporterNamespace string) *BlackBoxExporter {
+func New(client client.Client, log logr.Logger, ctx context.Context, blackBoxImage string, blackBoxExporterNamespace string, replicas int32) *BlackBoxExporter {
 	blackboxNamespacedName := types.NamespacedName{Name: blackboxexporter.BlackBoxExporterName, Namespace: blackBoxExporterNamespace}
-	return &BlackBoxExporter{client, log, ctx, blackBoxImage, blackboxNamespacedName}
+	return &BlackBoxExporter{Client: client, Log: log, Ctx: ctx, Image: blackBoxImage, NamespacedName: blackboxNamespacedName, Replicas: replicas}
+}
+
+// NewForPool returns the exporter of the pool. The namespace and the replicas of the default exporter
+// are used unless the pool sets them
+func NewForPool(client client.Client, log logr.Logger, ctx context.Context, blackBoxImage string, blackBoxExporterNamespace string, replicas int32, pool *v1alpha1.BlackboxExporterPool) *BlackBoxExporter {
+	if pool.Spec.Replicas != nil {
+		replicas = *pool.Spec.Replicas
+	}
+	return &BlackBoxExporter{
+		Client:         client,
+		Log:            log,
+		Ctx:            ctx,
+		Image:          blackBoxImage,
+		NamespacedName: PoolExporter(*pool, blackBoxExporterNamespace),
+		Replicas:       replicas,
+		Pool:           pool,
+	}
+}
+
+// PoolExporter returns the name and namespace of the exporter resources of the pool
+func PoolExporter(pool v1alpha1.BlackboxExporterPool, blackBoxExporterNamespace string) types.NamespacedName {
+	namespace := pool.Spec.Namespace
+	if namespace == "" {
+		namespace = blackBoxExporterNamespace
+	}
+	return types.NamespacedName{Name: blackboxexporter.PoolExporterName(pool.Name), Namespace: namespace}
 }
 
 func (b *BlackBoxExporter) GetBlackBoxExporterNamespace() string {
 	return b.NamespacedName.Namespace
 }
 
-func (b *BlackBoxExporter) ShouldDeleteBlackBoxExporterResources() (blackboxexporter.ShouldDeleteBlackBoxExporter, error) {
-	objectsDependingOnExporter := []metav1.Object{}
-
-	routeMonitors := &v1alpha1.RouteMonitorList{}
-	if err := b.Client.List(b.Ctx, routeMonitors); err != nil {
-		return blackboxexporter.KeepBlackBoxExporter, err
+// GetBlackBoxExporterForPool returns the exporter probing the monitors selecting the pool, the default exporter
+// for monitors not selecting a pool. It returns ErrBlackboxExporterPoolNotFound if the pool does not exist
+func (b *BlackBoxExporter) GetBlackBoxExporterForPool(pool string) (types.NamespacedName, error) {
+	if pool == "" {
+		return b.NamespacedName, nil
+	}
+	blackboxExporterPool := v1alpha1.BlackboxExporterPool{}
+	if err := b.Client.Get(b.Ctx, types.NamespacedName{Name: pool}, &blackboxExporterPool); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return types.NamespacedName{}, customerrors.ErrBlackboxExporterPoolNotFound
+		}
+		return types.NamespacedName{}, err
 	}
-	for i := range routeMonitors.Items {
-		objectsDependingOnExporter = append(objectsDependingOnExporter, &routeMonitors.Items[i])
+	if finalizer.WasDeleteRequested(&blackboxExporterPool) {
+		return types.NamespacedName{}, customerrors.ErrBlackboxExporterPoolNotFound
 	}
+	return PoolExporter(blackboxExporterPool, b.NamespacedName.Namespace), nil
+}
 
-	clusterUrlMonitors := &v1alpha1.ClusterUrlMonitorList{}
-	if err := b.Client.List(b.Ctx, clusterUrlMonitors); err != nil {
-		return blackboxexporter.KeepBlackBoxExporter, err
+// ExporterIndexKey indexes the RouteMonitors and ClusterUrlMonitors by the name of the blackbox exporter probing them.
+// Monitors being deleted are not indexed, so the index holds the references keeping an exporter in use
+const ExporterIndexKey = "blackboxExporter"
+
+// IndexByExporter returns the name of the blackbox exporter probing the monitor, nothing once its deletion was requested
+func IndexByExporter(obj client.Object) []string {
+	if finalizer.WasDeleteRequested(obj) {
+		return nil
+	}
+	var pool string
+	switch monitor := obj.(type) {
+	case *v1alpha1.RouteMonitor:
+		pool = monitor.Spec.BlackboxExporterPool
+	case *v1alpha1.ClusterUrlMonitor:
+		pool = monitor.Spec.BlackboxExporterPool
+	default:
+		return nil
 	}
-	for i := range clusterUrlMonitors.Items {
-		objectsDependingOnExporter = append(objectsDependingOnExporter, &clusterUrlMonitors.Items[i])
+	if pool == "" {
+		return []string{blackboxexporter.BlackBoxExporterName}
 	}
-	b.Log.V(4).Info("Number of objects depending on BlackBoxExporter:", "amountOfObjects", len(objectsDependingOnExporter))
+	return []string{blackboxexporter.PoolExporterName(pool)}
+}
+
+// SetupIndexes registers the ExporterIndexKey index of the RouteMonitors and ClusterUrlMonitors
+func SetupIndexes(ctx context.Context, indexer client.FieldIndexer) error {
+	if err := indexer.IndexField(ctx, &v1alpha1.RouteMonitor{}, ExporterIndexKey, IndexByExporter); err != nil {
+		return err
+	}
+	return indexer.IndexField(ctx, &v1alpha1.ClusterUrlMonitor{}, ExporterIndexKey, IndexByExporter)
+}
 
-	if len(objectsDependingOnExporter) == 1 && finalizer.WasDeleteRequested(objectsDependingOnExporter[0]) {
-		b.Log.V(3).Info("Deleting BlackBoxResources: decided to clean BlackBoxExporter resources")
-		return blackboxexporter.DeleteBlackBoxExporter, nil
+// references returns the monitors referencing the exporter through the ExporterIndexKey index
+func (b *BlackBoxExporter) references() ([]v1alpha1.RouteMonitor, []v1alpha1.ClusterUrlMonitor, error) {
+	routeMonitors := &v1alpha1.RouteMonitorList{}
+	if err := b.Client.List(b.Ctx, routeMonitors, client.MatchingFields{ExporterIndexKey: b.NamespacedName.Name}); err != nil {
+		return nil, nil, err
 	}
-	return blackboxexporter.KeepBlackBoxExporter, nil
+	clusterUrlMonitors := &v1alpha1.ClusterUrlMonitorList{}
+	if err := b.Client.List(b.Ctx, clusterUrlMonitors, client.MatchingFields{ExporterIndexKey: b.NamespacedName.Name}); err != nil {
+		return nil, nil, err
+	}
+	return routeMonitors.Items, clusterUrlMonitors.Items, nil
 }
 
-func (b *BlackBoxExporter) EnsureBlackBoxExporterDeploymentExists() error {
-	resource := appsv1.Deployment{}
-	template, err := b.templateForBlackBoxExporterDeployment(b.Image, b.NamespacedName)
+// IsInUse returns whether a RouteMonitor or ClusterUrlMonitor which is not being deleted probes through the blackbox exporter.
+// The monitors referencing the exporter are counted through the ExporterIndexKey index, so deletions of several monitors
+// at once are seen by the next reconciliation, whichever monitor is removed last
+func (b *BlackBoxExporter) IsInUse() (bool, error) {
+	routeMonitors, clusterUrlMonitors, err := b.references()
 	if err != nil {
-		return fmt.Errorf("failed to create blackboxexporter template: %w", err)
+		return false, err
 	}
+	b.Log.V(4).Info("Number of monitors referencing the BlackBoxExporter", "routeMonitors", len(routeMonitors), "clusterUrlMonitors", len(clusterUrlMonitors))
+	return len(routeMonitors)+len(clusterUrlMonitors) > 0, nil
+}
 
-	// Does the resource already exist?
-	err = b.Client.Get(b.Ctx, b.NamespacedName, &resource)
+// blackBoxExporterConfig renders the blackbox exporter configuration from the monitors referencing the exporter
+// and returns it along with the credentials referenced by it
+func (b *BlackBoxExporter) blackBoxExporterConfig() (string, map[string][]byte, error) {
+	routeMonitors, clusterUrlMonitors, err := b.references()
 	if err != nil {
-		// If this is an unknown error
-		if !k8serrors.IsNotFound(err) {
-			// return unexpectedly
-			return err
-		}
-		// and create it
-		err = b.Client.Create(b.Ctx, &template)
-		if err != nil {
-			return err
-		}
+		return "", nil, err
+	}
 
-		return nil
+	probes := []monitorProbe{}
+	for _, routeMonitor := range routeMonitors {
+		probes = append(probes, monitorProbe{namespace: routeMonitor.Namespace, url: routeMonitor.Status.RouteURL, probe: routeMonitor.Spec.Probe, insecure: routeMonitor.Spec.InsecureSkipTLSVerify})
+	}
+	for _, clusterUrlMonitor := range clusterUrlMonitors {
+		probes = append(probes, monitorProbe{namespace: clusterUrlMonitor.Namespace, url: clusterUrlMonitor.Status.URL, probe: clusterUrlMonitor.Spec.Probe})
 	}
 
-	// Update the deployment if it's different than the template
-	if !reflect.DeepEqual(resource.Spec, template.Spec) {
-		resource.ResourceVersion = ""
-		resource.Spec = template.Spec
-		err = b.Client.Update(b.Ctx, &resource)
-		if err != nil {
-			return err
+	credentials := map[string][]byte{}
+	usableProbes := []monitorProbe{}
+	for _, m := range probes {
+		if m.probe.Auth != nil && m.probe.IsValid() {
+			monitorCredentials, err := ProbeCredentials(b.Ctx, b.Client, m.namespace, *m.probe.Auth)
+			if errors.Is(err, customerrors.ErrInvalidProbeSecret) {
+				// reported on the monitor itself, skipping it keeps the other probes working
+				continue
+			}
+			if err != nil {
+				return "", nil, err
+			}
+			maps.Copy(credentials, monitorCredentials)
 		}
+		usableProbes = append(usableProbes, m)
 	}
 
-	return nil
+	config, err := renderBlackBoxExporterConfig(usableProbes)
+	if err != nil {
+		return "", nil, err
+	}
+	return config, credentials, nil
 }
 
-func (b *BlackBoxExporter) EnsureBlackBoxExporterServiceExists() error {
-	resource := corev1.Service{}
-	populationFunc := func() corev1.Service { return templateForBlackBoxExporterService(b.NamespacedName) }
-
-	// Does the resource already exist?
-	if err := b.Client.Get(b.Ctx, b.NamespacedName, &resource); err != nil {
-		// If this is an unknown error
-		if !k8serrors.IsNotFound(err) {
-			// return unexpectedly
-			return err
-		}
-		// populate the resource with the template
-		resource := populationFunc()
-		// and create it
-		if err = b.Client.Create(b.Ctx, &resource); err != nil {
-			return err
+// blackBoxExporterPodConfig returns the configuration of the blackbox exporter pods from the RouteMonitorOperatorConfig,
+// the defaults are used if it does not exist or does not configure them. Pools are configured by their spec instead
+func (b *BlackBoxExporter) blackBoxExporterPodConfig() (v1alpha1.BlackboxExporterConfig, error) {
+	if b.Pool != nil {
+		return b.Pool.Spec.BlackboxExporterConfig, nil
+	}
+	operatorConfig := &v1alpha1.RouteMonitorOperatorConfig{}
+	if err := b.Client.Get(b.Ctx, types.NamespacedName{Name: v1alpha1.RouteMonitorOperatorConfigName}, operatorConfig); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return v1alpha1.BlackboxExporterConfig{}, nil
 		}
+		return v1alpha1.BlackboxExporterConfig{}, err
 	}
-	return nil
+	if operatorConfig.Spec.BlackboxExporter == nil {
+		return v1alpha1.BlackboxExporterConfig{}, nil
+	}
+	return *operatorConfig.Spec.BlackboxExporter, nil
 }
 
-func (b *BlackBoxExporter) EnsureBlackBoxExporterConfigMapExists() error {
-	resource := corev1.ConfigMap{}
-	populationFunc := func() corev1.ConfigMap { return templateForBlackBoxExporterConfigMap(b.NamespacedName) }
-
-	// Does the resource already exist?
-	if err := b.Client.Get(b.Ctx, b.NamespacedName, &resource); err != nil {
-		// If this is an unknown error
+// apply server-side applies the resource with forced ownership, so fields changed by others are reverted and fields
+// which are no longer part of the template are removed. existing is an empty object of the same kind, it tells whether
+// the resource was created, updated or left unchanged
+func (b *BlackBoxExporter) apply(resource, existing client.Object) (controllerutil.OperationResult, error) {
+	found := true
+	if err := b.Client.Get(b.Ctx, client.ObjectKeyFromObject(resource), existing); err != nil {
 		if !k8serrors.IsNotFound(err) {
-			// return unexpectedly
-			return err
+			return controllerutil.OperationResultNone, err
 		}
-		// populate the resource with the template
-		resource := populationFunc()
-		// and create it
-		return b.Client.Create(b.Ctx, &resource)
+		found = false
 	}
-	return nil
+	if err := b.Client.Patch(b.Ctx, resource, client.Apply, client.FieldOwner(fieldOwner), client.ForceOwnership); err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+	if !found {
+		return controllerutil.OperationResultCreated, nil
+	}
+	if resource.GetResourceVersion() != existing.GetResourceVersion() {
+		return controllerutil.OperationResultUpdated, nil
+	}
+	return controllerutil.OperationResultNone, nil
 }
 
-// deploymentForBlackBoxExporter returns a blackbox deployment
-func (b *BlackBoxExporter) templateForBlackBoxExporterDeployment(blackBoxImage string, blackBoxNamespacedName types.NamespacedName) (appsv1.Deployment, error) {
-	privateNLB, err := util.ClusterHasPrivateNLB(b.Client)
+// EnsureBlackBoxExporterDeploymentExists applies the Deployment. The pods are rolled when the configuration changes,
+// as its hash is part of the pod template
+func (b *BlackBoxExporter) EnsureBlackBoxExporterDeploymentExists(config string, podConfig v1alpha1.BlackboxExporterConfig) (controllerutil.OperationResult, error) {
+	template, err := b.templateForBlackBoxExporterDeployment(configHash(config), podConfig)
 	if err != nil {
-		return appsv1.Deployment{}, fmt.Errorf("failed to determine if cluster has private network LoadBalancer: %w", err)
+		return controllerutil.OperationResultNone, fmt.Errorf("failed to create blackboxexporter template: %w", err)
+	}
+	return b.apply(&template, &appsv1.Deployment{})
+}
+
+func (b *BlackBoxExporter) EnsureBlackBoxExporterServiceExists() (controllerutil.OperationResult, error) {
+	template := b.templateForBlackBoxExporterService()
+	return b.apply(&template, &corev1.Service{})
+}
+
+// EnsureBlackBoxExporterPodDisruptionBudgetExists keeps node drains from evicting all blackbox exporter replicas at once
+func (b *BlackBoxExporter) EnsureBlackBoxExporterPodDisruptionBudgetExists() (controllerutil.OperationResult, error) {
+	template := b.templateForBlackBoxExporterPodDisruptionBudget()
+	return b.apply(&template, &policyv1.PodDisruptionBudget{})
+}
+
+func (b *BlackBoxExporter) EnsureBlackBoxExporterConfigMapExists(config string) (controllerutil.OperationResult, error) {
+	template := b.templateForBlackBoxExporterConfigMap(config)
+	return b.apply(&template, &corev1.ConfigMap{})
+}
+
+// EnsureBlackBoxExporterCredentialsExist copies the probe credentials to the namespace of the blackbox exporter.
+// The operator may only write Secrets where it bound the credentials ClusterRole, so the Secret is only written
+// while a probe uses credentials, and removed once none does
+func (b *BlackBoxExporter) EnsureBlackBoxExporterCredentialsExist(credentials map[string][]byte) (controllerutil.OperationResult, error) {
+	if len(credentials) == 0 {
+		return controllerutil.OperationResultNone, b.EnsureBlackBoxExporterCredentialsAbsent()
 	}
+	roleBinding := b.templateForBlackBoxExporterCredentialsRoleBinding()
+	if _, err := b.apply(&roleBinding, &rbacv1.RoleBinding{}); err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+	template := b.templateForBlackBoxExporterCredentials(credentials)
+	return b.apply(&template, &corev1.Secret{})
+}
 
-	nodeLabel := "node-role.kubernetes.io/infra"
-	if util.IsClusterVersionHigherOrEqualThan(b.Client, "4.13") && privateNLB {
-		nodeLabel = "node-role.kubernetes.io/master"
+// objectMeta returns the metadata of the exporter resource with the name. The resources of a pool are labeled with
+// and owned by the pool, so they are removed along with it
+func (b *BlackBoxExporter) objectMeta(name string) metav1.ObjectMeta {
+	meta := metav1.ObjectMeta{
+		Name:      name,
+		Namespace: b.NamespacedName.Namespace,
+		Labels:    blackboxexporter.LabelsFor(b.NamespacedName.Name),
 	}
+	if b.Pool != nil {
+		meta.Labels[blackboxexporter.PoolLabel] = b.Pool.Name
+		meta.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(b.Pool, v1alpha1.GroupVersion.WithKind("BlackboxExporterPool"))}
+	}
+	return meta
+}
+
+// credentialsSecretName returns the Secret the probe credentials of the exporter are copied to
+func (b *BlackBoxExporter) credentialsSecretName() string {
+	if b.Pool == nil {
+		return blackboxexporter.CredentialsSecretName
+	}
+	return blackboxexporter.CredentialsSecretNameFor(b.NamespacedName.Name)
+}
 
-	labels := blackboxexporter.GenerateBlackBoxExporterLables()
+// deploymentForBlackBoxExporter returns a blackbox deployment
+func (b *BlackBoxExporter) templateForBlackBoxExporterDeployment(configHash string, podConfig v1alpha1.BlackboxExporterConfig) (appsv1.Deployment, error) {
+	labels := blackboxexporter.LabelsFor(b.NamespacedName.Name)
 	labelSelectors := metav1.LabelSelector{
 		MatchLabels: labels}
-	// hardcode the replicasize for no
-	// replicas := m.Spec.Size
-	var replicas int32 = 1
+	replicas := b.Replicas
+	if replicas < 1 {
+		replicas = blackboxexporter.DefaultReplicas
+	}
+	var credentialsFileMode int32 = 0400
+	credentialsOptional := true
+
+	// Spreads the replicas over the nodes, so draining a node does not stop all probes.
+	// It is only preferred, as clusters may have a single infra node
+	affinity := &corev1.Affinity{
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{
+				Weight: 100,
+				PodAffinityTerm: corev1.PodAffinityTerm{
+					LabelSelector: &labelSelectors,
+					TopologyKey:   corev1.LabelHostname,
+				},
+			}},
+		},
+	}
+	tolerations := []corev1.Toleration{}
+	if len(podConfig.NodeSelector) == 0 {
+		privateNLB, err := util.ClusterHasPrivateNLB(b.Client)
+		if err != nil {
+			return appsv1.Deployment{}, fmt.Errorf("failed to determine if cluster has private network LoadBalancer: %w", err)
+		}
 
-	dep := appsv1.Deployment{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      blackBoxNamespacedName.Name,
-			Namespace: blackBoxNamespacedName.Namespace,
-			Labels:    labels,
+		nodeLabel := "node-role.kubernetes.io/infra"
+		if util.IsClusterVersionHigherOrEqualThan(b.Client, "4.13") && privateNLB {
+			nodeLabel = "node-role.kubernetes.io/master"
+		}
+		affinity.NodeAffinity = &corev1.NodeAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.PreferredSchedulingTerm{{
+				Preference: corev1.NodeSelectorTerm{
+					MatchExpressions: []corev1.NodeSelectorRequirement{{
+						Key:      nodeLabel,
+						Operator: corev1.NodeSelectorOpExists,
+					}},
+				},
+				Weight: 1,
+			}},
+		}
+		tolerations = append(tolerations, corev1.Toleration{
+			Operator: corev1.TolerationOpExists,
+			Effect:   corev1.TaintEffectNoSchedule,
+			Key:      nodeLabel,
+		})
+	}
+	tolerations = append(tolerations, podConfig.Tolerations...)
+
+	serviceAccountName := podConfig.ServiceAccountName
+	if serviceAccountName == "" {
+		serviceAccountName = blackboxexporter.DefaultServiceAccountName
+	}
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(blackboxexporter.DefaultCPURequest),
+			corev1.ResourceMemory: resource.MustParse(blackboxexporter.DefaultMemoryRequest),
 		},
+	}
+	if podConfig.Resources != nil {
+		resources = *podConfig.Resources.DeepCopy()
+	}
+	allowPrivilegeEscalation := false
+	readOnlyRootFilesystem := true
+	// The icmp prober does not need the NET_RAW capability, see the ping_group_range sysctl
+	containerSecurityContext := &corev1.SecurityContext{
+		AllowPrivilegeEscalation: &allowPrivilegeEscalation,
+		ReadOnlyRootFilesystem:   &readOnlyRootFilesystem,
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{"ALL"},
+		},
+	}
+	if podConfig.ContainerSecurityContext != nil {
+		containerSecurityContext = podConfig.ContainerSecurityContext.DeepCopy()
+	}
+	podSecurityContext := &corev1.PodSecurityContext{
+		SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}
+	if podConfig.SecurityContext != nil {
+		podSecurityContext = podConfig.SecurityContext.DeepCopy()
+	}
+	// Allows the icmp prober to ping without the NET_RAW capability. The sysctl belongs to the network namespace,
+	// pods in the network of their nodes are rejected when they set it
+	if !podConfig.HostNetwork && !slices.ContainsFunc(podSecurityContext.Sysctls, func(sysctl corev1.Sysctl) bool { return sysctl.Name == pingGroupRangeSysctl }) {
+		podSecurityContext.Sysctls = append(podSecurityContext.Sysctls, corev1.Sysctl{
+			Name:  pingGroupRangeSysctl,
+			Value: "0 2147483647",
+		})
+	}
+
+	// The default cluster DNS policy does not resolve cluster names for pods in the network of their nodes
+	dnsPolicy := podConfig.DNSPolicy
+	if dnsPolicy == "" && podConfig.HostNetwork {
+		dnsPolicy = corev1.DNSClusterFirstWithHostNet
+	}
+
+	dep := appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: appsv1.SchemeGroupVersion.String(), Kind: "Deployment"},
+		ObjectMeta: b.objectMeta(b.NamespacedName.Name),
 		Spec: appsv1.DeploymentSpec{
 			Replicas: &replicas,
 			Selector: &labelSelectors,
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: labels,
+					Annotations: map[string]string{
+						blackboxexporter.ConfigHashAnnotation: configHash,
+					},
 				},
 				Spec: corev1.PodSpec{
-					Affinity: &corev1.Affinity{
-						NodeAffinity: &corev1.NodeAffinity{
-							PreferredDuringSchedulingIgnoredDuringExecution: []corev1.PreferredSchedulingTerm{{
-								Preference: corev1.NodeSelectorTerm{
-									MatchExpressions: []corev1.NodeSelectorRequirement{{
-										Key:      nodeLabel,
-										Operator: corev1.NodeSelectorOpExists,
-									}},
-								},
-								Weight: 1,
-							}},
-						},
-					},
-					Tolerations: []corev1.Toleration{{
-						Operator: corev1.TolerationOpExists,
-						Effect:   corev1.TaintEffectNoSchedule,
-						Key:      nodeLabel,
+					Affinity: affinity,
+					TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
+						MaxSkew:           1,
+						TopologyKey:       corev1.LabelTopologyZone,
+						WhenUnsatisfiable: corev1.ScheduleAnyway,
+						LabelSelector:     &labelSelectors,
 					}},
-					ServiceAccountName: "route-monitor-operator-system",
+					NodeSelector:       podConfig.NodeSelector,
+					Tolerations:        tolerations,
+					PriorityClassName:  podConfig.PriorityClassName,
+					ServiceAccountName: serviceAccountName,
+					ImagePullSecrets:   podConfig.ImagePullSecrets,
+					HostNetwork:        podConfig.HostNetwork,
+					DNSPolicy:          dnsPolicy,
+					DNSConfig:          podConfig.DNSConfig,
+					SecurityContext:    podSecurityContext,
 					Containers: []corev1.Container{{
-						Image: blackBoxImage,
+						Image: b.Image,
 						Name:  "blackbox-exporter",
 						Args: []string{
 							"--config.file=/config/blackbox.yaml",
@@ -205,12 +449,19 @@ func (b *BlackBoxExporter) templateForBlackBoxExporterDeployment(blackBoxImage s
 							ContainerPort: blackboxexporter.BlackBoxExporterPortNumber,
 							Name:          blackboxexporter.BlackBoxExporterPortName,
 						}},
+						Resources:       resources,
+						SecurityContext: containerSecurityContext,
 						VolumeMounts: []corev1.VolumeMount{
 							{
 								Name:      "blackbox-config",
 								ReadOnly:  true,
 								MountPath: "/config",
 							},
+							{
+								Name:      "blackbox-credentials",
+								ReadOnly:  true,
+								MountPath: blackboxexporter.CredentialsMountPath,
+							},
 						},
 					}},
 					Volumes: []corev1.Volume{
@@ -219,11 +470,24 @@ func (b *BlackBoxExporter) templateForBlackBoxExporterDeployment(blackBoxImage s
 							VolumeSource: corev1.VolumeSource{
 								ConfigMap: &corev1.ConfigMapVolumeSource{
 									LocalObjectReference: corev1.LocalObjectReference{
-										Name: blackBoxNamespacedName.Name,
+										Name: b.NamespacedName.Name,
 									},
 								},
 							},
 						},
+						{
+							Name: "blackbox-credentials",
+							VolumeSource: corev1.VolumeSource{
+								// Secret volumes are updated in place, so rotated credentials
+								// are picked up without restarting the pods. The Secret only
+								// exists while a probe uses credentials
+								Secret: &corev1.SecretVolumeSource{
+									SecretName:  b.credentialsSecretName(),
+									DefaultMode: &credentialsFileMode,
+									Optional:    &credentialsOptional,
+								},
+							},
+						},
 					},
 				},
 			},
@@ -233,17 +497,12 @@ func (b *BlackBoxExporter) templateForBlackBoxExporterDeployment(blackBoxImage s
 }
 
 // templateForBlackBoxExporterService returns a blackbox service
-func templateForBlackBoxExporterService(blackboxNamespacedName types.NamespacedName) corev1.Service {
-	labels := blackboxexporter.GenerateBlackBoxExporterLables()
-
+func (b *BlackBoxExporter) templateForBlackBoxExporterService() corev1.Service {
 	svc := corev1.Service{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      blackboxNamespacedName.Name,
-			Namespace: blackboxNamespacedName.Namespace,
-			Labels:    labels,
-		},
+		TypeMeta:   metav1.TypeMeta{APIVersion: corev1.SchemeGroupVersion.String(), Kind: "Service"},
+		ObjectMeta: b.objectMeta(b.NamespacedName.Name),
 		Spec: corev1.ServiceSpec{
-			Selector: labels,
+			Selector: blackboxexporter.LabelsFor(b.NamespacedName.Name),
 			Ports: []corev1.ServicePort{{
 				TargetPort: intstr.FromString(blackboxexporter.BlackBoxExporterPortName),
 				Port:       blackboxexporter.BlackBoxExporterPortNumber,
@@ -254,26 +513,24 @@ func templateForBlackBoxExporterService(blackboxNamespacedName types.NamespacedN
 	return svc
 }
 
-func templateForBlackBoxExporterConfigMap(blackboxNamespacedName types.NamespacedName) corev1.ConfigMap {
-	labels := blackboxexporter.GenerateBlackBoxExporterLables()
+// templateForBlackBoxExporterPodDisruptionBudget returns a PodDisruptionBudget letting only one replica be evicted at a time
+func (b *BlackBoxExporter) templateForBlackBoxExporterPodDisruptionBudget() policyv1.PodDisruptionBudget {
+	maxUnavailable := intstr.FromInt32(1)
 
-	cfg := `modules:
-  http_2xx:
-    prober: http
-    timeout: 15s
-  insecure_http_2xx:
-    prober: http
-    timeout: 15s
-    http:
-      tls_config:
-        insecure_skip_verify: true`
+	return policyv1.PodDisruptionBudget{
+		TypeMeta:   metav1.TypeMeta{APIVersion: policyv1.SchemeGroupVersion.String(), Kind: "PodDisruptionBudget"},
+		ObjectMeta: b.objectMeta(b.NamespacedName.Name),
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &maxUnavailable,
+			Selector:       &metav1.LabelSelector{MatchLabels: blackboxexporter.LabelsFor(b.NamespacedName.Name)},
+		},
+	}
+}
 
+func (b *BlackBoxExporter) templateForBlackBoxExporterConfigMap(cfg string) corev1.ConfigMap {
 	cm := corev1.ConfigMap{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      blackboxNamespacedName.Name,
-			Namespace: blackboxNamespacedName.Namespace,
-			Labels:    labels,
-		},
+		TypeMeta:   metav1.TypeMeta{APIVersion: corev1.SchemeGroupVersion.String(), Kind: "ConfigMap"},
+		ObjectMeta: b.objectMeta(b.NamespacedName.Name),
 		Data: map[string]string{
 			"blackbox.yaml": cfg,
 		},
@@ -281,6 +538,34 @@ func templateForBlackBoxExporterConfigMap(blackboxNamespacedName types.Namespace
 	return cm
 }
 
+func (b *BlackBoxExporter) templateForBlackBoxExporterCredentials(credentials map[string][]byte) corev1.Secret {
+	return corev1.Secret{
+		TypeMeta:   metav1.TypeMeta{APIVersion: corev1.SchemeGroupVersion.String(), Kind: "Secret"},
+		ObjectMeta: b.objectMeta(b.credentialsSecretName()),
+		Type:       corev1.SecretTypeOpaque,
+		Data:       credentials,
+	}
+}
+
+// templateForBlackBoxExporterCredentialsRoleBinding grants the operator to write the credentials Secret
+// in the namespace of the blackbox exporter
+func (b *BlackBoxExporter) templateForBlackBoxExporterCredentialsRoleBinding() rbacv1.RoleBinding {
+	return rbacv1.RoleBinding{
+		TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "RoleBinding"},
+		ObjectMeta: b.objectMeta(b.credentialsSecretName()),
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     blackboxexporter.CredentialsClusterRoleName,
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      config.OperatorServiceAccountName,
+			Namespace: config.OperatorNamespace,
+		}},
+	}
+}
+
 func (b *BlackBoxExporter) EnsureBlackBoxExporterDeploymentAbsent() error {
 	resource := &appsv1.Deployment{}
 
@@ -323,6 +608,23 @@ func (b *BlackBoxExporter) EnsureBlackBoxExporterServiceAbsent() error {
 	return nil
 }
 
+func (b *BlackBoxExporter) EnsureBlackBoxExporterPodDisruptionBudgetAbsent() error {
+	resource := &policyv1.PodDisruptionBudget{}
+
+	// Does the resource already exist?
+	err := b.Client.Get(b.Ctx, b.NamespacedName, resource)
+	if err != nil {
+		// If this is an unknown error
+		if !k8serrors.IsNotFound(err) {
+			// return unexpectedly
+			return err
+		}
+		// Resource doesn't exist, nothing to do
+		return nil
+	}
+	return b.Client.Delete(b.Ctx, resource)
+}
+
 func (b *BlackBoxExporter) EnsureBlackBoxExporterConfigMapAbsent() error {
 	resource := &corev1.ConfigMap{}
 
@@ -344,6 +646,40 @@ func (b *BlackBoxExporter) EnsureBlackBoxExporterConfigMapAbsent() error {
 	return nil
 }
 
+func (b *BlackBoxExporter) EnsureBlackBoxExporterCredentialsAbsent() error {
+	resource := &corev1.Secret{}
+	namespacedName := types.NamespacedName{Name: b.credentialsSecretName(), Namespace: b.NamespacedName.Namespace}
+
+	// Does the resource already exist?
+	err := b.Client.Get(b.Ctx, namespacedName, resource)
+	if err != nil {
+		// If this is an unknown error
+		if !k8serrors.IsNotFound(err) {
+			// return unexpectedly
+			return err
+		}
+		// Resource doesn't exist, nothing to do
+		return nil
+	}
+	return b.Client.Delete(b.Ctx, resource)
+}
+
+// EnsureBlackBoxExporterCredentialsRoleBindingAbsent removes the grant to write the credentials Secret,
+// it has to be removed after the Secret
+func (b *BlackBoxExporter) EnsureBlackBoxExporterCredentialsRoleBindingAbsent() error {
+	resource := &rbacv1.RoleBinding{}
+	namespacedName := types.NamespacedName{Name: b.credentialsSecretName(), Namespace: b.NamespacedName.Namespace}
+
+	err := b.Client.Get(b.Ctx, namespacedName, resource)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+	return b.Client.Delete(b.Ctx, resource)
+}
+
 func (b *BlackBoxExporter) EnsureBlackBoxExporterResourcesAbsent() error {
 	b.Log.V(2).Info("Entering EnsureBlackBoxExporterServiceAbsent")
 	if err := b.EnsureBlackBoxExporterServiceAbsent(); err != nil {
@@ -353,25 +689,68 @@ func (b *BlackBoxExporter) EnsureBlackBoxExporterResourcesAbsent() error {
 	if err := b.EnsureBlackBoxExporterDeploymentAbsent(); err != nil {
 		return err
 	}
+	b.Log.V(2).Info("Entering EnsureBlackBoxExporterPodDisruptionBudgetAbsent")
+	if err := b.EnsureBlackBoxExporterPodDisruptionBudgetAbsent(); err != nil {
+		return err
+	}
 	b.Log.V(2).Info("Entering EnsureBlackBoxExporterConfigMapAbsent")
 	if err := b.EnsureBlackBoxExporterConfigMapAbsent(); err != nil {
 		return err
 	}
+	b.Log.V(2).Info("Entering EnsureBlackBoxExporterCredentialsAbsent")
+	if err := b.EnsureBlackBoxExporterCredentialsAbsent(); err != nil {
+		return err
+	}
+	b.Log.V(2).Info("Entering EnsureBlackBoxExporterCredentialsRoleBindingAbsent")
+	if err := b.EnsureBlackBoxExporterCredentialsRoleBindingAbsent(); err != nil {
+		return err
+	}
 	return nil
 }
 
-func (b *BlackBoxExporter) EnsureBlackBoxExporterResourcesExist() error {
-	if err := b.EnsureBlackBoxExporterConfigMapExists(); err != nil {
-		return err
+// EnsureBlackBoxExporterResourcesExist returns created if the blackbox exporter was deployed,
+// and updated if one of its resources was created or changed
+func (b *BlackBoxExporter) EnsureBlackBoxExporterResourcesExist() (controllerutil.OperationResult, error) {
+	config, credentials, err := b.blackBoxExporterConfig()
+	if err != nil {
+		return controllerutil.OperationResultNone, err
 	}
-	if err := b.EnsureBlackBoxExporterDeploymentExists(); err != nil {
-		return err
+	podConfig, err := b.blackBoxExporterPodConfig()
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+	// The credentials have to exist before the configuration referencing them
+	credentialsResult, err := b.EnsureBlackBoxExporterCredentialsExist(credentials)
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+	configMapResult, err := b.EnsureBlackBoxExporterConfigMapExists(config)
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+	deploymentResult, err := b.EnsureBlackBoxExporterDeploymentExists(config, podConfig)
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+	podDisruptionBudgetResult, err := b.EnsureBlackBoxExporterPodDisruptionBudgetExists()
+	if err != nil {
+		return controllerutil.OperationResultNone, err
 	}
 	// Creating Service after because:
 	//
 	// A Service should not point to an empty target (Deployment)
-	if err := b.EnsureBlackBoxExporterServiceExists(); err != nil {
-		return err
+	serviceResult, err := b.EnsureBlackBoxExporterServiceExists()
+	if err != nil {
+		return controllerutil.OperationResultNone, err
 	}
-	return nil
+
+	if deploymentResult == controllerutil.OperationResultCreated {
+		return controllerutil.OperationResultCreated, nil
+	}
+	for _, result := range []controllerutil.OperationResult{credentialsResult, configMapResult, deploymentResult, podDisruptionBudgetResult, serviceResult} {
+		if result != controllerutil.OperationResultNone {
+			return controllerutil.OperationResultUpdated, nil
+		}
+	}
+	return controllerutil.OperationResultNone, nil
 }
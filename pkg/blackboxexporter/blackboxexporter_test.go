@@ -9,10 +9,20 @@ import (
 	"github.com/openshift/route-monitor-operator/api/v1alpha1"
 	"github.com/openshift/route-monitor-operator/pkg/consts/blackboxexporter"
 	consterror "github.com/openshift/route-monitor-operator/pkg/consts/test/error"
+	constinit "github.com/openshift/route-monitor-operator/pkg/consts/test/init"
+	customerrors "github.com/openshift/route-monitor-operator/pkg/util/errors"
 	clientmocks "github.com/openshift/route-monitor-operator/pkg/util/test/generated/mocks/client"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -34,8 +44,7 @@ var _ = Describe("Blackboxexporter", func() {
 
 		get    helper.MockHelper
 		delete helper.MockHelper
-		create helper.MockHelper
-		list   helper.MockHelper
+		patch  helper.MockHelper
 	)
 	BeforeEach(func() {
 		mockCtrl = gomock.NewController(GinkgoT())
@@ -43,14 +52,14 @@ var _ = Describe("Blackboxexporter", func() {
 
 		get = helper.MockHelper{}
 		delete = helper.MockHelper{}
-		create = helper.MockHelper{}
-		list = helper.MockHelper{}
+		patch = helper.MockHelper{}
 	})
 	JustBeforeEach(func() {
 		blackboxExporter = BlackBoxExporter{
-			Log:    logr.Discard(),
-			Client: mockClient,
-			Ctx:    context.Background(),
+			Log:            logr.Discard(),
+			Client:         mockClient,
+			Ctx:            context.Background(),
+			NamespacedName: types.NamespacedName{Name: blackboxexporter.BlackBoxExporterName, Namespace: "openshift-monitoring"},
 		}
 
 		mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).
@@ -61,9 +70,9 @@ var _ = Describe("Blackboxexporter", func() {
 			Return(delete.ErrorResponse).
 			Times(delete.CalledTimes)
 
-		mockClient.EXPECT().Create(gomock.Any(), gomock.Any()).
-			Return(create.ErrorResponse).
-			Times(create.CalledTimes)
+		mockClient.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(patch.ErrorResponse).
+			Times(patch.CalledTimes)
 	})
 	AfterEach(func() {
 		mockCtrl.Finish()
@@ -199,7 +208,7 @@ var _ = Describe("Blackboxexporter", func() {
 				get.ErrorResponse = consterror.NotFoundErr
 			})
 			It("should return the error", func() {
-				err := blackboxExporter.EnsureBlackBoxExporterDeploymentExists()
+				_, err := blackboxExporter.EnsureBlackBoxExporterDeploymentExists("", v1alpha1.BlackboxExporterConfig{})
 				Expect(err).To(HaveOccurred())
 			})
 		})
@@ -213,7 +222,7 @@ var _ = Describe("Blackboxexporter", func() {
 				get.ErrorResponse = consterror.ErrCustomError
 			})
 			It("should return an error", func() {
-				err := blackboxExporter.EnsureBlackBoxExporterDeploymentExists()
+				_, err := blackboxExporter.EnsureBlackBoxExporterDeploymentExists("", v1alpha1.BlackboxExporterConfig{})
 				Expect(err).To(HaveOccurred())
 			})
 		})
@@ -227,13 +236,14 @@ var _ = Describe("Blackboxexporter", func() {
 				mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(2, ingresscontroller).Times(1)
 				get.CalledTimes = 2
 				get.ErrorResponse = consterror.NotFoundErr
-				create.CalledTimes = 1
+				patch.CalledTimes = 1
 			})
-			It("should call `Get` successfully and `Create` the resource(deployment)", func() {
+			It("should call `Get` successfully and apply the resource(deployment)", func() {
 				// Act
-				err := blackboxExporter.EnsureBlackBoxExporterDeploymentExists()
+				result, err := blackboxExporter.EnsureBlackBoxExporterDeploymentExists("", v1alpha1.BlackboxExporterConfig{})
 				// Assert
 				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(controllerutil.OperationResultCreated))
 			})
 		})
 		When("the resource(deployment) Get fails unexpectedly", func() {
@@ -246,15 +256,15 @@ var _ = Describe("Blackboxexporter", func() {
 				get.CalledTimes = 2
 				get.ErrorResponse = consterror.ErrCustomError
 			})
-			It("should return the error and not call `Create`", func() {
+			It("should return the error and not apply the resource", func() {
 				// Act
-				err := blackboxExporter.EnsureBlackBoxExporterDeploymentExists()
+				_, err := blackboxExporter.EnsureBlackBoxExporterDeploymentExists("", v1alpha1.BlackboxExporterConfig{})
 				// Assert
 				Expect(err).To(HaveOccurred())
 				Expect(err).To(MatchError(consterror.ErrCustomError))
 			})
 		})
-		When("the resource(deployment) Create fails unexpectedly", func() {
+		When("the resource(deployment) apply fails unexpectedly", func() {
 			// Arrange
 			BeforeEach(func() {
 				infrastructure := testAWSInfrastructure()
@@ -263,11 +273,11 @@ var _ = Describe("Blackboxexporter", func() {
 				mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(2, ingresscontroller).Times(1)
 				get.CalledTimes = 2
 				get.ErrorResponse = consterror.NotFoundErr
-				create = helper.CustomErrorHappensOnce()
+				patch = helper.CustomErrorHappensOnce()
 			})
-			It("should call `Get` Successfully and call `Create` but return the error", func() {
+			It("should call `Get` Successfully and apply the resource but return the error", func() {
 				// Act
-				err := blackboxExporter.EnsureBlackBoxExporterDeploymentExists()
+				_, err := blackboxExporter.EnsureBlackBoxExporterDeploymentExists("", v1alpha1.BlackboxExporterConfig{})
 				// Assert
 				Expect(err).To(HaveOccurred())
 				Expect(err).To(MatchError(consterror.ErrCustomError))
@@ -280,12 +290,14 @@ var _ = Describe("Blackboxexporter", func() {
 			// Arrange
 			BeforeEach(func() {
 				get.CalledTimes = 1
+				patch.CalledTimes = 1
 			})
-			It("should call `Get` and not call `Create`", func() {
+			It("should apply it and report it unchanged", func() {
 				// Act
-				err := blackboxExporter.EnsureBlackBoxExporterServiceExists()
+				result, err := blackboxExporter.EnsureBlackBoxExporterServiceExists()
 				// Assert
 				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(controllerutil.OperationResultNone))
 
 			})
 		})
@@ -293,11 +305,11 @@ var _ = Describe("Blackboxexporter", func() {
 			// Arrange
 			BeforeEach(func() {
 				get = helper.NotFoundErrorHappensOnce()
-				create.CalledTimes = 1
+				patch.CalledTimes = 1
 			})
-			It("should call `Get` successfully and `Create` the resource(service)", func() {
+			It("should call `Get` successfully and apply the resource(service)", func() {
 				// Act
-				err := blackboxExporter.EnsureBlackBoxExporterServiceExists()
+				_, err := blackboxExporter.EnsureBlackBoxExporterServiceExists()
 				// Assert
 				Expect(err).NotTo(HaveOccurred())
 			})
@@ -307,151 +319,94 @@ var _ = Describe("Blackboxexporter", func() {
 			BeforeEach(func() {
 				get = helper.CustomErrorHappensOnce()
 			})
-			It("should return the error and not call `Create`", func() {
+			It("should return the error and not apply the resource", func() {
 				// Act
-				err := blackboxExporter.EnsureBlackBoxExporterServiceExists()
+				_, err := blackboxExporter.EnsureBlackBoxExporterServiceExists()
 				// Assert
 				Expect(err).To(HaveOccurred())
 				Expect(err).To(MatchError(consterror.ErrCustomError))
 			})
 		})
-		When("the resource(service) Create fails unexpectedly", func() {
+		When("the resource(service) apply fails unexpectedly", func() {
 			// Arrange
 			BeforeEach(func() {
 				get = helper.NotFoundErrorHappensOnce()
-				create = helper.CustomErrorHappensOnce()
+				patch = helper.CustomErrorHappensOnce()
 			})
-			It("should call `Get` Successfully and call `Create` but return the error", func() {
+			It("should call `Get` Successfully and apply the resource but return the error", func() {
 				// Act
-				err := blackboxExporter.EnsureBlackBoxExporterServiceExists()
+				_, err := blackboxExporter.EnsureBlackBoxExporterServiceExists()
 				// Assert
 				Expect(err).To(HaveOccurred())
 				Expect(err).To(MatchError(consterror.ErrCustomError))
 			})
 		})
 	})
-	Describe("ShouldDeleteBlackBoxExporterResources", func() {
-		var (
-			routeMonitor       v1alpha1.RouteMonitor
-			routeMonitors      v1alpha1.RouteMonitorList
-			clusterUrlMonitors v1alpha1.ClusterUrlMonitorList
-		)
-		JustBeforeEach(func() {
-			gomock.InOrder(
-				mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(list.ErrorResponse).SetArg(1, routeMonitors).Times(1),
-				mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(list.ErrorResponse).SetArg(1, clusterUrlMonitors).AnyTimes(),
-			)
-		})
-		BeforeEach(func() {
-			list.CalledTimes = 2
-		})
-
-		JustBeforeEach(func() {
-			routeMonitor.DeletionTimestamp = &metav1.Time{Time: time.Unix(0, 0)}
-		})
-		When("the `List` command fails", func() {
-			// Arrange
-			BeforeEach(func() {
-				list = helper.CustomErrorHappensOnce()
-			})
-			It("should fail with the List error", func() {
-				// Act
-				_, err := blackboxExporter.ShouldDeleteBlackBoxExporterResources()
-				// Assert
-				Expect(err).To(HaveOccurred())
-				Expect(err).To(MatchError(consterror.ErrCustomError))
-			})
-		})
-
-		When("there are many RouteMonitors", func() {
-			var (
-				routeMonitorSecond v1alpha1.RouteMonitor
-			)
-
-			BeforeEach(func() {
-				routeMonitor.ObjectMeta = metav1.ObjectMeta{
-					Name:      "fake-name",
-					Namespace: "fake-namespace",
-				}
-				routeMonitorSecond.ObjectMeta = metav1.ObjectMeta{
-					Name:      routeMonitor.Name + "-but-different",
-					Namespace: routeMonitor.Namespace,
-				}
-				routeMonitors.Items = []v1alpha1.RouteMonitor{
-					routeMonitor,
-					routeMonitorSecond,
-				}
-			})
-			It("should return 'false' for too many RouteMonitors", func() {
-				// Act
-				res, err := blackboxExporter.ShouldDeleteBlackBoxExporterResources()
-				// Assert
-				Expect(err).NotTo(HaveOccurred())
-				Expect(res).To(Equal(blackboxexporter.KeepBlackBoxExporter))
-
-			})
-		})
-
-		When("there is just one RouteMonitor, and it's being deleted", func() {
-			BeforeEach(func() {
-				clusterUrlMonitors.Items = []v1alpha1.ClusterUrlMonitor{}
-				routeMonitors.Items = []v1alpha1.RouteMonitor{
-					{
-						ObjectMeta: metav1.ObjectMeta{
-							Name:              "fake-route-monitor",
-							Namespace:         "fake-route-monitor-namespace",
-							DeletionTimestamp: &metav1.Time{Time: time.Unix(0, 0)},
-						},
-					},
-				}
-			})
-			// Arrange
-			It("should return 'true'", func() {
-				// Act
-				res, err := blackboxExporter.ShouldDeleteBlackBoxExporterResources()
-				// Assert
-				Expect(err).NotTo(HaveOccurred())
-				Expect(res).To(Equal(blackboxexporter.DeleteBlackBoxExporter))
-			})
-
-		})
-	})
-
 	Describe("New", func() {
 		It("should create a BlackBoxExporter with correct properties", func() {
-			bbe := New(mockClient, logr.Discard(), context.Background(), "test-image", "test-namespace")
+			bbe := New(mockClient, logr.Discard(), context.Background(), "test-image", "test-namespace", 3)
 			Expect(bbe.Client).To(Equal(mockClient))
 			Expect(bbe.Image).To(Equal("test-image"))
 			Expect(bbe.NamespacedName.Namespace).To(Equal("test-namespace"))
+			Expect(bbe.Replicas).To(Equal(int32(3)))
 		})
 	})
 
 	Describe("GetBlackBoxExporterNamespace", func() {
 		It("should return the correct namespace", func() {
-			bbe := New(mockClient, logr.Discard(), context.Background(), "test-image", "test-namespace")
+			bbe := New(mockClient, logr.Discard(), context.Background(), "test-image", "test-namespace", 3)
 			result := bbe.GetBlackBoxExporterNamespace()
 			Expect(result).To(Equal("test-namespace"))
 		})
 	})
 
 	Describe("EnsureBlackBoxExporterConfigMapExists", func() {
+		const config = "modules: {}"
+
 		When("the resource exists", func() {
 			BeforeEach(func() {
-				get.CalledTimes = 1
+				existing := corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{ResourceVersion: "1"}, Data: map[string]string{"blackbox.yaml": config}}
+				mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(2, existing).Times(1)
+				mockClient.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+						obj.SetResourceVersion("1")
+						return nil
+					}).Times(1)
+			})
+			It("should report the ConfigMap unchanged", func() {
+				result, err := blackboxExporter.EnsureBlackBoxExporterConfigMapExists(config)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(controllerutil.OperationResultNone))
 			})
-			It("should not create a new ConfigMap", func() {
-				err := blackboxExporter.EnsureBlackBoxExporterConfigMapExists()
+		})
+
+		When("the resource exists with an outdated configuration", func() {
+			BeforeEach(func() {
+				existing := corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{ResourceVersion: "1"}, Data: map[string]string{"blackbox.yaml": "modules: {outdated: {}}"}}
+				mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(2, existing).Times(1)
+				mockClient.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+						Expect(patch).To(Equal(client.Apply))
+						Expect(opts).To(ContainElements(client.ForceOwnership, client.FieldOwner("route-monitor-operator")))
+						Expect(obj.(*corev1.ConfigMap).Data).To(HaveKeyWithValue("blackbox.yaml", config))
+						obj.SetResourceVersion("2")
+						return nil
+					}).Times(1)
+			})
+			It("should apply the ConfigMap with forced ownership", func() {
+				result, err := blackboxExporter.EnsureBlackBoxExporterConfigMapExists(config)
 				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(controllerutil.OperationResultUpdated))
 			})
 		})
 
 		When("the resource does not exist", func() {
 			BeforeEach(func() {
 				get = helper.NotFoundErrorHappensOnce()
-				create.CalledTimes = 1
+				patch.CalledTimes = 1
 			})
 			It("should create a new ConfigMap", func() {
-				err := blackboxExporter.EnsureBlackBoxExporterConfigMapExists()
+				_, err := blackboxExporter.EnsureBlackBoxExporterConfigMapExists(config)
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
@@ -461,7 +416,7 @@ var _ = Describe("Blackboxexporter", func() {
 				get = helper.CustomErrorHappensOnce()
 			})
 			It("should return the error", func() {
-				err := blackboxExporter.EnsureBlackBoxExporterConfigMapExists()
+				_, err := blackboxExporter.EnsureBlackBoxExporterConfigMapExists(config)
 				Expect(err).To(HaveOccurred())
 				Expect(err).To(MatchError(consterror.ErrCustomError))
 			})
@@ -502,10 +457,188 @@ var _ = Describe("Blackboxexporter", func() {
 		})
 	})
 
+	Describe("ProbeCredentials", func() {
+		var (
+			auth        v1alpha1.ProbeAuthSpec
+			credentials map[string][]byte
+			err         error
+		)
+		BeforeEach(func() {
+			auth = v1alpha1.ProbeAuthSpec{Type: v1alpha1.ProbeAuthBasicAuth, SecretName: "creds"}
+		})
+		JustBeforeEach(func() {
+			credentials, err = ProbeCredentials(context.Background(), mockClient, "monitor-ns", auth)
+		})
+
+		When("the Secret does not exist", func() {
+			BeforeEach(func() {
+				get = helper.NotFoundErrorHappensOnce()
+			})
+			It("should return ErrInvalidProbeSecret", func() {
+				Expect(err).To(MatchError(customerrors.ErrInvalidProbeSecret))
+			})
+		})
+
+		When("the Secret misses a key", func() {
+			BeforeEach(func() {
+				secret := corev1.Secret{Data: map[string][]byte{"username": []byte("user")}}
+				mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(2, secret).Times(1)
+			})
+			It("should return ErrInvalidProbeSecret", func() {
+				Expect(err).To(MatchError(customerrors.ErrInvalidProbeSecret))
+			})
+		})
+
+		When("the Secret holds all keys", func() {
+			BeforeEach(func() {
+				secret := corev1.Secret{Data: map[string][]byte{"username": []byte("user"), "password": []byte("pass"), "unrelated": []byte("x")}}
+				mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(2, secret).Times(1)
+			})
+			It("should return the required keys prefixed with the namespace and Secret name", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(credentials).To(Equal(map[string][]byte{
+					"monitor-ns_creds_username": []byte("user"),
+					"monitor-ns_creds_password": []byte("pass"),
+				}))
+			})
+		})
+	})
+
+	Describe("EnsureBlackBoxExporterCredentialsExist", func() {
+		credentials := map[string][]byte{"monitor-ns_creds_token": []byte("rotated")}
+
+		When("the resource does not exist", func() {
+			BeforeEach(func() {
+				get = helper.MockHelper{CalledTimes: 2, ErrorResponse: consterror.NotFoundErr}
+				patch.CalledTimes = 2
+			})
+			It("should bind the credentials ClusterRole and create the Secret", func() {
+				_, err := blackboxExporter.EnsureBlackBoxExporterCredentialsExist(credentials)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the credentials were rotated", func() {
+			BeforeEach(func() {
+				existing := corev1.Secret{Data: map[string][]byte{"monitor-ns_creds_token": []byte("old")}}
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.AssignableToTypeOf(&rbacv1.RoleBinding{})).Return(nil),
+					mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.AssignableToTypeOf(&corev1.Secret{})).Return(nil).SetArg(2, existing),
+				)
+				mockClient.EXPECT().Patch(gomock.Any(), gomock.AssignableToTypeOf(&rbacv1.RoleBinding{}), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+				mockClient.EXPECT().Patch(gomock.Any(), gomock.AssignableToTypeOf(&corev1.Secret{}), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+						Expect(obj.(*corev1.Secret).Data).To(Equal(credentials))
+						return nil
+					}).Times(1)
+			})
+			It("should update the Secret", func() {
+				_, err := blackboxExporter.EnsureBlackBoxExporterCredentialsExist(credentials)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		When("no monitor references credentials", func() {
+			When("the Secret exists", func() {
+				BeforeEach(func() {
+					get.CalledTimes = 1
+					delete.CalledTimes = 1
+				})
+				It("should delete the Secret instead of applying it", func() {
+					result, err := blackboxExporter.EnsureBlackBoxExporterCredentialsExist(map[string][]byte{})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result).To(Equal(controllerutil.OperationResultNone))
+				})
+			})
+			When("the Secret does not exist", func() {
+				BeforeEach(func() {
+					get = helper.NotFoundErrorHappensOnce()
+				})
+				It("should not write any Secret", func() {
+					_, err := blackboxExporter.EnsureBlackBoxExporterCredentialsExist(map[string][]byte{})
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+		})
+
+		When("the exporter of a pool is outside the operator namespace", func() {
+			var applied []client.Object
+			BeforeEach(func() {
+				applied = nil
+				get = helper.MockHelper{CalledTimes: 2, ErrorResponse: consterror.NotFoundErr}
+				mockClient.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+						applied = append(applied, obj)
+						return nil
+					}).Times(2)
+			})
+			It("should grant the operator to write the Secret in the namespace of the pool", func() {
+				pool := &v1alpha1.BlackboxExporterPool{
+					ObjectMeta: metav1.ObjectMeta{Name: "external"},
+					Spec:       v1alpha1.BlackboxExporterPoolSpec{Namespace: "probes"},
+				}
+				exporter := NewForPool(mockClient, logr.Discard(), context.Background(), "", "openshift-route-monitor-operator", 1, pool)
+				_, err := exporter.EnsureBlackBoxExporterCredentialsExist(credentials)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(applied).To(HaveLen(2))
+				roleBinding := applied[0].(*rbacv1.RoleBinding)
+				Expect(roleBinding.Namespace).To(Equal("probes"))
+				Expect(roleBinding.OwnerReferences).To(HaveLen(1))
+				Expect(roleBinding.RoleRef).To(Equal(rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: blackboxexporter.CredentialsClusterRoleName}))
+				Expect(roleBinding.Subjects).To(ConsistOf(rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: "route-monitor-operator-system", Namespace: "openshift-route-monitor-operator"}))
+				secret := applied[1].(*corev1.Secret)
+				Expect(secret.Namespace).To(Equal("probes"))
+				Expect(secret.Name).To(Equal(blackboxexporter.CredentialsSecretNameFor(blackboxexporter.PoolExporterName("external"))))
+				Expect(secret.Data).To(Equal(credentials))
+			})
+		})
+	})
+
+	Describe("EnsureBlackBoxExporterPodDisruptionBudgetExists", func() {
+		When("the resource does not exist", func() {
+			BeforeEach(func() {
+				get = helper.NotFoundErrorHappensOnce()
+				mockClient.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+						pdb := obj.(*policyv1.PodDisruptionBudget)
+						Expect(pdb.Spec.MaxUnavailable.IntValue()).To(Equal(1))
+						Expect(pdb.Spec.Selector.MatchLabels).To(Equal(blackboxexporter.GenerateBlackBoxExporterLables()))
+						return nil
+					}).Times(1)
+			})
+			It("should let only one replica be evicted at a time", func() {
+				result, err := blackboxExporter.EnsureBlackBoxExporterPodDisruptionBudgetExists()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(controllerutil.OperationResultCreated))
+			})
+		})
+		When("the resource exists", func() {
+			BeforeEach(func() {
+				get.CalledTimes = 1
+				patch.CalledTimes = 1
+			})
+			It("should report it unchanged", func() {
+				result, err := blackboxExporter.EnsureBlackBoxExporterPodDisruptionBudgetExists()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(controllerutil.OperationResultNone))
+			})
+		})
+		When("Get fails with unexpected error", func() {
+			BeforeEach(func() {
+				get = helper.CustomErrorHappensOnce()
+			})
+			It("should return the error", func() {
+				_, err := blackboxExporter.EnsureBlackBoxExporterPodDisruptionBudgetExists()
+				Expect(err).To(MatchError(consterror.ErrCustomError))
+			})
+		})
+	})
+
 	Describe("EnsureBlackBoxExporterResourcesAbsent", func() {
 		BeforeEach(func() {
-			get.CalledTimes = 3
-			delete.CalledTimes = 3
+			get.CalledTimes = 6
+			delete.CalledTimes = 6
 		})
 		It("should delete all BlackBox Exporter resources", func() {
 			err := blackboxExporter.EnsureBlackBoxExporterResourcesAbsent()
@@ -552,8 +685,8 @@ var _ = Describe("BlackBoxExporter Deployment Template", func() {
 				mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(consterror.NotFoundErr),
 			)
-			mockClient.EXPECT().Create(gomock.Any(), gomock.Any()).
-				DoAndReturn(func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+			mockClient.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
 					dep, ok := obj.(*appsv1.Deployment)
 					Expect(ok).To(BeTrue())
 					createdDeployment = dep
@@ -562,8 +695,8 @@ var _ = Describe("BlackBoxExporter Deployment Template", func() {
 		})
 
 		It("should set correct spec fields", func() {
-			bbe := New(mockClient, logr.Discard(), context.Background(), "test-image:latest", "test-namespace")
-			err := bbe.EnsureBlackBoxExporterDeploymentExists()
+			bbe := New(mockClient, logr.Discard(), context.Background(), "test-image:latest", "test-namespace", 0)
+			_, err := bbe.EnsureBlackBoxExporterDeploymentExists("", v1alpha1.BlackboxExporterConfig{})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(createdDeployment).NotTo(BeNil())
 
@@ -574,16 +707,55 @@ var _ = Describe("BlackBoxExporter Deployment Template", func() {
 			Expect(podSpec.Containers[0].Image).To(Equal("test-image:latest"))
 			Expect(podSpec.Containers[0].Ports).To(HaveLen(1))
 			Expect(podSpec.Containers[0].Ports[0].ContainerPort).To(Equal(int32(blackboxexporter.BlackBoxExporterPortNumber)))
-			Expect(podSpec.Volumes).To(HaveLen(1))
+			Expect(podSpec.Volumes).To(HaveLen(2))
 			Expect(podSpec.Volumes[0].Name).To(Equal("blackbox-config"))
-			Expect(podSpec.Containers[0].VolumeMounts).To(HaveLen(1))
+			Expect(podSpec.Volumes[1].Secret.SecretName).To(Equal(blackboxexporter.CredentialsSecretName))
+			Expect(podSpec.Containers[0].VolumeMounts).To(HaveLen(2))
 			Expect(podSpec.Containers[0].VolumeMounts[0].MountPath).To(Equal("/config"))
+			Expect(podSpec.Containers[0].VolumeMounts[1].MountPath).To(Equal(blackboxexporter.CredentialsMountPath))
 
 			Expect(podSpec.Affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution).To(HaveLen(1))
 			pref := podSpec.Affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution[0]
 			Expect(pref.Preference.MatchExpressions[0].Key).To(Equal("node-role.kubernetes.io/infra"))
 			Expect(podSpec.Tolerations[0].Key).To(Equal("node-role.kubernetes.io/infra"))
 		})
+
+		It("should request resources and restrict the privileges by default", func() {
+			bbe := New(mockClient, logr.Discard(), context.Background(), "test-image:latest", "test-namespace", 0)
+			_, err := bbe.EnsureBlackBoxExporterDeploymentExists("", v1alpha1.BlackboxExporterConfig{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(createdDeployment).NotTo(BeNil())
+
+			podSpec := createdDeployment.Spec.Template.Spec
+			Expect(podSpec.Containers[0].Resources.Requests.Cpu().String()).To(Equal(blackboxexporter.DefaultCPURequest))
+			Expect(podSpec.Containers[0].Resources.Requests.Memory().String()).To(Equal(blackboxexporter.DefaultMemoryRequest))
+			Expect(podSpec.Containers[0].Resources.Limits).To(BeEmpty())
+			Expect(*podSpec.Containers[0].SecurityContext.AllowPrivilegeEscalation).To(BeFalse())
+			Expect(*podSpec.Containers[0].SecurityContext.ReadOnlyRootFilesystem).To(BeTrue())
+			Expect(podSpec.Containers[0].SecurityContext.Capabilities.Drop).To(ConsistOf(corev1.Capability("ALL")))
+			Expect(podSpec.SecurityContext.SeccompProfile.Type).To(Equal(corev1.SeccompProfileTypeRuntimeDefault))
+			Expect(podSpec.SecurityContext.Sysctls).To(ConsistOf(corev1.Sysctl{Name: "net.ipv4.ping_group_range", Value: "0 2147483647"}))
+			Expect(podSpec.NodeSelector).To(BeEmpty())
+			Expect(podSpec.PriorityClassName).To(BeEmpty())
+			Expect(podSpec.ImagePullSecrets).To(BeEmpty())
+		})
+
+		It("should spread the default number of replicas over the nodes and zones", func() {
+			bbe := New(mockClient, logr.Discard(), context.Background(), "test-image:latest", "test-namespace", 0)
+			_, err := bbe.EnsureBlackBoxExporterDeploymentExists("", v1alpha1.BlackboxExporterConfig{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(createdDeployment).NotTo(BeNil())
+
+			Expect(*createdDeployment.Spec.Replicas).To(Equal(int32(blackboxexporter.DefaultReplicas)))
+			podSpec := createdDeployment.Spec.Template.Spec
+			Expect(podSpec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution).To(HaveLen(1))
+			term := podSpec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution[0].PodAffinityTerm
+			Expect(term.TopologyKey).To(Equal(corev1.LabelHostname))
+			Expect(term.LabelSelector.MatchLabels).To(Equal(blackboxexporter.GenerateBlackBoxExporterLables()))
+			Expect(podSpec.TopologySpreadConstraints).To(HaveLen(1))
+			Expect(podSpec.TopologySpreadConstraints[0].TopologyKey).To(Equal(corev1.LabelTopologyZone))
+			Expect(podSpec.TopologySpreadConstraints[0].WhenUnsatisfiable).To(Equal(corev1.ScheduleAnyway))
+		})
 	})
 
 	When("creating on a private NLB cluster running 4.13+", func() {
@@ -610,8 +782,8 @@ var _ = Describe("BlackBoxExporter Deployment Template", func() {
 				mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(consterror.NotFoundErr),
 			)
-			mockClient.EXPECT().Create(gomock.Any(), gomock.Any()).
-				DoAndReturn(func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+			mockClient.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
 					dep, ok := obj.(*appsv1.Deployment)
 					Expect(ok).To(BeTrue())
 					createdDeployment = dep
@@ -620,11 +792,12 @@ var _ = Describe("BlackBoxExporter Deployment Template", func() {
 		})
 
 		It("should use master node affinity and set ServiceAccountName", func() {
-			bbe := New(mockClient, logr.Discard(), context.Background(), "test-image:latest", "test-namespace")
-			err := bbe.EnsureBlackBoxExporterDeploymentExists()
+			bbe := New(mockClient, logr.Discard(), context.Background(), "test-image:latest", "test-namespace", 3)
+			_, err := bbe.EnsureBlackBoxExporterDeploymentExists("", v1alpha1.BlackboxExporterConfig{})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(createdDeployment).NotTo(BeNil())
 
+			Expect(*createdDeployment.Spec.Replicas).To(Equal(int32(3)))
 			podSpec := createdDeployment.Spec.Template.Spec
 			Expect(podSpec.ServiceAccountName).To(Equal("route-monitor-operator-system"))
 
@@ -635,6 +808,156 @@ var _ = Describe("BlackBoxExporter Deployment Template", func() {
 	})
 })
 
+var _ = Describe("BlackBoxExporter references", func() {
+	var (
+		deleted  = metav1.Time{Time: time.Unix(0, 0)}
+		monitors []client.Object
+	)
+	routeMonitor := func(name, pool string, deletionTimestamp *metav1.Time) *v1alpha1.RouteMonitor {
+		return &v1alpha1.RouteMonitor{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "fake-namespace", DeletionTimestamp: deletionTimestamp, Finalizers: []string{"fake-finalizer"}},
+			Spec:       v1alpha1.RouteMonitorSpec{BlackboxExporterPool: pool},
+		}
+	}
+	isInUse := func(bbe *BlackBoxExporter) bool {
+		fakeClient := fake.NewClientBuilder().
+			WithScheme(constinit.Scheme).
+			WithObjects(monitors...).
+			WithIndex(&v1alpha1.RouteMonitor{}, ExporterIndexKey, IndexByExporter).
+			WithIndex(&v1alpha1.ClusterUrlMonitor{}, ExporterIndexKey, IndexByExporter).
+			Build()
+		bbe.Client = fakeClient
+		bbe.Ctx = context.Background()
+		bbe.Log = logr.Discard()
+		inUse, err := bbe.IsInUse()
+		Expect(err).NotTo(HaveOccurred())
+		return inUse
+	}
+
+	Describe("IndexByExporter", func() {
+		It("indexes monitors by the exporter of their pool", func() {
+			Expect(IndexByExporter(routeMonitor("default", "", nil))).To(Equal([]string{blackboxexporter.BlackBoxExporterName}))
+			Expect(IndexByExporter(&v1alpha1.ClusterUrlMonitor{Spec: v1alpha1.ClusterUrlMonitorSpec{BlackboxExporterPool: "tenant-a"}})).
+				To(Equal([]string{blackboxexporter.PoolExporterName("tenant-a")}))
+		})
+		It("does not index monitors being deleted", func() {
+			Expect(IndexByExporter(routeMonitor("deleted", "", &deleted))).To(BeEmpty())
+		})
+	})
+
+	Describe("IsInUse", func() {
+		When("several monitors are deleted at once", func() {
+			BeforeEach(func() {
+				monitors = []client.Object{routeMonitor("first", "", &deleted), routeMonitor("second", "", &deleted)}
+			})
+			It("is not in use", func() {
+				Expect(isInUse(New(nil, logr.Discard(), nil, "", "fake-namespace", 1))).To(BeFalse())
+			})
+		})
+		When("one monitor remains", func() {
+			BeforeEach(func() {
+				monitors = []client.Object{routeMonitor("first", "", &deleted), routeMonitor("second", "", nil)}
+			})
+			It("is in use", func() {
+				Expect(isInUse(New(nil, logr.Discard(), nil, "", "fake-namespace", 1))).To(BeTrue())
+			})
+		})
+		When("the remaining monitors select a pool", func() {
+			BeforeEach(func() {
+				monitors = []client.Object{routeMonitor("first", "tenant-a", nil)}
+			})
+			It("is not in use for the default exporter", func() {
+				Expect(isInUse(New(nil, logr.Discard(), nil, "", "fake-namespace", 1))).To(BeFalse())
+			})
+			It("is in use for the exporter of the pool", func() {
+				pool := &v1alpha1.BlackboxExporterPool{ObjectMeta: metav1.ObjectMeta{Name: "tenant-a"}}
+				Expect(isInUse(NewForPool(nil, logr.Discard(), nil, "", "fake-namespace", 1, pool))).To(BeTrue())
+			})
+		})
+	})
+})
+
+var _ = Describe("BlackBoxExporter Deployment configuration", func() {
+	var (
+		bbe       *BlackBoxExporter
+		podConfig v1alpha1.BlackboxExporterConfig
+		applied   *appsv1.Deployment
+		patchType client.Patch
+		patchOpts []client.PatchOption
+	)
+
+	BeforeEach(func() {
+		applied = nil
+		fakeClient := fake.NewClientBuilder().WithScheme(constinit.Scheme).WithInterceptorFuncs(interceptor.Funcs{
+			// the fake client cannot apply, the applied Deployment is captured instead
+			Patch: func(_ context.Context, _ client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				applied = obj.(*appsv1.Deployment)
+				patchType = patch
+				patchOpts = opts
+				return nil
+			},
+		}).Build()
+		bbe = New(fakeClient, logr.Discard(), context.Background(), "test-image:latest", "test-namespace", 0)
+		memoryLimit := resource.MustParse("128Mi")
+		runAsNonRoot := true
+		podConfig = v1alpha1.BlackboxExporterConfig{
+			Resources:          &corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceMemory: memoryLimit}},
+			NodeSelector:       map[string]string{"node-role.kubernetes.io/monitoring": ""},
+			Tolerations:        []corev1.Toleration{{Key: "monitoring", Operator: corev1.TolerationOpExists}},
+			PriorityClassName:  "system-cluster-critical",
+			SecurityContext:    &corev1.PodSecurityContext{RunAsNonRoot: &runAsNonRoot},
+			ImagePullSecrets:   []corev1.LocalObjectReference{{Name: "pull-secret"}},
+			ServiceAccountName: "blackbox-exporter",
+		}
+	})
+
+	When("the pods are configured", func() {
+		It("should place and secure the pods as configured", func() {
+			result, err := bbe.EnsureBlackBoxExporterDeploymentExists("", podConfig)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(controllerutil.OperationResultCreated))
+			Expect(applied).NotTo(BeNil())
+
+			podSpec := applied.Spec.Template.Spec
+			Expect(podSpec.NodeSelector).To(Equal(podConfig.NodeSelector))
+			Expect(podSpec.Affinity.NodeAffinity).To(BeNil())
+			Expect(podSpec.Tolerations).To(Equal(podConfig.Tolerations))
+			Expect(podSpec.PriorityClassName).To(Equal("system-cluster-critical"))
+			Expect(podSpec.ImagePullSecrets).To(Equal(podConfig.ImagePullSecrets))
+			Expect(podSpec.ServiceAccountName).To(Equal("blackbox-exporter"))
+			Expect(podSpec.Containers[0].Resources).To(Equal(*podConfig.Resources))
+			Expect(*podSpec.SecurityContext.RunAsNonRoot).To(BeTrue())
+			Expect(podSpec.SecurityContext.Sysctls).To(ConsistOf(corev1.Sysctl{Name: "net.ipv4.ping_group_range", Value: "0 2147483647"}))
+		})
+
+		It("should apply the whole Deployment with forced ownership", func() {
+			_, err := bbe.EnsureBlackBoxExporterDeploymentExists("", podConfig)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(patchType).To(Equal(client.Apply))
+			Expect(patchOpts).To(ContainElements(client.ForceOwnership, client.FieldOwner("route-monitor-operator")))
+			Expect(applied.APIVersion).To(Equal("apps/v1"))
+			Expect(applied.Kind).To(Equal("Deployment"))
+		})
+	})
+
+	When("the pods run in the network of their nodes", func() {
+		BeforeEach(func() {
+			podConfig.HostNetwork = true
+		})
+		It("should not set the ping_group_range sysctl of the node network", func() {
+			_, err := bbe.EnsureBlackBoxExporterDeploymentExists("", podConfig)
+			Expect(err).NotTo(HaveOccurred())
+
+			podSpec := applied.Spec.Template.Spec
+			Expect(podSpec.HostNetwork).To(BeTrue())
+			Expect(podSpec.DNSPolicy).To(Equal(corev1.DNSClusterFirstWithHostNet))
+			Expect(*podSpec.SecurityContext.RunAsNonRoot).To(BeTrue())
+			Expect(podSpec.SecurityContext.Sysctls).To(BeEmpty())
+		})
+	})
+})
+
 func testPrivateDefaultIC() operatorv1.IngressController {
 	ic := operatorv1.IngressController{
 		ObjectMeta: metav1.ObjectMeta{
@@ -0,0 +1,76 @@
+package blackboxexporter
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"path"
+
+	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	"github.com/openshift/route-monitor-operator/pkg/consts/blackboxexporter"
+	customerrors "github.com/openshift/route-monitor-operator/pkg/util/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// credentialKey returns the key a credential is stored with in the copied Secret.
+// Namespaces and Secret names cannot contain underscores, so the key is unique. Keys longer than
+// allowed in a Secret are hashed, the hash holds a single underscore and cannot clash with other keys
+func credentialKey(namespace, secretName, key string) string {
+	credentialKey := namespace + "_" + secretName + "_" + key
+	if len(credentialKey) <= validation.DNS1123SubdomainMaxLength {
+		return credentialKey
+	}
+	hash := sha256.Sum256([]byte(credentialKey))
+	return "credential_" + hex.EncodeToString(hash[:])[:32]
+}
+
+// credentialFile returns the path of a credential in the blackbox exporter pods
+func credentialFile(namespace, secretName, key string) string {
+	return path.Join(blackboxexporter.CredentialsMountPath, credentialKey(namespace, secretName, key))
+}
+
+// ProbeCredentials returns the credentials referenced by the probe of a monitor in the given namespace,
+// keyed as they are stored in the copied Secret. It returns ErrInvalidProbeSecret if the Secret
+// does not exist or misses a key required by the auth type
+func ProbeCredentials(ctx context.Context, c client.Client, namespace string, auth v1alpha1.ProbeAuthSpec) (map[string][]byte, error) {
+	secret := corev1.Secret{}
+	if err := c.Get(ctx, types.NamespacedName{Name: auth.SecretName, Namespace: namespace}, &secret); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, customerrors.ErrInvalidProbeSecret
+		}
+		return nil, err
+	}
+
+	credentials := map[string][]byte{}
+	for _, key := range auth.SecretKeys() {
+		value, ok := secret.Data[key]
+		if !ok || len(value) == 0 {
+			return nil, customerrors.ErrInvalidProbeSecret
+		}
+		credentials[credentialKey(namespace, auth.SecretName, key)] = value
+	}
+	return credentials, nil
+}
+
+// ValidateProbe verifies that a module can be generated for the probe of a monitor in the given namespace.
+// It returns ErrInvalidProbe or ErrInvalidProbeSecret if that is not the case
+func ValidateProbe(ctx context.Context, c client.Client, namespace string, probe v1alpha1.ProbeSpec) error {
+	if !probe.IsValid() {
+		return customerrors.ErrInvalidProbe
+	}
+	if probe.Auth == nil {
+		return nil
+	}
+	_, err := ProbeCredentials(ctx, c, namespace, *probe.Auth)
+	return err
+}
+
+// IsInvalidProbe returns whether the error returned by ValidateProbe has to be fixed in the monitor or its Secret
+func IsInvalidProbe(err error) bool {
+	return errors.Is(err, customerrors.ErrInvalidProbe) || errors.Is(err, customerrors.ErrInvalidProbeSecret)
+}
@@ -0,0 +1,254 @@
+package blackboxexporter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	"github.com/openshift/route-monitor-operator/pkg/consts/blackboxexporter"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	probeTimeout = "15s"
+
+	// defaultDNSServer is the cluster DNS service of OpenShift
+	defaultDNSServer = "dns-default.openshift-dns.svc:53"
+)
+
+// blackBoxConfig mirrors the parts of the blackbox exporter configuration file the operator generates
+type blackBoxConfig struct {
+	Modules map[string]blackBoxModule `json:"modules"`
+}
+
+type blackBoxModule struct {
+	Prober  string     `json:"prober"`
+	Timeout string     `json:"timeout"`
+	HTTP    *httpProbe `json:"http,omitempty"`
+	DNS     *dnsProbe  `json:"dns,omitempty"`
+	GRPC    *grpcProbe `json:"grpc,omitempty"`
+}
+
+type httpProbe struct {
+	Method                     string            `json:"method,omitempty"`
+	Headers                    map[string]string `json:"headers,omitempty"`
+	Body                       string            `json:"body,omitempty"`
+	ValidStatusCodes           []int             `json:"valid_status_codes,omitempty"`
+	FailIfBodyMatchesRegexp    []string          `json:"fail_if_body_matches_regexp,omitempty"`
+	FailIfBodyNotMatchesRegexp []string          `json:"fail_if_body_not_matches_regexp,omitempty"`
+	BearerTokenFile            string            `json:"bearer_token_file,omitempty"`
+	BasicAuth                  *basicAuth        `json:"basic_auth,omitempty"`
+	TLSConfig                  *tlsConfig        `json:"tls_config,omitempty"`
+}
+
+type basicAuth struct {
+	UsernameFile string `json:"username_file"`
+	PasswordFile string `json:"password_file"`
+}
+
+type tlsConfig struct {
+	InsecureSkipVerify bool   `json:"insecure_skip_verify,omitempty"`
+	CertFile           string `json:"cert_file,omitempty"`
+	KeyFile            string `json:"key_file,omitempty"`
+}
+
+type dnsProbe struct {
+	QueryName string `json:"query_name"`
+	QueryType string `json:"query_type,omitempty"`
+}
+
+type grpcProbe struct {
+	Service   string     `json:"service,omitempty"`
+	TLS       bool       `json:"tls,omitempty"`
+	TLSConfig *tlsConfig `json:"tls_config,omitempty"`
+}
+
+// monitorProbe holds the settings of a monitor which determine its module
+type monitorProbe struct {
+	namespace string
+	url       string
+	probe     v1alpha1.ProbeSpec
+	insecure  bool
+}
+
+// ModuleName returns the name of the blackbox module used by a monitor in the given namespace
+// probing the URL with the given probe settings
+func ModuleName(namespace, url string, probe v1alpha1.ProbeSpec, insecure bool) string {
+	name, _ := moduleFor(monitorProbe{namespace: namespace, url: url, probe: probe, insecure: insecure})
+	return name
+}
+
+// ProbeTarget returns the target passed to the blackbox exporter for the URL:
+// the URL itself for http, its host and port for tcp and grpc, its host for icmp,
+// and the DNS server for dns, as the queried name is part of the module.
+// The target of the probe replaces the host and port of the URL when it is set
+func ProbeTarget(probe v1alpha1.ProbeSpec, rawURL string) (string, error) {
+	switch probe.ProbeType() {
+	case v1alpha1.ProbeTypeTCP, v1alpha1.ProbeTypeGRPC, v1alpha1.ProbeTypeICMP:
+		if probe.Target != "" {
+			return probe.Target, nil
+		}
+	}
+	switch probe.ProbeType() {
+	case v1alpha1.ProbeTypeTCP, v1alpha1.ProbeTypeGRPC:
+		u, err := parseURL(rawURL)
+		if err != nil {
+			return "", err
+		}
+		port := u.Port()
+		if port == "" {
+			port = "443"
+			if u.Scheme == "http" {
+				port = "80"
+			}
+		}
+		return net.JoinHostPort(u.Hostname(), port), nil
+	case v1alpha1.ProbeTypeICMP:
+		u, err := parseURL(rawURL)
+		if err != nil {
+			return "", err
+		}
+		return u.Hostname(), nil
+	case v1alpha1.ProbeTypeDNS:
+		if probe.DNS != nil && probe.DNS.Server != "" {
+			return probe.DNS.Server, nil
+		}
+		return defaultDNSServer, nil
+	}
+	return rawURL, nil
+}
+
+// parseURL parses the URL of a monitor, which might miss its scheme
+func parseURL(rawURL string) (*url.URL, error) {
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("URL %q has no host", rawURL)
+	}
+	return u, nil
+}
+
+// moduleFor returns the module for the given probe settings.
+// Monitors without custom settings share the default modules, every other
+// module is named after the prober and the hash of its definition, so monitors
+// with the same settings share a module and changed settings result in a new module.
+// Credentials are referenced by file, so their rotation does not change the module
+func moduleFor(m monitorProbe) (string, blackBoxModule) {
+	module := blackBoxModule{Prober: m.probe.ProbeType(), Timeout: probeTimeout}
+	switch module.Prober {
+	case v1alpha1.ProbeTypeTCP:
+		return blackboxexporter.TCPModule, module
+	case v1alpha1.ProbeTypeICMP:
+		return blackboxexporter.ICMPModule, module
+	case v1alpha1.ProbeTypeDNS:
+		module.DNS = &dnsProbe{QueryName: m.probe.Target}
+		if u, err := parseURL(m.url); err == nil && module.DNS.QueryName == "" {
+			module.DNS.QueryName = u.Hostname()
+		}
+		if m.probe.DNS != nil {
+			module.DNS.QueryType = m.probe.DNS.QueryType
+		}
+	case v1alpha1.ProbeTypeGRPC:
+		module.GRPC = &grpcProbe{}
+		if u, err := parseURL(m.url); err == nil {
+			module.GRPC.TLS = u.Scheme == "https"
+		}
+		if m.insecure && module.GRPC.TLS {
+			module.GRPC.TLSConfig = &tlsConfig{InsecureSkipVerify: true}
+		}
+		if m.probe.GRPC != nil {
+			module.GRPC.Service = m.probe.GRPC.Service
+		}
+	default:
+		if m.insecure {
+			module.HTTP = &httpProbe{TLSConfig: &tlsConfig{InsecureSkipVerify: true}}
+		}
+		if m.probe.HTTP == nil && m.probe.Auth == nil {
+			if m.insecure {
+				return blackboxexporter.InsecureModule, module
+			}
+			return blackboxexporter.DefaultModule, module
+		}
+		if module.HTTP == nil {
+			module.HTTP = &httpProbe{}
+		}
+		setHTTPProbe(module.HTTP, m)
+	}
+
+	// the module only consists of strings, maps and slices, so marshalling cannot fail
+	// and json sorts the map keys, which keeps the hash stable
+	definition, _ := json.Marshal(module)
+	hash := sha256.Sum256(definition)
+	return module.Prober + "_" + hex.EncodeToString(hash[:])[:10], module
+}
+
+// setHTTPProbe applies the request settings and the credentials of the monitor to the http prober
+func setHTTPProbe(probe *httpProbe, m monitorProbe) {
+	if http := m.probe.HTTP; http != nil {
+		probe.Method = http.Method
+		probe.Headers = http.Headers
+		probe.Body = http.Body
+		probe.ValidStatusCodes = http.ValidStatusCodes
+		probe.FailIfBodyMatchesRegexp = http.FailIfBodyMatchesRegexp
+		probe.FailIfBodyNotMatchesRegexp = http.FailIfBodyNotMatchesRegexp
+	}
+	if auth := m.probe.Auth; auth != nil {
+		switch auth.Type {
+		case v1alpha1.ProbeAuthBearerToken:
+			probe.BearerTokenFile = credentialFile(m.namespace, auth.SecretName, "token")
+		case v1alpha1.ProbeAuthBasicAuth:
+			probe.BasicAuth = &basicAuth{
+				UsernameFile: credentialFile(m.namespace, auth.SecretName, "username"),
+				PasswordFile: credentialFile(m.namespace, auth.SecretName, "password"),
+			}
+		case v1alpha1.ProbeAuthClientCertificate:
+			if probe.TLSConfig == nil {
+				probe.TLSConfig = &tlsConfig{}
+			}
+			probe.TLSConfig.CertFile = credentialFile(m.namespace, auth.SecretName, "tls.crt")
+			probe.TLSConfig.KeyFile = credentialFile(m.namespace, auth.SecretName, "tls.key")
+		}
+	}
+}
+
+// renderBlackBoxExporterConfig returns the blackbox exporter configuration containing
+// the default modules and a module for every distinct probe configuration of the monitors.
+// Monitors with an invalid probe configuration are skipped, as a single module
+// failing to load would break the probes of all monitors. So are dns monitors whose
+// URL is not known yet, as the queried name is part of the module
+func renderBlackBoxExporterConfig(probes []monitorProbe) (string, error) {
+	cfg := blackBoxConfig{Modules: map[string]blackBoxModule{}}
+	probes = append([]monitorProbe{{}, {insecure: true}}, probes...)
+	for _, m := range probes {
+		if !m.probe.IsValid() {
+			continue
+		}
+		name, module := moduleFor(m)
+		if module.DNS != nil && module.DNS.QueryName == "" {
+			continue
+		}
+		cfg.Modules[name] = module
+	}
+
+	out, err := yaml.Marshal(cfg)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// configHash returns a short hash of the configuration, used to roll the pods when it changes
+func configHash(config string) string {
+	hash := sha256.Sum256([]byte(config))
+	return hex.EncodeToString(hash[:])[:16]
+}
@@ -0,0 +1,227 @@
+package blackboxexporter
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	"github.com/openshift/route-monitor-operator/pkg/consts/blackboxexporter"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+)
+
+var _ = Describe("Modules", func() {
+	var (
+		postProbe v1alpha1.ProbeSpec
+	)
+	BeforeEach(func() {
+		postProbe = v1alpha1.ProbeSpec{
+			HTTP: &v1alpha1.HTTPProbeSpec{
+				Method:                  "POST",
+				Headers:                 map[string]string{"Host": "health.example.com", "Content-Type": "application/json"},
+				Body:                    `{"check":"all"}`,
+				ValidStatusCodes:        []int{200, 401},
+				FailIfBodyMatchesRegexp: []string{"degraded"},
+			},
+		}
+	})
+
+	Describe("ModuleName", func() {
+		It("should use the default modules when the probe is not customized", func() {
+			Expect(ModuleName("ns", "", v1alpha1.ProbeSpec{}, false)).To(Equal(blackboxexporter.DefaultModule))
+			Expect(ModuleName("ns", "", v1alpha1.ProbeSpec{}, true)).To(Equal(blackboxexporter.InsecureModule))
+		})
+		It("should use the same module for the same settings", func() {
+			samePostProbe := *postProbe.HTTP
+			Expect(ModuleName("ns", "", postProbe, false)).To(Equal(ModuleName("ns", "", v1alpha1.ProbeSpec{HTTP: &samePostProbe}, false)))
+			Expect(ModuleName("ns", "", postProbe, false)).To(HavePrefix("http_"))
+		})
+		It("should use a different module for different settings", func() {
+			Expect(ModuleName("ns", "", postProbe, false)).NotTo(Equal(ModuleName("ns", "", postProbe, true)))
+			otherProbe := *postProbe.HTTP
+			otherProbe.ValidStatusCodes = []int{200}
+			Expect(ModuleName("ns", "", postProbe, false)).NotTo(Equal(ModuleName("ns", "", v1alpha1.ProbeSpec{HTTP: &otherProbe}, false)))
+		})
+	})
+
+	Describe("ProbeTarget", func() {
+		target := func(probe v1alpha1.ProbeSpec, url string) string {
+			target, err := ProbeTarget(probe, url)
+			Expect(err).NotTo(HaveOccurred())
+			return target
+		}
+		It("should probe the URL over http", func() {
+			Expect(target(v1alpha1.ProbeSpec{}, "https://app.example.com/health")).To(Equal("https://app.example.com/health"))
+		})
+		It("should probe the host and port over tcp and grpc", func() {
+			Expect(target(v1alpha1.ProbeSpec{Type: v1alpha1.ProbeTypeTCP}, "api.example.com:6443/livez")).To(Equal("api.example.com:6443"))
+			Expect(target(v1alpha1.ProbeSpec{Type: v1alpha1.ProbeTypeTCP}, "https://app.example.com/health")).To(Equal("app.example.com:443"))
+			Expect(target(v1alpha1.ProbeSpec{Type: v1alpha1.ProbeTypeTCP}, "http://app.example.com")).To(Equal("app.example.com:80"))
+			Expect(target(v1alpha1.ProbeSpec{Type: v1alpha1.ProbeTypeGRPC}, "grpc.example.com:9000")).To(Equal("grpc.example.com:9000"))
+		})
+		It("should probe the host over icmp", func() {
+			Expect(target(v1alpha1.ProbeSpec{Type: v1alpha1.ProbeTypeICMP}, "https://app.example.com/health")).To(Equal("app.example.com"))
+		})
+		It("should query the DNS server over dns", func() {
+			Expect(target(v1alpha1.ProbeSpec{Type: v1alpha1.ProbeTypeDNS}, "app.example.com")).To(Equal(defaultDNSServer))
+			Expect(target(v1alpha1.ProbeSpec{Type: v1alpha1.ProbeTypeDNS, DNS: &v1alpha1.DNSProbeSpec{Server: "8.8.8.8:53"}}, "app.example.com")).To(Equal("8.8.8.8:53"))
+		})
+		It("should probe the target of the probe instead of the URL", func() {
+			Expect(target(v1alpha1.ProbeSpec{Type: v1alpha1.ProbeTypeTCP, Target: "db.example.com:5432"}, "https://app.example.com/health")).To(Equal("db.example.com:5432"))
+			Expect(target(v1alpha1.ProbeSpec{Type: v1alpha1.ProbeTypeGRPC, Target: "10.0.0.5:9000"}, "https://app.example.com")).To(Equal("10.0.0.5:9000"))
+			Expect(target(v1alpha1.ProbeSpec{Type: v1alpha1.ProbeTypeICMP, Target: "gateway.example.com"}, "https://app.example.com")).To(Equal("gateway.example.com"))
+			Expect(target(v1alpha1.ProbeSpec{Type: v1alpha1.ProbeTypeDNS, Target: "db.example.com"}, "app.example.com")).To(Equal(defaultDNSServer))
+		})
+		It("should fail for a URL without a host", func() {
+			_, err := ProbeTarget(v1alpha1.ProbeSpec{Type: v1alpha1.ProbeTypeTCP}, "https:///health")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("renderBlackBoxExporterConfig", func() {
+		var (
+			probes []monitorProbe
+			cfg    blackBoxConfig
+		)
+		BeforeEach(func() {
+			probes = nil
+		})
+		JustBeforeEach(func() {
+			out, err := renderBlackBoxExporterConfig(probes)
+			Expect(err).NotTo(HaveOccurred())
+			cfg = blackBoxConfig{}
+			Expect(yaml.Unmarshal([]byte(out), &cfg)).To(Succeed())
+		})
+
+		When("no monitor customizes its probe", func() {
+			It("should only contain the default modules", func() {
+				Expect(cfg.Modules).To(HaveLen(2))
+				Expect(cfg.Modules).To(HaveKey(blackboxexporter.DefaultModule))
+				Expect(cfg.Modules[blackboxexporter.InsecureModule].HTTP.TLSConfig.InsecureSkipVerify).To(BeTrue())
+			})
+		})
+
+		When("monitors customize their probe", func() {
+			BeforeEach(func() {
+				probes = []monitorProbe{
+					{namespace: "ns", probe: postProbe},
+					{namespace: "ns", probe: postProbe, insecure: true},
+					{namespace: "other", probe: postProbe},
+				}
+			})
+			It("should generate a module per distinct configuration", func() {
+				Expect(cfg.Modules).To(HaveLen(4))
+				module := cfg.Modules[ModuleName("ns", "", postProbe, false)]
+				Expect(module.Prober).To(Equal("http"))
+				Expect(module.HTTP.Method).To(Equal("POST"))
+				Expect(module.HTTP.Headers).To(HaveKeyWithValue("Host", "health.example.com"))
+				Expect(module.HTTP.Body).To(Equal(`{"check":"all"}`))
+				Expect(module.HTTP.ValidStatusCodes).To(Equal([]int{200, 401}))
+				Expect(module.HTTP.FailIfBodyMatchesRegexp).To(Equal([]string{"degraded"}))
+				Expect(module.HTTP.TLSConfig).To(BeNil())
+				Expect(cfg.Modules[ModuleName("ns", "", postProbe, true)].HTTP.TLSConfig.InsecureSkipVerify).To(BeTrue())
+			})
+		})
+
+		When("monitors authenticate their probes", func() {
+			BeforeEach(func() {
+				probes = []monitorProbe{
+					{namespace: "ns", probe: v1alpha1.ProbeSpec{Auth: &v1alpha1.ProbeAuthSpec{Type: v1alpha1.ProbeAuthBearerToken, SecretName: "token"}}},
+					{namespace: "ns", probe: v1alpha1.ProbeSpec{Auth: &v1alpha1.ProbeAuthSpec{Type: v1alpha1.ProbeAuthBasicAuth, SecretName: "basic"}}},
+					{namespace: "ns", probe: v1alpha1.ProbeSpec{Auth: &v1alpha1.ProbeAuthSpec{Type: v1alpha1.ProbeAuthClientCertificate, SecretName: "cert"}}, insecure: true},
+				}
+			})
+			It("should reference the mounted credentials", func() {
+				Expect(cfg.Modules).To(HaveLen(5))
+				bearer := cfg.Modules[ModuleName("ns", "", probes[0].probe, false)]
+				Expect(bearer.HTTP.BearerTokenFile).To(Equal("/credentials/ns_token_token"))
+				basic := cfg.Modules[ModuleName("ns", "", probes[1].probe, false)]
+				Expect(basic.HTTP.BasicAuth).To(Equal(&basicAuth{UsernameFile: "/credentials/ns_basic_username", PasswordFile: "/credentials/ns_basic_password"}))
+				cert := cfg.Modules[ModuleName("ns", "", probes[2].probe, true)]
+				Expect(cert.HTTP.TLSConfig).To(Equal(&tlsConfig{InsecureSkipVerify: true, CertFile: "/credentials/ns_cert_tls.crt", KeyFile: "/credentials/ns_cert_tls.key"}))
+			})
+		})
+
+		When("the Secret name is too long for the key of the copied Secret", func() {
+			var secretName string
+			BeforeEach(func() {
+				secretName = strings.Repeat("a", 250)
+				probes = []monitorProbe{
+					{namespace: "ns", probe: v1alpha1.ProbeSpec{Auth: &v1alpha1.ProbeAuthSpec{Type: v1alpha1.ProbeAuthBasicAuth, SecretName: secretName}}},
+				}
+			})
+			It("should reference the credentials by a hashed key", func() {
+				usernameKey := credentialKey("ns", secretName, "username")
+				passwordKey := credentialKey("ns", secretName, "password")
+				Expect(validation.IsConfigMapKey(usernameKey)).To(BeEmpty())
+				Expect(usernameKey).NotTo(Equal(passwordKey))
+				Expect(usernameKey).NotTo(Equal(credentialKey("ns", strings.Repeat("a", 249), "username")))
+
+				basic := cfg.Modules[ModuleName("ns", "", probes[0].probe, false)]
+				Expect(basic.HTTP.BasicAuth).To(Equal(&basicAuth{UsernameFile: "/credentials/" + usernameKey, PasswordFile: "/credentials/" + passwordKey}))
+			})
+		})
+
+		When("monitors use other probe types", func() {
+			BeforeEach(func() {
+				probes = []monitorProbe{
+					{namespace: "ns", url: "api.example.com:6443", probe: v1alpha1.ProbeSpec{Type: v1alpha1.ProbeTypeTCP}},
+					{namespace: "ns", url: "app.example.com", probe: v1alpha1.ProbeSpec{Type: v1alpha1.ProbeTypeICMP}},
+					{namespace: "ns", url: "https://app.example.com/health", probe: v1alpha1.ProbeSpec{Type: v1alpha1.ProbeTypeDNS, DNS: &v1alpha1.DNSProbeSpec{QueryType: "AAAA"}}},
+					{namespace: "ns", url: "https://grpc.example.com", probe: v1alpha1.ProbeSpec{Type: v1alpha1.ProbeTypeGRPC, GRPC: &v1alpha1.GRPCProbeSpec{Service: "health"}}, insecure: true},
+				}
+			})
+			It("should generate a module per prober", func() {
+				Expect(cfg.Modules).To(HaveLen(6))
+				Expect(cfg.Modules[blackboxexporter.TCPModule].Prober).To(Equal("tcp"))
+				Expect(cfg.Modules[blackboxexporter.ICMPModule].Prober).To(Equal("icmp"))
+				dns := cfg.Modules[ModuleName("ns", probes[2].url, probes[2].probe, false)]
+				Expect(dns.Prober).To(Equal("dns"))
+				Expect(dns.DNS).To(Equal(&dnsProbe{QueryName: "app.example.com", QueryType: "AAAA"}))
+				grpc := cfg.Modules[ModuleName("ns", probes[3].url, probes[3].probe, true)]
+				Expect(grpc.Prober).To(Equal("grpc"))
+				Expect(grpc.GRPC).To(Equal(&grpcProbe{Service: "health", TLS: true, TLSConfig: &tlsConfig{InsecureSkipVerify: true}}))
+			})
+		})
+
+		When("a dns monitor has a target", func() {
+			BeforeEach(func() {
+				probes = []monitorProbe{{namespace: "ns", url: "https://app.example.com/health", probe: v1alpha1.ProbeSpec{Type: v1alpha1.ProbeTypeDNS, Target: "db.example.com"}}}
+			})
+			It("should query the target", func() {
+				dns := cfg.Modules[ModuleName("ns", probes[0].url, probes[0].probe, false)]
+				Expect(dns.DNS).To(Equal(&dnsProbe{QueryName: "db.example.com"}))
+			})
+		})
+
+		When("the URL of a dns monitor is not known yet", func() {
+			BeforeEach(func() {
+				probes = []monitorProbe{{namespace: "ns", probe: v1alpha1.ProbeSpec{Type: v1alpha1.ProbeTypeDNS}}}
+			})
+			It("should skip the monitor", func() {
+				Expect(cfg.Modules).To(HaveLen(2))
+			})
+		})
+
+		When("a monitor has settings of another probe type", func() {
+			BeforeEach(func() {
+				probes = []monitorProbe{{namespace: "ns", url: "app.example.com", probe: v1alpha1.ProbeSpec{Type: v1alpha1.ProbeTypeTCP, HTTP: postProbe.HTTP}}}
+			})
+			It("should skip the monitor", func() {
+				Expect(cfg.Modules).To(HaveLen(2))
+			})
+		})
+
+		When("a monitor has an invalid regular expression", func() {
+			BeforeEach(func() {
+				postProbe.HTTP.FailIfBodyNotMatchesRegexp = []string{"("}
+				probes = []monitorProbe{{namespace: "ns", probe: postProbe}}
+			})
+			It("should skip the monitor", func() {
+				Expect(cfg.Modules).To(HaveLen(2))
+			})
+		})
+	})
+})
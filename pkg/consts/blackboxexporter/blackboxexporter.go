@@ -4,17 +4,59 @@ const ( // All things related BlackBoxExporter
 	BlackBoxExporterName       = "blackbox-exporter"
 	BlackBoxExporterPortName   = "blackbox"
 	BlackBoxExporterPortNumber = 9115
+
+	// DefaultReplicas is the number of blackbox exporter pods unless configured otherwise,
+	// more than one keeps the targets probed while a node is drained
+	DefaultReplicas = 2
+
+	// DefaultServiceAccountName is the ServiceAccount the blackbox exporter pods run as unless configured otherwise
+	DefaultServiceAccountName = "route-monitor-operator-system"
+	// DefaultCPURequest and DefaultMemoryRequest are requested by the blackbox exporter container unless configured otherwise
+	DefaultCPURequest    = "10m"
+	DefaultMemoryRequest = "32Mi"
+
+	// ProbeInterval is how often the ServiceMonitors scrape the blackbox exporter, thus how often every target gets probed
+	ProbeInterval = "30s"
+
+	// ConfigHashAnnotation is set on the blackbox exporter pods so they are rolled when the probe modules change
+	ConfigHashAnnotation = "blackbox-exporter.routemonitoroperator.monitoring.openshift.io/config-hash"
+
+	// CredentialsSecretName is the Secret in the blackbox exporter namespace the probe credentials are copied to
+	CredentialsSecretName = BlackBoxExporterName + "-credentials"
+	// CredentialsClusterRoleName grants writing the credentials Secrets, the operator binds it in the namespace of
+	// every exporter probing with credentials
+	CredentialsClusterRoleName = "route-monitor-operator-blackbox-exporter-secrets-role"
+
+	// PoolLabel is set to the name of the BlackboxExporterPool on the resources of the exporter of the pool
+	PoolLabel = "blackbox-exporter.routemonitoroperator.monitoring.openshift.io/pool"
+	// CredentialsMountPath is where the credentials Secret is mounted in the blackbox exporter pods
+	CredentialsMountPath = "/credentials"
+
+	// DefaultModule and InsecureModule are used by monitors not customizing their probe
+	DefaultModule  = "http_2xx"
+	InsecureModule = "insecure_http_2xx"
+	// TCPModule and ICMPModule are used by all tcp and icmp monitors, as these probers have no settings
+	TCPModule  = "tcp_connect"
+	ICMPModule = "icmp"
 )
 
 // generateBlackBoxLables creates a set of common labels to most resources
 // this function is here in case we need more labels in the future
 func GenerateBlackBoxExporterLables() map[string]string {
-	return map[string]string{"app": BlackBoxExporterName}
+	return LabelsFor(BlackBoxExporterName)
 }
 
-type ShouldDeleteBlackBoxExporter bool
+// LabelsFor returns the labels selecting the pods of the blackbox exporter with the name
+func LabelsFor(name string) map[string]string {
+	return map[string]string{"app": name}
+}
 
-var (
-	KeepBlackBoxExporter   ShouldDeleteBlackBoxExporter = false
-	DeleteBlackBoxExporter ShouldDeleteBlackBoxExporter = true
-)
+// PoolExporterName returns the name of the exporter resources of a BlackboxExporterPool
+func PoolExporterName(pool string) string {
+	return BlackBoxExporterName + "-" + pool
+}
+
+// CredentialsSecretNameFor returns the Secret the probe credentials are copied to for the blackbox exporter with the name
+func CredentialsSecretNameFor(name string) string {
+	return name + "-credentials"
+}
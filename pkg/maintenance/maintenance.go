@@ -0,0 +1,101 @@
+// Package maintenance determines the occurrences of the maintenance windows of monitors, during which the alerts
+// of the monitors are suppressed
+package maintenance
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Window is a single occurrence of a maintenance window
+type Window struct {
+	Name  string
+	Start time.Time
+	End   time.Time
+}
+
+// IsActive returns whether the occurrence is in progress at the time
+func (w Window) IsActive(now time.Time) bool {
+	return !now.Before(w.Start) && now.Before(w.End)
+}
+
+// Validate returns an error for the first maintenance window whose schedule cannot be parsed
+func Validate(windows []v1alpha1.MaintenanceWindow) error {
+	for _, window := range windows {
+		if window.Schedule == "" {
+			continue
+		}
+		if _, err := ParseSchedule(window.Schedule); err != nil {
+			return fmt.Errorf("maintenance window %s: %w", window.Name, err)
+		}
+	}
+	return nil
+}
+
+// Upcoming returns the occurrences of the maintenance windows which have not ended yet: every one-off window until
+// its end, and the occurrence of every schedule which is in progress, or else the next one.
+// Windows with an invalid schedule are skipped, they are rejected by Validate
+func Upcoming(windows []v1alpha1.MaintenanceWindow, now time.Time) []Window {
+	upcoming := []Window{}
+	for _, window := range windows {
+		switch {
+		case window.Start != nil && window.End != nil:
+			if now.Before(window.End.Time) {
+				upcoming = append(upcoming, Window{Name: window.Name, Start: window.Start.Time, End: window.End.Time})
+			}
+		case window.Schedule != "" && window.Duration != nil && window.Duration.Duration > 0:
+			schedule, err := ParseSchedule(window.Schedule)
+			if err != nil {
+				continue
+			}
+			// the first start after the beginning of an occurrence which would still be in progress
+			start := schedule.Next(now.Add(-window.Duration.Duration))
+			if !start.IsZero() {
+				upcoming = append(upcoming, Window{Name: window.Name, Start: start, End: start.Add(window.Duration.Duration)})
+			}
+		}
+	}
+	return upcoming
+}
+
+// Active returns the occurrence in progress which ends last, nil if there is none
+func Active(windows []Window, now time.Time) *v1alpha1.ActiveMaintenanceWindow {
+	var active *Window
+	for i, window := range windows {
+		if window.IsActive(now) && (active == nil || window.End.After(active.End)) {
+			active = &windows[i]
+		}
+	}
+	if active == nil {
+		return nil
+	}
+	return &v1alpha1.ActiveMaintenanceWindow{
+		Name:  active.Name,
+		Start: metav1.NewTime(active.Start),
+		End:   metav1.NewTime(active.End),
+	}
+}
+
+// Equal returns whether both active windows are the same occurrence, regardless of the location of their times
+func Equal(a, b *v1alpha1.ActiveMaintenanceWindow) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Name == b.Name && a.Start.Equal(&b.Start) && a.End.Equal(&b.End)
+}
+
+// NextTransition returns how long it takes until the next occurrence starts or ends, zero if none is upcoming
+func NextTransition(windows []Window, now time.Time) time.Duration {
+	var next time.Duration
+	for _, window := range windows {
+		for _, transition := range []time.Time{window.Start, window.End} {
+			if wait := transition.Sub(now); wait > 0 && (next == 0 || wait < next) {
+				next = wait
+			}
+		}
+	}
+	return next
+}
@@ -0,0 +1,123 @@
+package maintenance_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	"github.com/openshift/route-monitor-operator/pkg/maintenance"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func at(value string) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	Expect(err).NotTo(HaveOccurred())
+	return t
+}
+
+var _ = Describe("Maintenance", func() {
+	Describe("ParseSchedule", func() {
+		It("rejects invalid expressions", func() {
+			for _, expression := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "* * * JAN *"} {
+				_, err := maintenance.ParseSchedule(expression)
+				Expect(err).To(HaveOccurred(), expression)
+			}
+		})
+		It("returns the next time matched by the schedule", func() {
+			for _, tt := range []struct{ expression, after, want string }{
+				// every minute
+				{"* * * * *", "2024-01-01T10:00:30Z", "2024-01-01T10:01:00Z"},
+				// later the same day and on the next day
+				{"30 2 * * *", "2024-01-01T01:00:00Z", "2024-01-01T02:30:00Z"},
+				{"30 2 * * *", "2024-01-01T02:30:00Z", "2024-01-02T02:30:00Z"},
+				// steps, lists and ranges
+				{"*/20 * * * *", "2024-01-01T10:41:00Z", "2024-01-01T11:00:00Z"},
+				{"0 1,3-4 * * *", "2024-01-01T01:00:00Z", "2024-01-01T03:00:00Z"},
+				// Saturdays, and Sundays as 7
+				{"0 2 * * 6", "2024-01-01T00:00:00Z", "2024-01-06T02:00:00Z"},
+				{"0 2 * * 7", "2024-01-01T00:00:00Z", "2024-01-07T02:00:00Z"},
+				// the 15th or Mondays
+				{"0 0 15 * 1", "2024-01-02T00:00:00Z", "2024-01-08T00:00:00Z"},
+				// leap days
+				{"0 0 29 2 *", "2024-03-01T00:00:00Z", "2028-02-29T00:00:00Z"},
+			} {
+				schedule, err := maintenance.ParseSchedule(tt.expression)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(schedule.Next(at(tt.after))).To(Equal(at(tt.want)), tt.expression)
+			}
+		})
+		It("never matches impossible days", func() {
+			schedule, err := maintenance.ParseSchedule("0 0 31 2 *")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(schedule.Next(at("2024-01-01T00:00:00Z")).IsZero()).To(BeTrue())
+		})
+	})
+
+	Describe("Validate", func() {
+		It("returns the window with an invalid schedule", func() {
+			windows := []v1alpha1.MaintenanceWindow{
+				{Name: "upgrade", Start: &metav1.Time{}, End: &metav1.Time{}},
+				{Name: "weekly", Schedule: "0 2 * * SAT", Duration: &metav1.Duration{Duration: time.Hour}},
+			}
+			err := maintenance.Validate(windows)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("weekly"))
+			Expect(maintenance.Validate(windows[:1])).To(Succeed())
+		})
+	})
+
+	Describe("Upcoming", func() {
+		var windows []v1alpha1.MaintenanceWindow
+		BeforeEach(func() {
+			windows = []v1alpha1.MaintenanceWindow{
+				{Name: "upgrade", Start: &metav1.Time{Time: at("2024-01-10T08:00:00Z")}, End: &metav1.Time{Time: at("2024-01-10T12:00:00Z")}},
+				{Name: "nightly", Schedule: "0 2 * * *", Duration: &metav1.Duration{Duration: 2 * time.Hour}},
+			}
+		})
+		It("returns the one-off windows and the next occurrences of the schedules", func() {
+			now := at("2024-01-10T07:00:00Z")
+			upcoming := maintenance.Upcoming(windows, now)
+			Expect(upcoming).To(Equal([]maintenance.Window{
+				{Name: "upgrade", Start: at("2024-01-10T08:00:00Z"), End: at("2024-01-10T12:00:00Z")},
+				{Name: "nightly", Start: at("2024-01-11T02:00:00Z"), End: at("2024-01-11T04:00:00Z")},
+			}))
+			Expect(maintenance.Active(upcoming, now)).To(BeNil())
+			Expect(maintenance.NextTransition(upcoming, now)).To(Equal(time.Hour))
+		})
+		It("returns the occurrence of a schedule in progress", func() {
+			now := at("2024-01-10T03:00:00Z")
+			upcoming := maintenance.Upcoming(windows, now)
+			Expect(upcoming).To(ContainElement(maintenance.Window{Name: "nightly", Start: at("2024-01-10T02:00:00Z"), End: at("2024-01-10T04:00:00Z")}))
+			Expect(maintenance.Active(upcoming, now)).To(Equal(&v1alpha1.ActiveMaintenanceWindow{
+				Name:  "nightly",
+				Start: metav1.NewTime(at("2024-01-10T02:00:00Z")),
+				End:   metav1.NewTime(at("2024-01-10T04:00:00Z")),
+			}))
+			Expect(maintenance.NextTransition(upcoming, now)).To(Equal(time.Hour))
+		})
+		It("drops one-off windows once they ended", func() {
+			now := at("2024-01-10T12:00:00Z")
+			upcoming := maintenance.Upcoming(windows, now)
+			Expect(upcoming).To(HaveLen(1))
+			Expect(upcoming[0].Name).To(Equal("nightly"))
+		})
+		It("reports the active window ending last", func() {
+			windows[1].Schedule = "0 9 * * *"
+			now := at("2024-01-10T10:00:00Z")
+			Expect(maintenance.Active(maintenance.Upcoming(windows, now), now).Name).To(Equal("upgrade"))
+		})
+	})
+
+	Describe("Equal", func() {
+		It("compares the times regardless of their location", func() {
+			a := &v1alpha1.ActiveMaintenanceWindow{Name: "upgrade", Start: metav1.NewTime(at("2024-01-10T08:00:00Z")), End: metav1.NewTime(at("2024-01-10T12:00:00Z"))}
+			b := a.DeepCopy()
+			b.Start = metav1.NewTime(b.Start.Local())
+			Expect(maintenance.Equal(a, b)).To(BeTrue())
+			Expect(maintenance.Equal(a, nil)).To(BeFalse())
+			Expect(maintenance.Equal(nil, nil)).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,154 @@
+package maintenance
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// searchDays bounds the search for the next time of a schedule, schedules matching less than every four years
+// such as the 31st of February never match
+const searchDays = 4*366 + 1
+
+// Schedule is a parsed cron expression with the five fields minute, hour, day of month, month and day of week
+type Schedule struct {
+	minutes, hours, daysOfMonth, months, daysOfWeek []bool
+	// restricted days of month and days of week match either of them, as in cron
+	anyDayOfMonth, anyDayOfWeek bool
+}
+
+// field is the range of the values of a cron field
+type field struct {
+	name     string
+	min, max int
+}
+
+var fields = []field{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	// 7 is Sunday as well
+	{name: "day of week", min: 0, max: 7},
+}
+
+// ParseSchedule parses a cron expression of numbers, *, ranges, lists and steps, e.g. "*/15 2-4 * * 1,3"
+func ParseSchedule(expression string) (*Schedule, error) {
+	parts := strings.Fields(expression)
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("schedule %q has %d fields, expected %d", expression, len(parts), len(fields))
+	}
+	values := make([][]bool, len(fields))
+	for i, part := range parts {
+		parsed, err := fields[i].parse(part)
+		if err != nil {
+			return nil, fmt.Errorf("schedule %q: %w", expression, err)
+		}
+		values[i] = parsed
+	}
+	if values[4][7] {
+		values[4][0] = true
+	}
+	return &Schedule{
+		minutes:       values[0],
+		hours:         values[1],
+		daysOfMonth:   values[2],
+		months:        values[3],
+		daysOfWeek:    values[4],
+		anyDayOfMonth: parts[2] == "*",
+		anyDayOfWeek:  parts[4] == "*",
+	}, nil
+}
+
+// parse returns which values of the field are matched by the comma separated list of the field
+func (f field) parse(list string) ([]bool, error) {
+	values := make([]bool, f.max+1)
+	for _, item := range strings.Split(list, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepPart); err != nil || step < 1 {
+				return nil, fmt.Errorf("invalid step %q of the %s", stepPart, f.name)
+			}
+		}
+		from, to := f.min, f.max
+		if rangePart != "*" {
+			fromPart, toPart, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if from, err = f.value(fromPart); err != nil {
+				return nil, err
+			}
+			to = from
+			if isRange {
+				if to, err = f.value(toPart); err != nil {
+					return nil, err
+				}
+			} else if hasStep {
+				to = f.max
+			}
+			if to < from {
+				return nil, fmt.Errorf("invalid range %q of the %s", rangePart, f.name)
+			}
+		}
+		for value := from; value <= to; value += step {
+			values[value] = true
+		}
+	}
+	return values, nil
+}
+
+// value parses a single value of the field
+func (f field) value(s string) (int, error) {
+	value, err := strconv.Atoi(s)
+	if err != nil || value < f.min || value > f.max {
+		return 0, fmt.Errorf("invalid %s %q, expected a number from %d to %d", f.name, s, f.min, f.max)
+	}
+	return value, nil
+}
+
+// Next returns the first time matched by the schedule after t, the zero time if there is none
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	for i := 0; i < searchDays; i, day = i+1, day.AddDate(0, 0, 1) {
+		if !s.matchesDay(day) {
+			continue
+		}
+		for hour := 0; hour < 24; hour++ {
+			if !s.hours[hour] {
+				continue
+			}
+			for minute := 0; minute < 60; minute++ {
+				if !s.minutes[minute] {
+					continue
+				}
+				if next := day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute); !next.Before(t) {
+					return next
+				}
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// matchesDay returns whether the schedule matches the day. When both the day of month and the day of week are
+// restricted, a day matching either of them matches, as in cron
+func (s *Schedule) matchesDay(day time.Time) bool {
+	if !s.months[int(day.Month())] {
+		return false
+	}
+	dayOfMonth := s.daysOfMonth[day.Day()]
+	dayOfWeek := s.daysOfWeek[int(day.Weekday())]
+	switch {
+	case s.anyDayOfMonth && s.anyDayOfWeek:
+		return true
+	case s.anyDayOfMonth:
+		return dayOfWeek
+	case s.anyDayOfWeek:
+		return dayOfMonth
+	default:
+		return dayOfMonth || dayOfWeek
+	}
+}
@@ -0,0 +1,170 @@
+package metrics
+
+import (
+	"sync"
+
+	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	utilreconcile "github.com/openshift/route-monitor-operator/pkg/util/reconcile"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// The kinds of the monitors
+const (
+	KindRouteMonitor      = "RouteMonitor"
+	KindClusterUrlMonitor = "ClusterUrlMonitor"
+)
+
+// The states of a monitor, derived from its Degraded and Ready conditions
+const (
+	StateReady   = "ready"
+	StateError   = "error"
+	StatePending = "pending"
+)
+
+// The outcomes of a reconcile step
+const (
+	OutcomeContinue = "continue"
+	OutcomeStop     = "stop"
+	OutcomeRequeue  = "requeue"
+	OutcomeError    = "error"
+)
+
+// The operations on the resources managed for the monitors
+const (
+	OperationCreated = "created"
+	OperationUpdated = "updated"
+	OperationDeleted = "deleted"
+)
+
+var (
+	monitorsDesc = prometheus.NewDesc(
+		"route_monitor_operator_monitors",
+		"Number of monitors by kind, type, state and the reason of the error",
+		[]string{"kind", "type", "state", "error"},
+		nil,
+	)
+
+	reconcileStepsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "route_monitor_operator_reconcile_steps_total",
+			Help: "Total number of reconcile steps by the kind of the monitor, the step and its outcome",
+		},
+		[]string{"kind", "step", "outcome"},
+	)
+
+	resourceOperationsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "route_monitor_operator_resource_operations_total",
+			Help: "Total number of ServiceMonitors and PrometheusRules created, updated and deleted",
+		},
+		[]string{"resource", "operation"},
+	)
+
+	blackBoxExporterEventsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "route_monitor_operator_blackbox_exporter_events_total",
+			Help: "Total number of times the blackbox exporter was deployed, updated and removed",
+		},
+		[]string{"event"},
+	)
+
+	monitors = &monitorCollector{states: map[monitorKey]monitorState{}}
+)
+
+func init() {
+	crmetrics.Registry.MustRegister(
+		monitors,
+		reconcileStepsTotal,
+		resourceOperationsTotal,
+		blackBoxExporterEventsTotal,
+	)
+}
+
+// monitorKey identifies a monitor across kinds
+type monitorKey struct {
+	kind string
+	name types.NamespacedName
+}
+
+// monitorState holds the labels a monitor is counted with
+type monitorState struct {
+	monitorType string
+	state       string
+	err         string
+}
+
+// monitorCollector counts the monitors by the state they were last reconciled in, so deleted monitors are
+// not counted anymore once they are removed
+type monitorCollector struct {
+	mutex  sync.Mutex
+	states map[monitorKey]monitorState
+}
+
+func (c *monitorCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- monitorsDesc
+}
+
+func (c *monitorCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mutex.Lock()
+	counts := map[[4]string]int{}
+	for key, state := range c.states {
+		counts[[4]string{key.kind, state.monitorType, state.state, state.err}]++
+	}
+	c.mutex.Unlock()
+
+	for labels, count := range counts {
+		ch <- prometheus.MustNewConstMetric(monitorsDesc, prometheus.GaugeValue, float64(count), labels[:]...)
+	}
+}
+
+// SetMonitorState records the state of the monitor derived from its conditions.
+// A degraded monitor is in the error state labeled with the reason of the Degraded condition,
+// e.g. InvalidSpec or ReconcileFailed
+func SetMonitorState(kind string, name types.NamespacedName, monitorType string, conditions []metav1.Condition) {
+	state := monitorState{monitorType: monitorType, state: StatePending}
+	if degraded := meta.FindStatusCondition(conditions, v1alpha1.ConditionDegraded); degraded != nil && degraded.Status == metav1.ConditionTrue {
+		state.state = StateError
+		state.err = degraded.Reason
+	} else if meta.IsStatusConditionTrue(conditions, v1alpha1.ConditionReady) {
+		state.state = StateReady
+	}
+
+	monitors.mutex.Lock()
+	defer monitors.mutex.Unlock()
+	monitors.states[monitorKey{kind: kind, name: name}] = state
+}
+
+// DeleteMonitorState stops counting a monitor which no longer exists
+func DeleteMonitorState(kind string, name types.NamespacedName) {
+	monitors.mutex.Lock()
+	defer monitors.mutex.Unlock()
+	delete(monitors.states, monitorKey{kind: kind, name: name})
+}
+
+// RecordReconcileStep records the outcome of a reconcile step, e.g. EnsureServiceMonitorExists
+func RecordReconcileStep(kind, step string, res utilreconcile.Result, err error) {
+	outcome := OutcomeContinue
+	switch {
+	case err != nil:
+		outcome = OutcomeError
+	case res.Requeue || res.RequeueAfter > 0:
+		outcome = OutcomeRequeue
+	case res.ShouldStop():
+		outcome = OutcomeStop
+	}
+	reconcileStepsTotal.WithLabelValues(kind, step, outcome).Inc()
+}
+
+// RecordResourceOperation records the creation, update or deletion of a ServiceMonitor or PrometheusRule
+func RecordResourceOperation(resource, operation string) {
+	resourceOperationsTotal.WithLabelValues(resource, operation).Inc()
+}
+
+// RecordBlackBoxExporterEvent records the deployment, update or removal of the blackbox exporter
+func RecordBlackBoxExporterEvent(event string) {
+	blackBoxExporterEventsTotal.WithLabelValues(event).Inc()
+}
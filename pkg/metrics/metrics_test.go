@@ -0,0 +1,108 @@
+package metrics
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	utilreconcile "github.com/openshift/route-monitor-operator/pkg/util/reconcile"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestMonitorState(t *testing.T) {
+	ready := []metav1.Condition{
+		{Type: v1alpha1.ConditionDegraded, Status: metav1.ConditionFalse, Reason: v1alpha1.ReasonAsExpected},
+		{Type: v1alpha1.ConditionReady, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReconciled},
+	}
+	invalid := []metav1.Condition{
+		{Type: v1alpha1.ConditionDegraded, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonInvalidSpec},
+		{Type: v1alpha1.ConditionReady, Status: metav1.ConditionFalse, Reason: v1alpha1.ReasonInvalidSpec},
+	}
+
+	SetMonitorState(KindRouteMonitor, types.NamespacedName{Name: "ready", Namespace: "a"}, v1alpha1.ServiceMonitorTypeCoreOS, ready)
+	SetMonitorState(KindRouteMonitor, types.NamespacedName{Name: "ready", Namespace: "b"}, v1alpha1.ServiceMonitorTypeCoreOS, ready)
+	SetMonitorState(KindRouteMonitor, types.NamespacedName{Name: "invalid", Namespace: "a"}, v1alpha1.ServiceMonitorTypeCoreOS, invalid)
+	SetMonitorState(KindClusterUrlMonitor, types.NamespacedName{Name: "new", Namespace: "a"}, string(v1alpha1.ClusterDomainRefHCP), nil)
+	// the state of a monitor is replaced when it is reconciled again
+	SetMonitorState(KindClusterUrlMonitor, types.NamespacedName{Name: "deleted", Namespace: "a"}, string(v1alpha1.ClusterDomainRefInfra), invalid)
+	SetMonitorState(KindClusterUrlMonitor, types.NamespacedName{Name: "deleted", Namespace: "a"}, string(v1alpha1.ClusterDomainRefInfra), ready)
+	DeleteMonitorState(KindClusterUrlMonitor, types.NamespacedName{Name: "deleted", Namespace: "a"})
+
+	expected := `
+# HELP route_monitor_operator_monitors Number of monitors by kind, type, state and the reason of the error
+# TYPE route_monitor_operator_monitors gauge
+route_monitor_operator_monitors{error="",kind="ClusterUrlMonitor",state="pending",type="hcp"} 1
+route_monitor_operator_monitors{error="",kind="RouteMonitor",state="ready",type="monitoring.coreos.com"} 2
+route_monitor_operator_monitors{error="InvalidSpec",kind="RouteMonitor",state="error",type="monitoring.coreos.com"} 1
+`
+	if err := testutil.CollectAndCompare(monitors, strings.NewReader(expected)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRecordReconcileStep(t *testing.T) {
+	tests := []struct {
+		name        string
+		res         utilreconcile.Result
+		err         error
+		wantOutcome string
+	}{
+		{
+			name:        "continues",
+			res:         utilreconcile.ContinueOperation(),
+			wantOutcome: OutcomeContinue,
+		},
+		{
+			name:        "stops after a status update",
+			res:         utilreconcile.StopOperation(),
+			wantOutcome: OutcomeStop,
+		},
+		{
+			name:        "requeues after an interval",
+			res:         utilreconcile.Result{Continue: true, RequeueAfter: time.Minute},
+			wantOutcome: OutcomeRequeue,
+		},
+		{
+			name:        "fails",
+			res:         utilreconcile.RequeueOperation(),
+			err:         errors.New("failed"),
+			wantOutcome: OutcomeError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := testutil.ToFloat64(reconcileStepsTotal.WithLabelValues(KindRouteMonitor, "EnsureServiceMonitorExists", tt.wantOutcome))
+			RecordReconcileStep(KindRouteMonitor, "EnsureServiceMonitorExists", tt.res, tt.err)
+			after := testutil.ToFloat64(reconcileStepsTotal.WithLabelValues(KindRouteMonitor, "EnsureServiceMonitorExists", tt.wantOutcome))
+
+			if after != before+1 {
+				t.Errorf("expected counter to increment by 1, got delta %f", after-before)
+			}
+		})
+	}
+}
+
+func TestRecordResourceOperation(t *testing.T) {
+	before := testutil.ToFloat64(resourceOperationsTotal.WithLabelValues("PrometheusRule", OperationDeleted))
+	RecordResourceOperation("PrometheusRule", OperationDeleted)
+	after := testutil.ToFloat64(resourceOperationsTotal.WithLabelValues("PrometheusRule", OperationDeleted))
+
+	if after != before+1 {
+		t.Errorf("expected counter to increment by 1, got delta %f", after-before)
+	}
+}
+
+func TestRecordBlackBoxExporterEvent(t *testing.T) {
+	before := testutil.ToFloat64(blackBoxExporterEventsTotal.WithLabelValues(OperationCreated))
+	RecordBlackBoxExporterEvent(OperationCreated)
+	after := testutil.ToFloat64(blackBoxExporterEventsTotal.WithLabelValues(OperationCreated))
+
+	if after != before+1 {
+		t.Errorf("expected counter to increment by 1, got delta %f", after-before)
+	}
+}
@@ -0,0 +1,242 @@
+package operatorconfig
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	"github.com/openshift/route-monitor-operator/config"
+	"github.com/openshift/route-monitor-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ConfigMapName is the deprecated ConfigMap in the operator namespace the configuration was read from
+// before the RouteMonitorOperatorConfig was introduced
+const ConfigMapName = config.OperatorName + "-config"
+
+// MinReconcileInterval is the shortest interval the HostedControlPlanes can be reconciled at
+const MinReconcileInterval = 10 * time.Second
+
+// Config is the operator-wide configuration
+type Config struct {
+	ProbeAPIURL                   string
+	ProbeTenant                   string
+	OIDCClientID                  string
+	OIDCClientSecret              string
+	OIDCIssuerURL                 string
+	OnlyPublicClusters            bool
+	SkipInfrastructureHealthCheck bool
+	// ReconcileInterval is zero unless configured, the HostedControlPlane reconciler uses its default then
+	ReconcileInterval  time.Duration
+	DynatraceEnabled   bool
+	PrometheusURL      string
+	RHOBSPrometheusURL string
+}
+
+// Result is the effective configuration with the source of every value and the values which were rejected
+type Result struct {
+	Config Config
+	Values []v1alpha1.EffectiveConfigValue
+	Errors field.ErrorList
+}
+
+// Loader resolves the effective configuration from the command-line flags, the deprecated ConfigMap and the
+// RouteMonitorOperatorConfig, in increasing precedence. A value which is rejected is reported in the errors
+// of the result and the value of the previous source stays in effect
+type Loader struct {
+	Client client.Reader
+	// Flags are the values of the command-line flags
+	Flags Config
+}
+
+// setting is a value which can be set by every source
+type setting struct {
+	// name is the field of the RouteMonitorOperatorConfig spec
+	name string
+	// key is the key of the ConfigMap, which is the name of the flag as well
+	key string
+	// secret values are not reported in the status
+	secret bool
+	// validate returns why the value is rejected, nil for values which are always valid
+	validate func(string) string
+	// apply sets the validated value on the configuration
+	apply func(*Config, string)
+}
+
+var settings = []setting{
+	{name: "probeAPIURL", key: "probe-api-url", validate: validateURL, apply: func(c *Config, v string) { c.ProbeAPIURL = v }},
+	{name: "probeTenant", key: "probe-tenant", apply: func(c *Config, v string) { c.ProbeTenant = v }},
+	{name: "oidcClientID", key: "oidc-client-id", apply: func(c *Config, v string) { c.OIDCClientID = v }},
+	{name: "oidcClientSecretRef", key: "oidc-client-secret", secret: true, apply: func(c *Config, v string) { c.OIDCClientSecret = v }},
+	{name: "oidcIssuerURL", key: "oidc-issuer-url", validate: validateURL, apply: func(c *Config, v string) { c.OIDCIssuerURL = v }},
+	{name: "onlyPublicClusters", key: "only-public-clusters", validate: validateBool, apply: func(c *Config, v string) { c.OnlyPublicClusters, _ = strconv.ParseBool(v) }},
+	{name: "skipInfrastructureHealthCheck", key: "skip-infrastructure-health-check", validate: validateBool, apply: func(c *Config, v string) { c.SkipInfrastructureHealthCheck, _ = strconv.ParseBool(v) }},
+	{name: "reconcileInterval", key: "reconcile-interval", validate: validateReconcileInterval, apply: func(c *Config, v string) { c.ReconcileInterval, _ = time.ParseDuration(v) }},
+	{name: "dynatraceEnabled", key: "dynatrace-enabled", validate: validateBool, apply: func(c *Config, v string) { c.DynatraceEnabled, _ = strconv.ParseBool(v) }},
+	{name: "prometheusURL", key: "prometheus-url", validate: validateURL, apply: func(c *Config, v string) { c.PrometheusURL = v }},
+	{name: "rhobsPrometheusURL", key: "rhobs-prometheus-url", validate: validateURL, apply: func(c *Config, v string) { c.RHOBSPrometheusURL = v }},
+}
+
+// Load resolves the effective configuration. Missing ConfigMaps and RouteMonitorOperatorConfigs are skipped.
+// If they cannot be read, the configuration is resolved without them and the error is returned along with it
+func (l *Loader) Load(ctx context.Context) (Result, error) {
+	result := Result{}
+	var readErrs []error
+
+	configMapValues := map[string]string{}
+	configMap := &corev1.ConfigMap{}
+	if err := l.Client.Get(ctx, types.NamespacedName{Name: ConfigMapName, Namespace: config.OperatorNamespace}, configMap); err != nil && !k8serrors.IsNotFound(err) {
+		readErrs = append(readErrs, err)
+	}
+	for key, value := range configMap.Data {
+		configMapValues[key] = strings.TrimSpace(value)
+	}
+
+	specValues := map[string]string{}
+	operatorConfig := &v1alpha1.RouteMonitorOperatorConfig{}
+	if err := l.Client.Get(ctx, types.NamespacedName{Name: v1alpha1.RouteMonitorOperatorConfigName}, operatorConfig); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			readErrs = append(readErrs, err)
+		}
+	} else {
+		values, errs, err := l.specValues(ctx, operatorConfig.Spec)
+		if err != nil {
+			readErrs = append(readErrs, err)
+		} else {
+			specValues = values
+			result.Errors = append(result.Errors, errs...)
+		}
+	}
+
+	flagValues := flagValues(l.Flags)
+	for _, s := range settings {
+		effective := v1alpha1.EffectiveConfigValue{Name: s.name, Source: v1alpha1.ConfigSourceFlag}
+		value := ""
+		sources := []struct {
+			source v1alpha1.ConfigSource
+			value  string
+			path   *field.Path
+		}{
+			{v1alpha1.ConfigSourceFlag, flagValues[s.key], field.NewPath("--" + s.key)},
+			{v1alpha1.ConfigSourceConfigMap, configMapValues[s.key], field.NewPath("configMap", "data").Key(s.key)},
+			{v1alpha1.ConfigSourceRouteMonitorOperatorConfig, specValues[s.name], field.NewPath("spec", s.name)},
+		}
+		for _, source := range sources {
+			if source.value == "" {
+				continue
+			}
+			if s.validate != nil {
+				if reason := s.validate(source.value); reason != "" {
+					result.Errors = append(result.Errors, field.Invalid(source.path, source.value, reason))
+					continue
+				}
+			}
+			value = source.value
+			effective.Source = source.source
+		}
+
+		if value != "" {
+			s.apply(&result.Config, value)
+		}
+		if !s.secret {
+			effective.Value = value
+		}
+		result.Values = append(result.Values, effective)
+	}
+	return result, errors.Join(readErrs...)
+}
+
+// specValues returns the values set in the spec by the names of the settings, the client secret is read from the referenced Secret
+func (l *Loader) specValues(ctx context.Context, spec v1alpha1.RouteMonitorOperatorConfigSpec) (map[string]string, field.ErrorList, error) {
+	values := map[string]string{
+		"probeAPIURL":        spec.ProbeAPIURL,
+		"probeTenant":        spec.ProbeTenant,
+		"oidcClientID":       spec.OIDCClientID,
+		"oidcIssuerURL":      spec.OIDCIssuerURL,
+		"prometheusURL":      spec.PrometheusURL,
+		"rhobsPrometheusURL": spec.RHOBSPrometheusURL,
+	}
+	if spec.OnlyPublicClusters != nil {
+		values["onlyPublicClusters"] = strconv.FormatBool(*spec.OnlyPublicClusters)
+	}
+	if spec.SkipInfrastructureHealthCheck != nil {
+		values["skipInfrastructureHealthCheck"] = strconv.FormatBool(*spec.SkipInfrastructureHealthCheck)
+	}
+	if spec.ReconcileInterval != nil {
+		values["reconcileInterval"] = spec.ReconcileInterval.Duration.String()
+	}
+	if spec.DynatraceEnabled != nil {
+		values["dynatraceEnabled"] = strconv.FormatBool(*spec.DynatraceEnabled)
+	}
+
+	errs := field.ErrorList{}
+	if ref := spec.OIDCClientSecretRef; ref != nil {
+		path := field.NewPath("spec", "oidcClientSecretRef")
+		secret := &corev1.Secret{}
+		err := l.Client.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: config.OperatorNamespace}, secret)
+		switch {
+		case k8serrors.IsNotFound(err):
+			errs = append(errs, field.NotFound(path.Child("name"), ref.Name))
+		case err != nil:
+			return nil, nil, err
+		case len(secret.Data[ref.Key]) == 0:
+			errs = append(errs, field.NotFound(path.Child("key"), ref.Key))
+		default:
+			values["oidcClientSecretRef"] = strings.TrimSpace(string(secret.Data[ref.Key]))
+		}
+	}
+	return values, errs, nil
+}
+
+// flagValues returns the values of the flags by the keys of the settings
+func flagValues(flags Config) map[string]string {
+	values := map[string]string{
+		"probe-api-url":        flags.ProbeAPIURL,
+		"probe-tenant":         flags.ProbeTenant,
+		"oidc-client-id":       flags.OIDCClientID,
+		"oidc-client-secret":   flags.OIDCClientSecret,
+		"oidc-issuer-url":      flags.OIDCIssuerURL,
+		"prometheus-url":       flags.PrometheusURL,
+		"rhobs-prometheus-url": flags.RHOBSPrometheusURL,
+		// false is the default of the boolean flags, it is reported as their value
+		"only-public-clusters":             strconv.FormatBool(flags.OnlyPublicClusters),
+		"skip-infrastructure-health-check": strconv.FormatBool(flags.SkipInfrastructureHealthCheck),
+		"dynatrace-enabled":                strconv.FormatBool(flags.DynatraceEnabled),
+	}
+	if flags.ReconcileInterval != 0 {
+		values["reconcile-interval"] = flags.ReconcileInterval.String()
+	}
+	return values
+}
+
+func validateURL(value string) string {
+	if !util.ValidURL(value) {
+		return "must start with http:// or https:// and include a host"
+	}
+	return ""
+}
+
+func validateBool(value string) string {
+	if _, err := strconv.ParseBool(value); err != nil {
+		return "must be true or false"
+	}
+	return ""
+}
+
+func validateReconcileInterval(value string) string {
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		return "must be a duration, e.g. 5m"
+	}
+	if interval < MinReconcileInterval {
+		return "must be at least " + MinReconcileInterval.String()
+	}
+	return ""
+}
@@ -0,0 +1,200 @@
+package operatorconfig
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	"github.com/openshift/route-monitor-operator/config"
+	constinit "github.com/openshift/route-monitor-operator/pkg/consts/test/init"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestLoad(t *testing.T) {
+	flags := Config{
+		ProbeAPIURL:      "https://flag-api.example.com/probes",
+		ProbeTenant:      "flag-tenant",
+		OIDCClientSecret: "flag-secret",
+		OIDCIssuerURL:    "https://flag-issuer.example.com",
+	}
+	configMap := func(data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: ConfigMapName, Namespace: config.OperatorNamespace},
+			Data:       data,
+		}
+	}
+	operatorConfig := func(spec v1alpha1.RouteMonitorOperatorConfigSpec) *v1alpha1.RouteMonitorOperatorConfig {
+		return &v1alpha1.RouteMonitorOperatorConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: v1alpha1.RouteMonitorOperatorConfigName},
+			Spec:       spec,
+		}
+	}
+	enabled := true
+
+	tests := []struct {
+		name        string
+		flags       Config
+		objs        []client.Object
+		wantConfig  Config
+		wantSources map[string]v1alpha1.ConfigSource
+		wantErrors  field.ErrorList
+	}{
+		{
+			name:       "flags only",
+			flags:      flags,
+			wantConfig: flags,
+			wantSources: map[string]v1alpha1.ConfigSource{
+				"probeAPIURL": v1alpha1.ConfigSourceFlag,
+				"probeTenant": v1alpha1.ConfigSourceFlag,
+			},
+		},
+		{
+			name:  "ConfigMap overrides flags",
+			flags: flags,
+			objs: []client.Object{configMap(map[string]string{
+				"probe-tenant":      " configmap-tenant ",
+				"dynatrace-enabled": "true",
+			})},
+			wantConfig: Config{
+				ProbeAPIURL:      flags.ProbeAPIURL,
+				ProbeTenant:      "configmap-tenant",
+				OIDCClientSecret: flags.OIDCClientSecret,
+				OIDCIssuerURL:    flags.OIDCIssuerURL,
+				DynatraceEnabled: true,
+			},
+			wantSources: map[string]v1alpha1.ConfigSource{
+				"probeAPIURL":      v1alpha1.ConfigSourceFlag,
+				"probeTenant":      v1alpha1.ConfigSourceConfigMap,
+				"dynatraceEnabled": v1alpha1.ConfigSourceConfigMap,
+			},
+		},
+		{
+			name:  "RouteMonitorOperatorConfig overrides the ConfigMap",
+			flags: flags,
+			objs: []client.Object{
+				configMap(map[string]string{"probe-tenant": "configmap-tenant", "reconcile-interval": "1m"}),
+				operatorConfig(v1alpha1.RouteMonitorOperatorConfigSpec{
+					ProbeTenant:        "config-tenant",
+					ReconcileInterval:  &metav1.Duration{Duration: 2 * time.Minute},
+					OnlyPublicClusters: &enabled,
+				}),
+			},
+			wantConfig: Config{
+				ProbeAPIURL:        flags.ProbeAPIURL,
+				ProbeTenant:        "config-tenant",
+				OIDCClientSecret:   flags.OIDCClientSecret,
+				OIDCIssuerURL:      flags.OIDCIssuerURL,
+				OnlyPublicClusters: true,
+				ReconcileInterval:  2 * time.Minute,
+			},
+			wantSources: map[string]v1alpha1.ConfigSource{
+				"probeTenant":        v1alpha1.ConfigSourceRouteMonitorOperatorConfig,
+				"reconcileInterval":  v1alpha1.ConfigSourceRouteMonitorOperatorConfig,
+				"onlyPublicClusters": v1alpha1.ConfigSourceRouteMonitorOperatorConfig,
+			},
+		},
+		{
+			name:  "invalid values keep the value of the previous source",
+			flags: flags,
+			objs: []client.Object{configMap(map[string]string{
+				"reconcile-interval": "5s",
+				"probe-api-url":      "not-a-url",
+				"dynatrace-enabled":  "maybe",
+			})},
+			wantConfig: flags,
+			wantSources: map[string]v1alpha1.ConfigSource{
+				"probeAPIURL":       v1alpha1.ConfigSourceFlag,
+				"reconcileInterval": v1alpha1.ConfigSourceFlag,
+				"dynatraceEnabled":  v1alpha1.ConfigSourceFlag,
+			},
+			wantErrors: field.ErrorList{
+				field.Invalid(field.NewPath("configMap", "data").Key("probe-api-url"), "not-a-url", "must start with http:// or https:// and include a host"),
+				field.Invalid(field.NewPath("configMap", "data").Key("reconcile-interval"), "5s", "must be at least 10s"),
+				field.Invalid(field.NewPath("configMap", "data").Key("dynatrace-enabled"), "maybe", "must be true or false"),
+			},
+		},
+		{
+			name:       "invalid flag URL is rejected",
+			flags:      Config{ProbeAPIURL: "api.example.com"},
+			wantConfig: Config{},
+			wantErrors: field.ErrorList{
+				field.Invalid(field.NewPath("--probe-api-url"), "api.example.com", "must start with http:// or https:// and include a host"),
+			},
+		},
+		{
+			name:  "client secret is read from the referenced Secret",
+			flags: flags,
+			objs: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "rhobs-oidc", Namespace: config.OperatorNamespace},
+					Data:       map[string][]byte{"client-secret": []byte("config-secret\n")},
+				},
+				operatorConfig(v1alpha1.RouteMonitorOperatorConfigSpec{
+					OIDCClientSecretRef: &v1alpha1.SecretKeyReference{Name: "rhobs-oidc", Key: "client-secret"},
+				}),
+			},
+			wantConfig: Config{
+				ProbeAPIURL:      flags.ProbeAPIURL,
+				ProbeTenant:      flags.ProbeTenant,
+				OIDCClientSecret: "config-secret",
+				OIDCIssuerURL:    flags.OIDCIssuerURL,
+			},
+			wantSources: map[string]v1alpha1.ConfigSource{
+				"oidcClientSecretRef": v1alpha1.ConfigSourceRouteMonitorOperatorConfig,
+			},
+		},
+		{
+			name:  "missing Secret is reported",
+			flags: flags,
+			objs: []client.Object{
+				operatorConfig(v1alpha1.RouteMonitorOperatorConfigSpec{
+					OIDCClientSecretRef: &v1alpha1.SecretKeyReference{Name: "rhobs-oidc", Key: "client-secret"},
+				}),
+			},
+			wantConfig: flags,
+			wantSources: map[string]v1alpha1.ConfigSource{
+				"oidcClientSecretRef": v1alpha1.ConfigSourceFlag,
+			},
+			wantErrors: field.ErrorList{
+				field.NotFound(field.NewPath("spec", "oidcClientSecretRef", "name"), "rhobs-oidc"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loader := Loader{
+				Client: fake.NewClientBuilder().WithScheme(constinit.Scheme).WithObjects(tt.objs...).Build(),
+				Flags:  tt.flags,
+			}
+			result, err := loader.Load(context.TODO())
+			if err != nil {
+				t.Fatalf("Load() unexpected error = %v", err)
+			}
+
+			if !reflect.DeepEqual(result.Config, tt.wantConfig) {
+				t.Errorf("Load() config = %+v, want %+v", result.Config, tt.wantConfig)
+			}
+			if len(result.Values) != len(settings) {
+				t.Errorf("Load() reported %d values, want %d", len(result.Values), len(settings))
+			}
+			for _, value := range result.Values {
+				if want, ok := tt.wantSources[value.Name]; ok && value.Source != want {
+					t.Errorf("Load() source of %s = %s, want %s", value.Name, value.Source, want)
+				}
+				if value.Name == "oidcClientSecretRef" && value.Value != "" {
+					t.Errorf("Load() reported the value of the client secret")
+				}
+			}
+			if !reflect.DeepEqual(result.Errors, tt.wantErrors) {
+				t.Errorf("Load() errors = %v, want %v", result.Errors, tt.wantErrors)
+			}
+		})
+	}
+}
@@ -0,0 +1,283 @@
+package probestatus
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	"github.com/openshift/route-monitor-operator/pkg/operatorconfig"
+	"github.com/openshift/route-monitor-operator/pkg/servicemonitor"
+	"github.com/openshift/route-monitor-operator/pkg/util/conditions"
+	customerrors "github.com/openshift/route-monitor-operator/pkg/util/errors"
+	"github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultInterval is how often the probe status of a monitor is refreshed, unless configured otherwise
+const DefaultInterval = 5 * time.Minute
+
+// ReloadInterval is how often the Prometheus endpoints are resolved from the operator configuration at most
+const ReloadInterval = time.Minute
+
+// queryTimeout bounds a single query, so an unresponsive endpoint does not block the reconcile loop
+const queryTimeout = 30 * time.Second
+
+// Config configures the Prometheus or Thanos Querier endpoints the probe status is queried from
+type Config struct {
+	// URL is queried for monitors using monitoring.coreos.com ServiceMonitors
+	URL string
+	// RHOBSURL is queried for monitors using monitoring.rhobs ServiceMonitors
+	RHOBSURL string
+	// BearerTokenFile optionally holds the token sent to the endpoints, it is read for every query to pick up rotations
+	BearerTokenFile string
+	// CAFile optionally holds the CA verifying the endpoints, the system pool is used otherwise
+	CAFile string
+	// Interval is how often the probe status of a monitor is refreshed
+	Interval time.Duration
+}
+
+// Enabled returns whether any endpoint is configured
+func (c Config) Enabled() bool {
+	return c.URL != "" || c.RHOBSURL != ""
+}
+
+// ProbeStatus queries the health of the probes from Prometheus
+type ProbeStatus struct {
+	Ctx      context.Context
+	coreos   promv1.API
+	rhobs    promv1.API
+	interval time.Duration
+}
+
+// New creates a client for every configured endpoint
+func New(ctx context.Context, cfg Config) (*ProbeStatus, error) {
+	roundTripper, err := newRoundTripper(cfg)
+	if err != nil {
+		return nil, err
+	}
+	p := &ProbeStatus{Ctx: ctx, interval: cfg.Interval}
+	if p.interval <= 0 {
+		p.interval = DefaultInterval
+	}
+	if cfg.URL != "" {
+		if p.coreos, err = newAPI(cfg.URL, roundTripper); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.RHOBSURL != "" {
+		if p.rhobs, err = newAPI(cfg.RHOBSURL, roundTripper); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// Reloader creates the clients of the endpoints resolved from the operator configuration, so changes of the
+// RouteMonitorOperatorConfig or the deprecated ConfigMap are picked up without restarting the operator
+type Reloader struct {
+	Ctx context.Context
+	// Loader resolves the endpoints, its flags hold the endpoints of the command-line flags
+	Loader operatorconfig.Loader
+	// Config holds the bearer token, the CA and the interval, its endpoints are replaced by the resolved ones
+	Config Config
+	// ReloadInterval is the minimum time between two resolutions of the endpoints, they are resolved on every call if zero
+	ReloadInterval time.Duration
+
+	mu        sync.Mutex
+	loaded    time.Time
+	endpoints Config
+	current   *ProbeStatus
+}
+
+// Get returns the client of the currently configured endpoints, it is only recreated when they changed.
+// It returns nil when no endpoint is configured. If the configuration cannot be read, the client of the
+// previous endpoints is returned along with the error
+func (r *Reloader) Get(ctx context.Context) (*ProbeStatus, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.loaded.IsZero() && time.Since(r.loaded) < r.ReloadInterval {
+		return r.current, nil
+	}
+
+	result, err := r.Loader.Load(ctx)
+	if err != nil {
+		return r.current, fmt.Errorf("failed to read the operator configuration: %w", err)
+	}
+	cfg := r.Config
+	cfg.URL = result.Config.PrometheusURL
+	cfg.RHOBSURL = result.Config.RHOBSPrometheusURL
+
+	if r.current == nil || r.endpoints != cfg {
+		r.endpoints, r.current = cfg, nil
+		if cfg.Enabled() {
+			current, err := New(r.Ctx, cfg)
+			if err != nil {
+				return nil, err
+			}
+			r.current = current
+		}
+	}
+	r.loaded = time.Now()
+	return r.current, nil
+}
+
+func newAPI(address string, roundTripper http.RoundTripper) (promv1.API, error) {
+	client, err := api.NewClient(api.Config{Address: address, RoundTripper: roundTripper})
+	if err != nil {
+		return nil, err
+	}
+	return promv1.NewAPI(client), nil
+}
+
+func newRoundTripper(cfg Config) (http.RoundTripper, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.CAFile != "" {
+		ca, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificates found in %s", cfg.CAFile)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+	if cfg.BearerTokenFile == "" {
+		return transport, nil
+	}
+	return &bearerTokenRoundTripper{tokenFile: cfg.BearerTokenFile, next: transport}, nil
+}
+
+// bearerTokenRoundTripper authenticates requests with the token of a file, e.g. the one of the ServiceAccount
+type bearerTokenRoundTripper struct {
+	tokenFile string
+	next      http.RoundTripper
+}
+
+func (b *bearerTokenRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := os.ReadFile(b.tokenFile)
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	return b.next.RoundTrip(req)
+}
+
+// RefreshInterval returns how often the probe status of a monitor is refreshed
+func (p *ProbeStatus) RefreshInterval() time.Duration {
+	return p.interval
+}
+
+// GetProbeStatus queries the last probe result of the URL, its availability over the SLO window
+// and the remaining error budget of the SLO. The RHOBS endpoint is queried when rhobs is set
+func (p *ProbeStatus) GetProbeStatus(url string, rhobs bool, slo v1alpha1.SloSpec) (v1alpha1.ProbeStatus, error) {
+	querier := p.coreos
+	if rhobs {
+		querier = p.rhobs
+	}
+	if querier == nil {
+		return v1alpha1.ProbeStatus{}, customerrors.ErrNoPrometheusEndpoint
+	}
+
+	now := time.Now()
+	window := slo.WindowOrDefault()
+	status := v1alpha1.ProbeStatus{Window: window, LastQueryTime: metav1.NewTime(now)}
+	selector := fmt.Sprintf(`probe_success{%s="%s"}`, servicemonitor.UrlLabelName, url)
+
+	// a failure of any of the probes of the URL counts as failure, unless another blackbox exporter replica succeeded
+	success, found, err := p.query(querier, "min("+servicemonitor.WithoutReplicas(selector)+")", now)
+	if err != nil || !found {
+		return status, err
+	}
+	lastProbeSuccess := success == 1
+	status.LastProbeSuccess = &lastProbeSuccess
+
+	scraped, found, err := p.query(querier, "max(timestamp("+selector+"))", now)
+	if err != nil {
+		return status, err
+	}
+	if found {
+		lastProbeTime := metav1.NewTime(time.UnixMilli(int64(scraped * 1000)))
+		status.LastProbeTime = &lastProbeTime
+	}
+
+	// same ratio as evaluated by the burn rate alerts
+	availability, found, err := p.query(querier, "sum("+servicemonitor.OverReplicas("sum_over_time", selector, window)+")"+
+		" / sum("+servicemonitor.OverReplicas("count_over_time", selector, window)+")", now)
+	if err != nil || !found {
+		return status, err
+	}
+	status.AvailabilityPercent = formatPercent(availability)
+	if isValid, target := slo.IsValid(); isValid {
+		ratio, _ := strconv.ParseFloat(target, 64)
+		status.RemainingErrorBudgetPercent = formatPercent(1 - (1-availability)/(1-ratio))
+	}
+	return status, nil
+}
+
+// query runs an instant query and returns the value of its first sample, if there is any
+func (p *ProbeStatus) query(querier promv1.API, query string, ts time.Time) (float64, bool, error) {
+	ctx, cancel := context.WithTimeout(p.Ctx, queryTimeout)
+	defer cancel()
+	result, _, err := querier.Query(ctx, query, ts)
+	if err != nil {
+		return 0, false, err
+	}
+	vector, ok := result.(model.Vector)
+	if !ok {
+		return 0, false, fmt.Errorf("unexpected result type %s of query %s", result.Type(), query)
+	}
+	if len(vector) == 0 || math.IsNaN(float64(vector[0].Value)) {
+		return 0, false, nil
+	}
+	return float64(vector[0].Value), true, nil
+}
+
+// formatPercent formats a ratio as percent with up to three decimals
+func formatPercent(ratio float64) string {
+	return strconv.FormatFloat(math.Round(ratio*100000)/1000, 'f', -1, 64)
+}
+
+// NextRefresh returns how long to wait until the probe status is due to be refreshed, zero if it is due
+func NextRefresh(status *v1alpha1.ProbeStatus, interval time.Duration) time.Duration {
+	if status == nil {
+		return 0
+	}
+	if wait := interval - time.Since(status.LastQueryTime.Time); wait > 0 {
+		return wait
+	}
+	return 0
+}
+
+// Record returns the probe status to store for the result of a query and sets the TargetHealthy condition
+// of the monitor accordingly. When the query failed, the results of the previous status are kept
+func Record(object conditions.Object, previous *v1alpha1.ProbeStatus, status v1alpha1.ProbeStatus, err error) *v1alpha1.ProbeStatus {
+	switch {
+	case err != nil:
+		conditions.Set(object, v1alpha1.ConditionTargetHealthy, metav1.ConditionUnknown, v1alpha1.ReasonQueryFailed, err.Error())
+		status = v1alpha1.ProbeStatus{}
+		if previous != nil {
+			status = *previous
+		}
+		status.LastQueryTime = metav1.Now()
+	case status.LastProbeSuccess == nil:
+		conditions.Set(object, v1alpha1.ConditionTargetHealthy, metav1.ConditionUnknown, v1alpha1.ReasonNoData, "Prometheus has no probe results for the URL")
+	case *status.LastProbeSuccess:
+		conditions.MarkTrue(object, v1alpha1.ConditionTargetHealthy, v1alpha1.ReasonProbeSucceeded)
+	default:
+		conditions.Set(object, v1alpha1.ConditionTargetHealthy, metav1.ConditionFalse, v1alpha1.ReasonProbeFailed, "the last probe of the URL failed")
+	}
+	return &status
+}
@@ -0,0 +1,13 @@
+package probestatus_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestProbeStatus(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "ProbeStatus Suite")
+}
@@ -0,0 +1,298 @@
+package probestatus_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	constinit "github.com/openshift/route-monitor-operator/pkg/consts/test/init"
+	"github.com/openshift/route-monitor-operator/pkg/operatorconfig"
+	. "github.com/openshift/route-monitor-operator/pkg/probestatus"
+	customerrors "github.com/openshift/route-monitor-operator/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var _ = Describe("ProbeStatus", func() {
+	var (
+		// results maps a substring of a query to the value returned for it, queries without match return no samples
+		results       map[string]string
+		authorization string
+		server        *httptest.Server
+		cfg           Config
+		slo           v1alpha1.SloSpec
+		rhobs         bool
+
+		status v1alpha1.ProbeStatus
+		err    error
+	)
+	BeforeEach(func() {
+		results = map[string]string{
+			"min(max without": "1",
+			"max(timestamp(":  "1700000000",
+			"sum_over_time(":  "0.9996",
+		}
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.ParseForm()).To(Succeed())
+			authorization = r.Header.Get("Authorization")
+			samples := ""
+			for match, value := range results {
+				if strings.Contains(r.Form.Get("query"), match) {
+					samples = fmt.Sprintf(`{"metric":{},"value":[1700000000,"%s"]}`, value)
+				}
+			}
+			fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":[%s]}}`, samples)
+		}))
+		cfg = Config{URL: server.URL}
+		slo = v1alpha1.SloSpec{TargetAvailabilityPercent: "99.95"}
+		rhobs = false
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+	JustBeforeEach(func() {
+		var p *ProbeStatus
+		p, err = New(context.Background(), cfg)
+		Expect(err).NotTo(HaveOccurred())
+		status, err = p.GetProbeStatus("https://fake-url", rhobs, slo)
+	})
+
+	When("Prometheus has probe results", func() {
+		It("reports the last probe, the availability and the remaining error budget", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*status.LastProbeSuccess).To(BeTrue())
+			Expect(status.LastProbeTime.Time).To(Equal(time.Unix(1700000000, 0)))
+			Expect(status.Window).To(Equal(v1alpha1.DefaultSloWindow))
+			Expect(status.AvailabilityPercent).To(Equal("99.96"))
+			Expect(status.RemainingErrorBudgetPercent).To(Equal("20"))
+		})
+	})
+	When("the SLO defines a window", func() {
+		BeforeEach(func() {
+			slo.Window = "7d"
+		})
+		It("computes the availability over it", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.Window).To(Equal("7d"))
+		})
+	})
+	When("the monitor has no SLO", func() {
+		BeforeEach(func() {
+			slo = v1alpha1.SloSpec{}
+		})
+		It("omits the remaining error budget", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.AvailabilityPercent).To(Equal("99.96"))
+			Expect(status.RemainingErrorBudgetPercent).To(BeEmpty())
+		})
+	})
+	When("Prometheus has no probe results", func() {
+		BeforeEach(func() {
+			results = map[string]string{}
+		})
+		It("only records the query time", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.LastProbeSuccess).To(BeNil())
+			Expect(status.AvailabilityPercent).To(BeEmpty())
+			Expect(status.LastQueryTime.IsZero()).To(BeFalse())
+		})
+	})
+	When("a bearer token file is configured", func() {
+		var tokenDir string
+		BeforeEach(func() {
+			// GinkgoT().TempDir() is not implemented by Ginkgo v1
+			var mkdirErr error
+			tokenDir, mkdirErr = os.MkdirTemp("", "probestatus")
+			Expect(mkdirErr).NotTo(HaveOccurred())
+			tokenFile := filepath.Join(tokenDir, "token")
+			Expect(os.WriteFile(tokenFile, []byte("fake-token\n"), 0600)).To(Succeed())
+			cfg.BearerTokenFile = tokenFile
+		})
+		AfterEach(func() {
+			Expect(os.RemoveAll(tokenDir)).To(Succeed())
+		})
+		It("authenticates with the token", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(authorization).To(Equal("Bearer fake-token"))
+		})
+	})
+	When("the monitor uses RHOBS ServiceMonitors", func() {
+		BeforeEach(func() {
+			rhobs = true
+		})
+		When("no RHOBS endpoint is configured", func() {
+			It("returns an error", func() {
+				Expect(err).To(MatchError(customerrors.ErrNoPrometheusEndpoint))
+			})
+		})
+		When("a RHOBS endpoint is configured", func() {
+			BeforeEach(func() {
+				cfg = Config{RHOBSURL: server.URL}
+			})
+			It("queries it", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*status.LastProbeSuccess).To(BeTrue())
+			})
+		})
+	})
+})
+
+var _ = Describe("Reloader", func() {
+	var (
+		ctx            context.Context
+		operatorConfig *v1alpha1.RouteMonitorOperatorConfig
+		fakeClient     client.Client
+		reloader       *Reloader
+		readErr        error
+	)
+	BeforeEach(func() {
+		ctx = context.Background()
+		readErr = nil
+		operatorConfig = &v1alpha1.RouteMonitorOperatorConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: v1alpha1.RouteMonitorOperatorConfigName},
+		}
+		fakeClient = fake.NewClientBuilder().WithScheme(constinit.Scheme).WithObjects(operatorConfig).WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if readErr != nil {
+					return readErr
+				}
+				return c.Get(ctx, key, obj, opts...)
+			},
+		}).Build()
+		reloader = &Reloader{
+			Ctx:    ctx,
+			Loader: operatorconfig.Loader{Client: fakeClient},
+			Config: Config{Interval: time.Minute},
+		}
+	})
+	updateEndpoints := func(url, rhobsURL string) {
+		operatorConfig.Spec.PrometheusURL = url
+		operatorConfig.Spec.RHOBSPrometheusURL = rhobsURL
+		Expect(fakeClient.Update(ctx, operatorConfig)).To(Succeed())
+	}
+
+	It("returns no client while no endpoint is configured", func() {
+		p, err := reloader.Get(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(p).To(BeNil())
+	})
+	It("falls back to the endpoints of the flags", func() {
+		reloader.Loader.Flags = operatorconfig.Config{PrometheusURL: "https://flag-prometheus.example.com"}
+		p, err := reloader.Get(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(p).NotTo(BeNil())
+		Expect(p.RefreshInterval()).To(Equal(time.Minute))
+	})
+	It("keeps the client while the endpoints are unchanged and recreates it when they change", func() {
+		updateEndpoints("https://prometheus.example.com", "")
+		first, err := reloader.Get(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(first).NotTo(BeNil())
+
+		same, err := reloader.Get(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(same).To(BeIdenticalTo(first))
+
+		updateEndpoints("https://prometheus.example.com", "https://rhobs.example.com")
+		changed, err := reloader.Get(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changed).NotTo(BeNil())
+		Expect(changed).NotTo(BeIdenticalTo(first))
+
+		updateEndpoints("", "")
+		removed, err := reloader.Get(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(removed).To(BeNil())
+	})
+	It("keeps the client of the previous endpoints while the configuration cannot be read", func() {
+		updateEndpoints("https://prometheus.example.com", "")
+		first, err := reloader.Get(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(first).NotTo(BeNil())
+
+		readErr = errors.New("fake error")
+		kept, err := reloader.Get(ctx)
+		Expect(err).To(MatchError(ContainSubstring("fake error")))
+		Expect(kept).To(BeIdenticalTo(first))
+
+		readErr = nil
+		same, err := reloader.Get(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(same).To(BeIdenticalTo(first))
+	})
+	It("resolves the endpoints at most once per reload interval", func() {
+		reloader.ReloadInterval = time.Hour
+		updateEndpoints("https://prometheus.example.com", "")
+		first, err := reloader.Get(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(first).NotTo(BeNil())
+
+		updateEndpoints("", "")
+		same, err := reloader.Get(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(same).To(BeIdenticalTo(first))
+	})
+	It("returns the error of an unreadable CA", func() {
+		reloader.Config.CAFile = "/nonexistent/ca.crt"
+		updateEndpoints("https://prometheus.example.com", "")
+		p, err := reloader.Get(ctx)
+		Expect(err).To(HaveOccurred())
+		Expect(p).To(BeNil())
+	})
+})
+
+var _ = Describe("NextRefresh", func() {
+	It("is due without previous status", func() {
+		Expect(NextRefresh(nil, time.Minute)).To(BeZero())
+	})
+	It("is due once the interval passed", func() {
+		Expect(NextRefresh(&v1alpha1.ProbeStatus{LastQueryTime: metav1.NewTime(time.Now().Add(-2 * time.Minute))}, time.Minute)).To(BeZero())
+	})
+	It("waits for the rest of the interval", func() {
+		wait := NextRefresh(&v1alpha1.ProbeStatus{LastQueryTime: metav1.NewTime(time.Now().Add(-time.Minute))}, 5*time.Minute)
+		Expect(wait).To(BeNumerically("~", 4*time.Minute, time.Second))
+	})
+})
+
+var _ = Describe("Record", func() {
+	var routeMonitor v1alpha1.RouteMonitor
+	BeforeEach(func() {
+		routeMonitor = v1alpha1.RouteMonitor{}
+	})
+	It("marks the target healthy when the last probe succeeded", func() {
+		lastProbeSuccess := true
+		Record(&routeMonitor, nil, v1alpha1.ProbeStatus{LastProbeSuccess: &lastProbeSuccess}, nil)
+		Expect(meta.IsStatusConditionTrue(routeMonitor.Status.Conditions, v1alpha1.ConditionTargetHealthy)).To(BeTrue())
+	})
+	It("marks the target health unknown without probe results", func() {
+		Record(&routeMonitor, nil, v1alpha1.ProbeStatus{}, nil)
+		condition := meta.FindStatusCondition(routeMonitor.Status.Conditions, v1alpha1.ConditionTargetHealthy)
+		Expect(condition.Status).To(Equal(metav1.ConditionUnknown))
+		Expect(condition.Reason).To(Equal(v1alpha1.ReasonNoData))
+	})
+	It("keeps the previous results when the query failed", func() {
+		status := Record(&routeMonitor, &v1alpha1.ProbeStatus{AvailabilityPercent: "99"}, v1alpha1.ProbeStatus{}, errors.New("fake error"))
+		Expect(status.AvailabilityPercent).To(Equal("99"))
+		Expect(status.LastQueryTime.IsZero()).To(BeFalse())
+		Expect(meta.FindStatusCondition(routeMonitor.Status.Conditions, v1alpha1.ConditionTargetHealthy).Reason).To(Equal(v1alpha1.ReasonQueryFailed))
+	})
+	It("does not affect the Ready condition", func() {
+		lastProbeSuccess := false
+		Record(&routeMonitor, nil, v1alpha1.ProbeStatus{LastProbeSuccess: &lastProbeSuccess}, nil)
+		Expect(meta.FindStatusCondition(routeMonitor.Status.Conditions, v1alpha1.ConditionReady).Message).NotTo(ContainSubstring(v1alpha1.ConditionTargetHealthy))
+		Expect(meta.IsStatusConditionTrue(routeMonitor.Status.Conditions, v1alpha1.ConditionDegraded)).To(BeFalse())
+	})
+})
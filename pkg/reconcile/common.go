@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"time"
 
+	"github.com/openshift/route-monitor-operator/pkg/consts/blackboxexporter"
 	"github.com/openshift/route-monitor-operator/pkg/util/finalizer"
 	"github.com/openshift/route-monitor-operator/pkg/util/reconcile"
 
@@ -13,6 +15,7 @@ import (
 	"github.com/openshift/route-monitor-operator/api/v1alpha1"
 	customerrors "github.com/openshift/route-monitor-operator/pkg/util/errors"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	prometheus "github.com/prometheus/common/model"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -74,18 +77,14 @@ func (u *MonitorResourceCommon) needsErrorStatusToBeSet(errorStatus *string, err
 	return *errorStatus == "" && err != nil
 }
 
-func (u *MonitorResourceCommon) SetResourceReference(reference *v1alpha1.NamespacedName, targetNamespace types.NamespacedName) (bool, error) {
+// SetResourceReference points the reference to the target and returns whether it changed
+func (u *MonitorResourceCommon) SetResourceReference(reference *v1alpha1.NamespacedName, targetNamespace types.NamespacedName) bool {
 	desiredRef := v1alpha1.NamespacedName{Name: targetNamespace.Name, Namespace: targetNamespace.Namespace}
-	if *reference == (v1alpha1.NamespacedName{}) ||
-		desiredRef == (v1alpha1.NamespacedName{}) {
-		*reference = desiredRef
-		return true, nil
-	}
-	if *reference != desiredRef {
-		// TODO Check when this is really required
-		return false, customerrors.ErrInvalidReferenceUpdate
+	if *reference == desiredRef {
+		return false
 	}
-	return false, nil
+	*reference = desiredRef
+	return true
 }
 
 // remove boolean
@@ -100,6 +99,17 @@ func (u *MonitorResourceCommon) ParseMonitorSLOSpecs(routeURL string, sloSpec v1
 	if !isValid {
 		return "", customerrors.ErrInvalidSLO
 	}
+	if sloSpec.Latency != nil {
+		if isValid, _ := sloSpec.Latency.IsValid(); !isValid {
+			return "", customerrors.ErrInvalidLatencySLO
+		}
+	}
+	if sloSpec.Alerting != nil {
+		probeInterval, _ := prometheus.ParseDuration(blackboxexporter.ProbeInterval)
+		if !sloSpec.Alerting.IsValid(time.Duration(probeInterval)) {
+			return "", customerrors.ErrInvalidSLOAlerting
+		}
+	}
 	return parsedSlo, nil
 }
 
@@ -182,6 +182,98 @@ var _ = Describe("CR Deployment Handling", func() {
 				Expect(err).To(Not(HaveOccurred()))
 			})
 		})
+		Describe("the SLO defines a latency objective", func() {
+			var latency v1alpha1.LatencySloSpec
+			BeforeEach(func() {
+				latency = v1alpha1.LatencySloSpec{TargetPercent: "99", Threshold: "800ms"}
+			})
+			JustBeforeEach(func() {
+				sloSpec.Latency = &latency
+				res, err = rc.ParseMonitorSLOSpecs(url, sloSpec)
+			})
+			When("the latency objective is valid", func() {
+				It("should return the parsed availability SLO", func() {
+					Expect(res).To(Equal("0.995"))
+					Expect(err).To(Not(HaveOccurred()))
+				})
+			})
+			When("the threshold cannot be parsed", func() {
+				BeforeEach(func() {
+					latency.Threshold = "fast"
+				})
+				It("should return an empty string and an error", func() {
+					Expect(res).To(Equal(""))
+					Expect(err).To(Equal(customerrors.ErrInvalidLatencySLO))
+				})
+			})
+			When("the target percent is out of range", func() {
+				BeforeEach(func() {
+					latency.TargetPercent = "100"
+				})
+				It("should return an empty string and an error", func() {
+					Expect(res).To(Equal(""))
+					Expect(err).To(Equal(customerrors.ErrInvalidLatencySLO))
+				})
+			})
+		})
+		Describe("the SLO defines its own burn rate windows", func() {
+			var window v1alpha1.BurnRateWindow
+			BeforeEach(func() {
+				window = v1alpha1.BurnRateWindow{
+					LongWindow:  "1h",
+					ShortWindow: "5m",
+					BurnRate:    "14.4",
+					For:         "2m",
+					Severity:    v1alpha1.SeverityCritical,
+				}
+			})
+			JustBeforeEach(func() {
+				sloSpec.Alerting = &v1alpha1.SloAlertingSpec{BurnRateWindows: []v1alpha1.BurnRateWindow{window}}
+				res, err = rc.ParseMonitorSLOSpecs(url, sloSpec)
+			})
+			When("the windows are valid", func() {
+				It("should return the parsed SLO", func() {
+					Expect(res).To(Equal("0.995"))
+					Expect(err).To(Not(HaveOccurred()))
+				})
+			})
+			When("the short window is not shorter than the long window", func() {
+				BeforeEach(func() {
+					window.ShortWindow = "1h"
+				})
+				It("should return an empty string and an error", func() {
+					Expect(res).To(Equal(""))
+					Expect(err).To(Equal(customerrors.ErrInvalidSLOAlerting))
+				})
+			})
+			When("the short window covers less than two probe intervals", func() {
+				BeforeEach(func() {
+					window.ShortWindow = "45s"
+				})
+				It("should return an empty string and an error", func() {
+					Expect(res).To(Equal(""))
+					Expect(err).To(Equal(customerrors.ErrInvalidSLOAlerting))
+				})
+			})
+			When("the burn rate cannot be parsed", func() {
+				BeforeEach(func() {
+					window.BurnRate = "fast"
+				})
+				It("should return an empty string and an error", func() {
+					Expect(res).To(Equal(""))
+					Expect(err).To(Equal(customerrors.ErrInvalidSLOAlerting))
+				})
+			})
+			When("the severity is unknown", func() {
+				BeforeEach(func() {
+					window.Severity = "page"
+				})
+				It("should return an empty string and an error", func() {
+					Expect(res).To(Equal(""))
+					Expect(err).To(Equal(customerrors.ErrInvalidSLOAlerting))
+				})
+			})
+		})
 	})
 	Describe("UpdateMonitorResource", func() {
 		var (
@@ -225,7 +317,6 @@ var _ = Describe("CR Deployment Handling", func() {
 			reference v1alpha1.NamespacedName
 			target    types.NamespacedName
 			res       bool
-			err       error
 		)
 		BeforeEach(func() {
 			reference = v1alpha1.NamespacedName{}
@@ -233,7 +324,7 @@ var _ = Describe("CR Deployment Handling", func() {
 
 		})
 		JustBeforeEach(func() {
-			res, err = rc.SetResourceReference(&reference, target)
+			res = rc.SetResourceReference(&reference, target)
 
 		})
 		When("when existing reference is flushed", func() {
@@ -243,7 +334,6 @@ var _ = Describe("CR Deployment Handling", func() {
 			})
 			It("should indicate that the references has been altered", func() {
 				Expect(res).To(Equal(true))
-				Expect(err).To(Not(HaveOccurred()))
 			})
 		})
 		When("when empty reference is filled", func() {
@@ -253,7 +343,6 @@ var _ = Describe("CR Deployment Handling", func() {
 			})
 			It("should indicate that the references has been altered", func() {
 				Expect(res).To(Equal(true))
-				Expect(err).To(Not(HaveOccurred()))
 			})
 		})
 		When("when reference is already set according to the target", func() {
@@ -263,17 +352,16 @@ var _ = Describe("CR Deployment Handling", func() {
 			})
 			It("should indicate that the references has not been altered", func() {
 				Expect(res).To(Equal(false))
-				Expect(err).To(Not(HaveOccurred()))
 			})
 		})
-		When("it trys to fill reference with new values, which should never happen and is not supported", func() {
+		When("the reference is migrated to another target", func() {
 			BeforeEach(func() {
 				reference = v1alpha1.NamespacedName{Name: "fake", Namespace: "fake-namespace"}
 				target = types.NamespacedName{Name: "fake2", Namespace: "fake-namespace2"}
 			})
-			It("should indicate error", func() {
-				Expect(res).To(Equal(false))
-				Expect(err).To(Equal(customerrors.ErrInvalidReferenceUpdate))
+			It("should point the reference to the new target", func() {
+				Expect(res).To(Equal(true))
+				Expect(reference).To(Equal(v1alpha1.NamespacedName{Name: "fake2", Namespace: "fake-namespace2"}))
 			})
 		})
 	})
@@ -2,6 +2,9 @@ package servicemonitor
 
 import (
 	"context"
+	"net/url"
+	"slices"
+	"strings"
 
 	"github.com/openshift/route-monitor-operator/api/v1alpha1"
 	"github.com/openshift/route-monitor-operator/pkg/consts/blackboxexporter"
@@ -12,6 +15,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 type ServiceMonitor struct {
@@ -29,109 +33,252 @@ func NewServiceMonitor(ctx context.Context, c client.Client) *ServiceMonitor {
 }
 
 const (
-	ServiceMonitorPeriod string = "30s"
+	ServiceMonitorPeriod string = blackboxexporter.ProbeInterval
 	UrlLabelName         string = "probe_url"
+	RouterLabelName      string = "router"
+	LocationLabelName    string = "location"
 )
 
-func (u *ServiceMonitor) TemplateAndUpdateServiceMonitorDeployment(routeURL, blackBoxExporterNamespace string, namespacedName types.NamespacedName, clusterID string, isHCPMonitor bool, useInsecure bool, owner *metav1.OwnerReference) error {
-	module := "http_2xx"
-	if useInsecure {
-		module = "insecure_http_2xx"
+// ReplicaLabels tell apart the series of the blackbox exporter replicas, as every replica is scraped by the endpoints
+// of a ServiceMonitor and probes the target on its own
+var ReplicaLabels = []string{"instance", "pod"}
+
+// WithoutReplicas merges the series of the blackbox exporter replicas into one series per target, so a probe is
+// counted once regardless of the number of replicas. A probe succeeded if it succeeded on any replica
+func WithoutReplicas(selector string) string {
+	return "max without (" + strings.Join(ReplicaLabels, ",") + ") (" + selector + ")"
+}
+
+// OverReplicas applies the range function to the samples of the selector over the window and averages the result
+// over the blackbox exporter replicas, so a probe is counted once regardless of the number of replicas.
+// Unlike a subquery, which repeats the last sample at every step, the range vector only holds the probes which ran.
+// The params are passed to the range function before the range vector, e.g. the quantile of quantile_over_time
+func OverReplicas(function, selector, window string, params ...string) string {
+	args := strings.Join(slices.Concat(params, []string{selector + "[" + window + "]"}), ",")
+	return "avg without (" + strings.Join(ReplicaLabels, ",") + ") (" + function + "(" + args + "))"
+}
+
+// Target is probed by an endpoint of the ServiceMonitor
+type Target struct {
+	// URL is passed as target to the blackbox exporter
+	URL string
+	// Router is set as router label on the metrics of the endpoint, if not empty
+	Router string
+	// Location is set as location label on the metrics of the endpoint, if not empty
+	Location string
+	// Exporter is the URL of a remote blackbox exporter probing the target instead of the blackbox exporter
+	// selected by the ServiceMonitor, if not empty
+	Exporter string
+	// Module overrides the module of the monitor, remote exporters probe with their own modules
+	Module string
+}
+
+// WithLocations returns the targets probed from every location, the targets are returned unchanged without locations
+func WithLocations(targets []Target, locations *v1alpha1.ProbeLocationsSpec) []Target {
+	if locations == nil {
+		return targets
 	}
+	located := []Target{}
+	for _, location := range locations.Locations {
+		for _, target := range targets {
+			target.Location = location.Name
+			if location.Type == v1alpha1.ProbeLocationRemoteExporter {
+				target.Exporter = location.URL
+				target.Module = location.Module
+				if target.Module == "" {
+					target.Module = blackboxexporter.DefaultModule
+				}
+			}
+			located = append(located, target)
+		}
+	}
+	return located
+}
 
-	params := map[string][]string{
-		"module": {module},
-		"target": {routeURL},
+// exporter returns the scheme and the address the endpoint of the target is scraped at. The address is empty for the
+// blackbox exporter selected by the ServiceMonitor, whose replicas are scraped at their own addresses
+func (t Target) exporter() (string, string) {
+	if t.Exporter == "" {
+		return "http", ""
 	}
+	exporter, err := url.Parse(t.Exporter)
+	if err != nil {
+		// the URL is validated by the CRD
+		return "http", ""
+	}
+	return exporter.Scheme, exporter.Host
+}
 
+// TemplateAndUpdateServiceMonitorDeployment probes the targets with the module through the blackbox exporter, the probe_url label is set to the routeURL
+func (u *ServiceMonitor) TemplateAndUpdateServiceMonitorDeployment(routeURL string, targets []Target, blackBoxExporter types.NamespacedName, namespacedName types.NamespacedName, clusterID string, isHCPMonitor bool, module string, owner *metav1.OwnerReference) (controllerutil.OperationResult, error) {
 	if isHCPMonitor {
-		s := u.HyperShiftTemplateForServiceMonitorResource(routeURL, blackBoxExporterNamespace, params, namespacedName, clusterID, owner)
+		s := u.HyperShiftTemplateForServiceMonitorResource(routeURL, blackBoxExporter, module, targets, namespacedName, clusterID, owner)
 		return u.HypershiftUpdateServiceMonitorDeployment(s)
 	}
-	s := u.TemplateForServiceMonitorResource(routeURL, blackBoxExporterNamespace, params, namespacedName, clusterID, owner)
+	s := u.TemplateForServiceMonitorResource(routeURL, blackBoxExporter, module, targets, namespacedName, clusterID, owner)
 	return u.UpdateServiceMonitorDeployment(s)
 }
 
-// Creates or Updates Service Monitor Deployment according to the template
+// params returns the parameters of the blackbox exporter probing the target with the module
+func (t Target) params(module string) map[string][]string {
+	if t.Module != "" {
+		module = t.Module
+	}
+	return map[string][]string{
+		"module": {module},
+		"target": {t.URL},
+	}
+}
 
-func (u *ServiceMonitor) UpdateServiceMonitorDeployment(template monitoringv1.ServiceMonitor) error {
+// Creates or Updates Service Monitor Deployment according to the template and returns whether it was created or updated
+
+func (u *ServiceMonitor) UpdateServiceMonitorDeployment(template monitoringv1.ServiceMonitor) (controllerutil.OperationResult, error) {
 	namespacedName := types.NamespacedName{Name: template.Name, Namespace: template.Namespace}
 	deployedServiceMonitor := &monitoringv1.ServiceMonitor{}
 	err := u.Client.Get(u.Ctx, namespacedName, deployedServiceMonitor)
 	if err != nil {
 		// No similar ServiceMonitor exists
 		if !k8serrors.IsNotFound(err) {
-			return err
+			return controllerutil.OperationResultNone, err
+		}
+		if err := u.Client.Create(u.Ctx, &template); err != nil {
+			return controllerutil.OperationResultNone, err
 		}
-		return u.Client.Create(u.Ctx, &template)
+		return controllerutil.OperationResultCreated, nil
 	}
 	if !u.Comparer.DeepEqual(deployedServiceMonitor.Spec, template.Spec) {
 		// Update existing ServiceMonitor for the case that the template changed
 		deployedServiceMonitor.Spec = template.Spec
-		return u.Client.Update(u.Ctx, deployedServiceMonitor)
+		if err := u.Client.Update(u.Ctx, deployedServiceMonitor); err != nil {
+			return controllerutil.OperationResultNone, err
+		}
+		return controllerutil.OperationResultUpdated, nil
 	}
-	return nil
+	return controllerutil.OperationResultNone, nil
 }
 
-// Creates or Updates Service Monitor Deployment according to the template if enable of the hypershift
-func (u *ServiceMonitor) HypershiftUpdateServiceMonitorDeployment(template rhobsv1.ServiceMonitor) error {
+// Creates or Updates Service Monitor Deployment according to the template if enable of the hypershift and returns whether it was created or updated
+func (u *ServiceMonitor) HypershiftUpdateServiceMonitorDeployment(template rhobsv1.ServiceMonitor) (controllerutil.OperationResult, error) {
 	namespacedName := types.NamespacedName{Name: template.Name, Namespace: template.Namespace}
 	deployedServiceMonitor := &rhobsv1.ServiceMonitor{}
 	err := u.Client.Get(u.Ctx, namespacedName, deployedServiceMonitor)
 	if err != nil {
 		// No similar ServiceMonitor exists
 		if !k8serrors.IsNotFound(err) {
-			return err
+			return controllerutil.OperationResultNone, err
+		}
+		if err := u.Client.Create(u.Ctx, &template); err != nil {
+			return controllerutil.OperationResultNone, err
 		}
-		return u.Client.Create(u.Ctx, &template)
+		return controllerutil.OperationResultCreated, nil
 	}
 	if !u.Comparer.DeepEqual(deployedServiceMonitor.Spec, template.Spec) {
 		// Update existing ServiceMonitor for the case that the template changed
 		deployedServiceMonitor.Spec = template.Spec
-		return u.Client.Update(u.Ctx, deployedServiceMonitor)
+		if err := u.Client.Update(u.Ctx, deployedServiceMonitor); err != nil {
+			return controllerutil.OperationResultNone, err
+		}
+		return controllerutil.OperationResultUpdated, nil
 	}
-	return nil
+	return controllerutil.OperationResultNone, nil
 }
 
-// Deletes the ServiceMonitor Deployment
-func (u *ServiceMonitor) DeleteServiceMonitorDeployment(serviceMonitorRef v1alpha1.NamespacedName, isHCPMonitor bool) error {
+// DeleteServiceMonitorDeployment deletes the ServiceMonitor and returns whether it existed
+func (u *ServiceMonitor) DeleteServiceMonitorDeployment(serviceMonitorRef v1alpha1.NamespacedName, isHCPMonitor bool) (bool, error) {
 	if serviceMonitorRef == (v1alpha1.NamespacedName{}) {
-		return nil
+		return false, nil
 	}
 	namespacedName := types.NamespacedName{Name: serviceMonitorRef.Name, Namespace: serviceMonitorRef.Namespace}
 
+	var resource client.Object = &monitoringv1.ServiceMonitor{}
 	if isHCPMonitor {
-		resource := &rhobsv1.ServiceMonitor{}
-		// Does the resource already exist?
-		err := u.Client.Get(u.Ctx, namespacedName, resource)
-		if err != nil {
-			if !k8serrors.IsNotFound(err) {
-				// If this is an unknown error
-				return err
-			}
-			// Resource doesn't exist, nothing to do
-			return nil
-		}
-
-		return u.Client.Delete(u.Ctx, resource)
+		resource = &rhobsv1.ServiceMonitor{}
 	}
-	resource := &monitoringv1.ServiceMonitor{}
 	// Does the resource already exist?
 	err := u.Client.Get(u.Ctx, namespacedName, resource)
 	if err != nil {
 		if !k8serrors.IsNotFound(err) {
 			// If this is an unknown error
-			return err
+			return false, err
 		}
 		// Resource doesn't exist, nothing to do
-		return nil
+		return false, nil
 	}
+	if err := u.Client.Delete(u.Ctx, resource); err != nil {
+		return false, err
+	}
+	return true, nil
+}
 
-	return u.Client.Delete(u.Ctx, resource)
+// ServiceMonitorExists returns whether the ServiceMonitor referenced by a namespaced name exists
+func (u *ServiceMonitor) ServiceMonitorExists(serviceMonitorRef v1alpha1.NamespacedName, isHCPMonitor bool) (bool, error) {
+	namespacedName := types.NamespacedName{Name: serviceMonitorRef.Name, Namespace: serviceMonitorRef.Namespace}
+	var resource client.Object = &monitoringv1.ServiceMonitor{}
+	if isHCPMonitor {
+		resource = &rhobsv1.ServiceMonitor{}
+	}
+	err := u.Client.Get(u.Ctx, namespacedName, resource)
+	if k8serrors.IsNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
 }
 
-// TemplateForServiceMonitorResource returns a ServiceMonitor
-func (u *ServiceMonitor) TemplateForServiceMonitorResource(routeURL, blackBoxExporterNamespace string, params map[string][]string, namespacedName types.NamespacedName, clusterID string, owner *metav1.OwnerReference) monitoringv1.ServiceMonitor {
+// TemplateForServiceMonitorResource returns a ServiceMonitor scraping the Service of the blackbox exporter
+func (u *ServiceMonitor) TemplateForServiceMonitorResource(routeURL string, blackBoxExporter types.NamespacedName, module string, targets []Target, namespacedName types.NamespacedName, clusterID string, owner *metav1.OwnerReference) monitoringv1.ServiceMonitor {
+	endpoints := []monitoringv1.Endpoint{}
+	for _, target := range targets {
+		relabelConfigs := []*monitoringv1.RelabelConfig{
+			{
+				Replacement: routeURL,
+				TargetLabel: UrlLabelName,
+			},
+			{
+				Replacement: clusterID,
+				TargetLabel: "_id",
+			},
+		}
+		if target.Router != "" {
+			relabelConfigs = append(relabelConfigs, &monitoringv1.RelabelConfig{
+				Replacement: target.Router,
+				TargetLabel: RouterLabelName,
+			})
+		}
+		if target.Location != "" {
+			relabelConfigs = append(relabelConfigs, &monitoringv1.RelabelConfig{
+				Replacement: target.Location,
+				TargetLabel: LocationLabelName,
+			})
+		}
+		scheme, address := target.exporter()
+		var targetRelabelConfigs []*monitoringv1.RelabelConfig
+		if address != "" {
+			// every replica of the selected exporter turns into the same target once the pod labels are dropped,
+			// so the remote exporter is scraped once
+			targetRelabelConfigs = []*monitoringv1.RelabelConfig{
+				{
+					Action: "labeldrop",
+					Regex:  "pod|container",
+				},
+				{
+					Replacement: address,
+					TargetLabel: "__address__",
+				},
+			}
+		}
+		endpoints = append(endpoints, monitoringv1.Endpoint{
+			Port: blackboxexporter.BlackBoxExporterPortName,
+			// Probe every 30s
+			Interval: monitoringv1.Duration(ServiceMonitorPeriod),
+			// Timeout has to be smaller than probe interval
+			ScrapeTimeout:        "15s",
+			Path:                 "/probe",
+			Scheme:               scheme,
+			Params:               target.params(module),
+			RelabelConfigs:       targetRelabelConfigs,
+			MetricRelabelConfigs: relabelConfigs,
+		})
+	}
 	return monitoringv1.ServiceMonitor{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:            namespacedName.Name,
@@ -139,41 +286,74 @@ func (u *ServiceMonitor) TemplateForServiceMonitorResource(routeURL, blackBoxExp
 			OwnerReferences: []metav1.OwnerReference{*owner},
 		},
 		Spec: monitoringv1.ServiceMonitorSpec{
-			Endpoints: []monitoringv1.Endpoint{
-				{
-					Port: blackboxexporter.BlackBoxExporterPortName,
-					// Probe every 30s
-					Interval: monitoringv1.Duration(ServiceMonitorPeriod),
-					// Timeout has to be smaller than probe interval
-					ScrapeTimeout: "15s",
-					Path:          "/probe",
-					Scheme:        "http",
-					Params:        params,
-					MetricRelabelConfigs: []*monitoringv1.RelabelConfig{
-						{
-							Replacement: routeURL,
-							TargetLabel: UrlLabelName,
-						},
-						{
-							Replacement: clusterID,
-							TargetLabel: "_id",
-						},
-					},
-				}},
+			Endpoints: endpoints,
 			Selector: metav1.LabelSelector{
-				MatchLabels: blackboxexporter.GenerateBlackBoxExporterLables(),
+				MatchLabels: blackboxexporter.LabelsFor(blackBoxExporter.Name),
 			},
 			NamespaceSelector: monitoringv1.NamespaceSelector{
 				MatchNames: []string{
-					blackBoxExporterNamespace,
+					blackBoxExporter.Namespace,
 				},
 			},
 		},
 	}
 }
 
-// HyperShiftTemplateForServiceMonitorResource returns a ServiceMonitor for Hypershift
-func (u *ServiceMonitor) HyperShiftTemplateForServiceMonitorResource(routeURL, blackBoxExporterNamespace string, params map[string][]string, namespacedName types.NamespacedName, clusterID string, owner *metav1.OwnerReference) rhobsv1.ServiceMonitor {
+// HyperShiftTemplateForServiceMonitorResource returns a ServiceMonitor for Hypershift scraping the Service of the blackbox exporter
+func (u *ServiceMonitor) HyperShiftTemplateForServiceMonitorResource(routeURL string, blackBoxExporter types.NamespacedName, module string, targets []Target, namespacedName types.NamespacedName, clusterID string, owner *metav1.OwnerReference) rhobsv1.ServiceMonitor {
+	endpoints := []rhobsv1.Endpoint{}
+	for _, target := range targets {
+		relabelConfigs := []*rhobsv1.RelabelConfig{
+			{
+				Replacement: routeURL,
+				TargetLabel: UrlLabelName,
+			},
+			{
+				Replacement: clusterID,
+				TargetLabel: "_id",
+			},
+		}
+		if target.Router != "" {
+			relabelConfigs = append(relabelConfigs, &rhobsv1.RelabelConfig{
+				Replacement: target.Router,
+				TargetLabel: RouterLabelName,
+			})
+		}
+		if target.Location != "" {
+			relabelConfigs = append(relabelConfigs, &rhobsv1.RelabelConfig{
+				Replacement: target.Location,
+				TargetLabel: LocationLabelName,
+			})
+		}
+		scheme, address := target.exporter()
+		var targetRelabelConfigs []*rhobsv1.RelabelConfig
+		if address != "" {
+			// every replica of the selected exporter turns into the same target once the pod labels are dropped,
+			// so the remote exporter is scraped once
+			targetRelabelConfigs = []*rhobsv1.RelabelConfig{
+				{
+					Action: "labeldrop",
+					Regex:  "pod|container",
+				},
+				{
+					Replacement: address,
+					TargetLabel: "__address__",
+				},
+			}
+		}
+		endpoints = append(endpoints, rhobsv1.Endpoint{
+			Port: blackboxexporter.BlackBoxExporterPortName,
+			// Probe every 30s
+			Interval: rhobsv1.Duration(ServiceMonitorPeriod),
+			// Timeout has to be smaller than probe interval
+			ScrapeTimeout:        "15s",
+			Path:                 "/probe",
+			Scheme:               scheme,
+			Params:               target.params(module),
+			RelabelConfigs:       targetRelabelConfigs,
+			MetricRelabelConfigs: relabelConfigs,
+		})
+	}
 	return rhobsv1.ServiceMonitor{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:            namespacedName.Name,
@@ -181,33 +361,13 @@ func (u *ServiceMonitor) HyperShiftTemplateForServiceMonitorResource(routeURL, b
 			OwnerReferences: []metav1.OwnerReference{*owner},
 		},
 		Spec: rhobsv1.ServiceMonitorSpec{
-			Endpoints: []rhobsv1.Endpoint{
-				{
-					Port: blackboxexporter.BlackBoxExporterPortName,
-					// Probe every 30s
-					Interval: rhobsv1.Duration(ServiceMonitorPeriod),
-					// Timeout has to be smaller than probe interval
-					ScrapeTimeout: "15s",
-					Path:          "/probe",
-					Scheme:        "http",
-					Params:        params,
-					MetricRelabelConfigs: []*rhobsv1.RelabelConfig{
-						{
-							Replacement: routeURL,
-							TargetLabel: UrlLabelName,
-						},
-						{
-							Replacement: clusterID,
-							TargetLabel: "_id",
-						},
-					},
-				}},
+			Endpoints: endpoints,
 			Selector: metav1.LabelSelector{
-				MatchLabels: blackboxexporter.GenerateBlackBoxExporterLables(),
+				MatchLabels: blackboxexporter.LabelsFor(blackBoxExporter.Name),
 			},
 			NamespaceSelector: rhobsv1.NamespaceSelector{
 				MatchNames: []string{
-					blackBoxExporterNamespace,
+					blackBoxExporter.Namespace,
 				},
 			},
 		},
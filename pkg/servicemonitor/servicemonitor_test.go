@@ -8,6 +8,7 @@ import (
 	"go.uber.org/mock/gomock"
 
 	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	"github.com/openshift/route-monitor-operator/pkg/consts/blackboxexporter"
 	consterror "github.com/openshift/route-monitor-operator/pkg/consts/test/error"
 	"github.com/openshift/route-monitor-operator/pkg/servicemonitor"
 
@@ -18,6 +19,7 @@ import (
 	rhobsv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 type ResourceComparerMockHelper struct {
@@ -40,6 +42,8 @@ var _ = Describe("CR Deployment Handling", func() {
 		serviceMonitorRef v1alpha1.NamespacedName
 		serviceMonitor    monitoringv1.ServiceMonitor
 		sm                servicemonitor.ServiceMonitor
+		result            controllerutil.OperationResult
+		deleted           bool
 		err               error
 	)
 	BeforeEach(func() {
@@ -93,7 +97,7 @@ var _ = Describe("CR Deployment Handling", func() {
 			get.CalledTimes = 1
 		})
 		JustBeforeEach(func() {
-			err = sm.UpdateServiceMonitorDeployment(serviceMonitor)
+			result, err = sm.UpdateServiceMonitorDeployment(serviceMonitor)
 		})
 		When("The Client failed to fetch existing deployments", func() {
 			BeforeEach(func() {
@@ -110,6 +114,7 @@ var _ = Describe("CR Deployment Handling", func() {
 			})
 			It("tryies to creates one", func() {
 				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(controllerutil.OperationResultCreated))
 			})
 			When("an error appeared during the creation", func() {
 				BeforeEach(func() {
@@ -131,6 +136,7 @@ var _ = Describe("CR Deployment Handling", func() {
 				})
 				It("updates the existing deployment", func() {
 					Expect(err).NotTo(HaveOccurred())
+					Expect(result).To(Equal(controllerutil.OperationResultUpdated))
 				})
 				When("The Client failed to update the existing deployments", func() {
 					BeforeEach(func() {
@@ -147,13 +153,14 @@ var _ = Describe("CR Deployment Handling", func() {
 				})
 				It("does nothing", func() {
 					Expect(err).NotTo(HaveOccurred())
+					Expect(result).To(Equal(controllerutil.OperationResultNone))
 				})
 			})
 		})
 	})
 	Describe("DeleteServiceMonitorDeployment", func() {
 		JustBeforeEach(func() {
-			err = sm.DeleteServiceMonitorDeployment(serviceMonitorRef, false)
+			deleted, err = sm.DeleteServiceMonitorDeployment(serviceMonitorRef, false)
 		})
 		When("The ServiceMonitorRef is not set", func() {
 			BeforeEach(func() {
@@ -182,6 +189,7 @@ var _ = Describe("CR Deployment Handling", func() {
 				})
 				It("does nothing", func() {
 					Expect(err).NotTo(HaveOccurred())
+					Expect(deleted).To(BeFalse())
 				})
 			})
 			When("the ServiceMonitorDeployment exists", func() {
@@ -190,6 +198,7 @@ var _ = Describe("CR Deployment Handling", func() {
 				})
 				It("deletes the Deployment", func() {
 					Expect(err).NotTo(HaveOccurred())
+					Expect(deleted).To(BeTrue())
 				})
 				When("the client failed to delete the deployment", func() {
 					BeforeEach(func() {
@@ -203,6 +212,41 @@ var _ = Describe("CR Deployment Handling", func() {
 		})
 	})
 
+	Describe("ServiceMonitorExists", func() {
+		var exists bool
+		BeforeEach(func() {
+			serviceMonitorRef = v1alpha1.NamespacedName{Name: "test", Namespace: "test"}
+			get.CalledTimes = 1
+		})
+		JustBeforeEach(func() {
+			exists, err = sm.ServiceMonitorExists(serviceMonitorRef, false)
+		})
+		When("the client failed to fetch the ServiceMonitor", func() {
+			BeforeEach(func() {
+				get.ErrorResponse = consterror.ErrCustomError
+			})
+			It("returns the received error", func() {
+				Expect(err).To(Equal(consterror.ErrCustomError))
+				Expect(exists).To(BeFalse())
+			})
+		})
+		When("the ServiceMonitor doesnt exist", func() {
+			BeforeEach(func() {
+				get.ErrorResponse = consterror.NotFoundErr
+			})
+			It("returns false", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(exists).To(BeFalse())
+			})
+		})
+		When("the ServiceMonitor exists", func() {
+			It("returns true", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(exists).To(BeTrue())
+			})
+		})
+	})
+
 	Describe("NewServiceMonitor", func() {
 		It("should create a ServiceMonitor with correct properties", func() {
 			sm := servicemonitor.NewServiceMonitor(context.Background(), mockClient)
@@ -213,13 +257,13 @@ var _ = Describe("CR Deployment Handling", func() {
 
 	Describe("TemplateAndUpdateServiceMonitorDeployment", func() {
 		var (
-			routeURL                  = "https://example.com"
-			blackBoxExporterNamespace = "test-namespace"
-			namespacedName            = serviceMonitorRef
-			clusterID                 = "test-cluster"
-			isHCPMonitor              = false
-			useInsecure               = false
-			owner                     *metav1.OwnerReference
+			routeURL         = "https://example.com"
+			blackBoxExporter = types.NamespacedName{Name: blackboxexporter.BlackBoxExporterName, Namespace: "test-namespace"}
+			namespacedName   = serviceMonitorRef
+			clusterID        = "test-cluster"
+			isHCPMonitor     = false
+			module           = blackboxexporter.DefaultModule
+			owner            *metav1.OwnerReference
 		)
 
 		BeforeEach(func() {
@@ -239,7 +283,7 @@ var _ = Describe("CR Deployment Handling", func() {
 			})
 			It("should use regular ServiceMonitor template", func() {
 				nsName := types.NamespacedName{Name: namespacedName.Name, Namespace: namespacedName.Namespace}
-				err := sm.TemplateAndUpdateServiceMonitorDeployment(routeURL, blackBoxExporterNamespace, nsName, clusterID, isHCPMonitor, useInsecure, owner)
+				_, err := sm.TemplateAndUpdateServiceMonitorDeployment(routeURL, []servicemonitor.Target{{URL: routeURL}}, blackBoxExporter, nsName, clusterID, isHCPMonitor, module, owner)
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
@@ -253,21 +297,21 @@ var _ = Describe("CR Deployment Handling", func() {
 			})
 			It("should use HyperShift ServiceMonitor template", func() {
 				nsName := types.NamespacedName{Name: namespacedName.Name, Namespace: namespacedName.Namespace}
-				err := sm.TemplateAndUpdateServiceMonitorDeployment(routeURL, blackBoxExporterNamespace, nsName, clusterID, isHCPMonitor, useInsecure, owner)
+				_, err := sm.TemplateAndUpdateServiceMonitorDeployment(routeURL, []servicemonitor.Target{{URL: routeURL}}, blackBoxExporter, nsName, clusterID, isHCPMonitor, module, owner)
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
 
-		When("useInsecure is true", func() {
+		When("a custom module is used", func() {
 			BeforeEach(func() {
-				useInsecure = true
+				module = "http_0123456789"
 				get.CalledTimes = 1
 				get.ErrorResponse = consterror.NotFoundErr
 				create.CalledTimes = 1
 			})
-			It("should use insecure module", func() {
+			It("should use the custom module", func() {
 				nsName := types.NamespacedName{Name: namespacedName.Name, Namespace: namespacedName.Namespace}
-				err := sm.TemplateAndUpdateServiceMonitorDeployment(routeURL, blackBoxExporterNamespace, nsName, clusterID, isHCPMonitor, useInsecure, owner)
+				_, err := sm.TemplateAndUpdateServiceMonitorDeployment(routeURL, []servicemonitor.Target{{URL: routeURL}}, blackBoxExporter, nsName, clusterID, isHCPMonitor, module, owner)
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
@@ -292,8 +336,9 @@ var _ = Describe("CR Deployment Handling", func() {
 				create.CalledTimes = 1
 			})
 			It("should create a new ServiceMonitor", func() {
-				err := sm.HypershiftUpdateServiceMonitorDeployment(template)
+				result, err := sm.HypershiftUpdateServiceMonitorDeployment(template)
 				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(controllerutil.OperationResultCreated))
 			})
 		})
 
@@ -302,7 +347,7 @@ var _ = Describe("CR Deployment Handling", func() {
 				get.ErrorResponse = consterror.ErrCustomError
 			})
 			It("should return the error", func() {
-				err := sm.HypershiftUpdateServiceMonitorDeployment(template)
+				_, err := sm.HypershiftUpdateServiceMonitorDeployment(template)
 				Expect(err).To(Equal(consterror.ErrCustomError))
 			})
 		})
@@ -314,7 +359,7 @@ var _ = Describe("CR Deployment Handling", func() {
 				update.CalledTimes = 1
 			})
 			It("should update the ServiceMonitor", func() {
-				err := sm.HypershiftUpdateServiceMonitorDeployment(template)
+				_, err := sm.HypershiftUpdateServiceMonitorDeployment(template)
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
@@ -323,7 +368,7 @@ var _ = Describe("CR Deployment Handling", func() {
 	Describe("TemplateForServiceMonitorResource", func() {
 		It("should create a properly configured ServiceMonitor", func() {
 			routeURL := "https://example.com"
-			blackBoxExporterNamespace := "test-namespace"
+			blackBoxExporter := types.NamespacedName{Name: blackboxexporter.BlackBoxExporterName, Namespace: "test-namespace"}
 			params := map[string][]string{"module": {"http_2xx"}, "target": {routeURL}}
 			namespacedName := types.NamespacedName{Name: "test", Namespace: "test"}
 			clusterID := "test-cluster"
@@ -333,20 +378,73 @@ var _ = Describe("CR Deployment Handling", func() {
 				Name:       "test-owner",
 			}
 
-			result := sm.TemplateForServiceMonitorResource(routeURL, blackBoxExporterNamespace, params, namespacedName, clusterID, owner)
+			result := sm.TemplateForServiceMonitorResource(routeURL, blackBoxExporter, "http_2xx", []servicemonitor.Target{{URL: routeURL}}, namespacedName, clusterID, owner)
 
 			Expect(result.Name).To(Equal("test"))
 			Expect(result.Namespace).To(Equal("test"))
 			Expect(result.OwnerReferences).To(HaveLen(1))
 			Expect(result.Spec.Endpoints).To(HaveLen(1))
 			Expect(result.Spec.Endpoints[0].Params).To(Equal(params))
+			Expect(result.Spec.Endpoints[0].MetricRelabelConfigs).To(HaveLen(2))
+			Expect(result.Spec.Selector.MatchLabels).To(Equal(blackboxexporter.GenerateBlackBoxExporterLables()))
+			Expect(result.Spec.NamespaceSelector.MatchNames).To(Equal([]string{"test-namespace"}))
+		})
+		It("should select the exporter of a BlackboxExporterPool", func() {
+			routeURL := "https://example.com"
+			pool := types.NamespacedName{Name: blackboxexporter.PoolExporterName("private"), Namespace: "private-probes"}
+			owner := &metav1.OwnerReference{Name: "test-owner"}
+
+			result := sm.TemplateForServiceMonitorResource(routeURL, pool, "http_2xx", []servicemonitor.Target{{URL: routeURL}}, types.NamespacedName{Name: "test", Namespace: "test"}, "test-cluster", owner)
+
+			Expect(result.Spec.Selector.MatchLabels).To(Equal(map[string]string{"app": "blackbox-exporter-private"}))
+			Expect(result.Spec.NamespaceSelector.MatchNames).To(Equal([]string{"private-probes"}))
+		})
+		It("should probe every target by a separate endpoint labeled with its router", func() {
+			routeURL := "https://example.com"
+			targets := []servicemonitor.Target{
+				{URL: routeURL, Router: "default"},
+				{URL: "https://example.private.com", Router: "private"},
+			}
+			owner := &metav1.OwnerReference{Name: "test-owner"}
+
+			result := sm.TemplateForServiceMonitorResource(routeURL, types.NamespacedName{Name: blackboxexporter.BlackBoxExporterName, Namespace: "test-namespace"}, "http_2xx", targets, types.NamespacedName{Name: "test", Namespace: "test"}, "test-cluster", owner)
+
+			Expect(result.Spec.Endpoints).To(HaveLen(2))
+			Expect(result.Spec.Endpoints[1].Params["target"]).To(Equal([]string{"https://example.private.com"}))
+			Expect(result.Spec.Endpoints[1].MetricRelabelConfigs).To(ContainElement(&monitoringv1.RelabelConfig{Replacement: "https://example.com", TargetLabel: servicemonitor.UrlLabelName}))
+			Expect(result.Spec.Endpoints[1].MetricRelabelConfigs).To(ContainElement(&monitoringv1.RelabelConfig{Replacement: "private", TargetLabel: servicemonitor.RouterLabelName}))
+		})
+		It("should probe the targets from every location", func() {
+			routeURL := "https://example.com"
+			locations := &v1alpha1.ProbeLocationsSpec{
+				Locations: []v1alpha1.ProbeLocation{
+					{Name: "cluster", Type: v1alpha1.ProbeLocationInCluster},
+					{Name: "eu-west-1", Type: v1alpha1.ProbeLocationRemoteExporter, URL: "https://blackbox.eu-west-1.example.com:9115"},
+				},
+			}
+			targets := servicemonitor.WithLocations([]servicemonitor.Target{{URL: routeURL}}, locations)
+			owner := &metav1.OwnerReference{Name: "test-owner"}
+
+			result := sm.TemplateForServiceMonitorResource(routeURL, types.NamespacedName{Name: blackboxexporter.BlackBoxExporterName, Namespace: "test-namespace"}, "custom-module", targets, types.NamespacedName{Name: "test", Namespace: "test"}, "test-cluster", owner)
+
+			Expect(result.Spec.Endpoints).To(HaveLen(2))
+			inCluster, remote := result.Spec.Endpoints[0], result.Spec.Endpoints[1]
+			Expect(inCluster.Scheme).To(Equal("http"))
+			Expect(inCluster.Params["module"]).To(Equal([]string{"custom-module"}))
+			Expect(inCluster.RelabelConfigs).To(BeEmpty())
+			Expect(inCluster.MetricRelabelConfigs).To(ContainElement(&monitoringv1.RelabelConfig{Replacement: "cluster", TargetLabel: servicemonitor.LocationLabelName}))
+			Expect(remote.Scheme).To(Equal("https"))
+			Expect(remote.Params["module"]).To(Equal([]string{"http_2xx"}))
+			Expect(remote.RelabelConfigs).To(ContainElement(&monitoringv1.RelabelConfig{Replacement: "blackbox.eu-west-1.example.com:9115", TargetLabel: "__address__"}))
+			Expect(remote.RelabelConfigs).To(ContainElement(&monitoringv1.RelabelConfig{Action: "labeldrop", Regex: "pod|container"}))
+			Expect(remote.MetricRelabelConfigs).To(ContainElement(&monitoringv1.RelabelConfig{Replacement: "eu-west-1", TargetLabel: servicemonitor.LocationLabelName}))
 		})
 	})
 
 	Describe("HyperShiftTemplateForServiceMonitorResource", func() {
 		It("should create a properly configured HyperShift ServiceMonitor", func() {
 			routeURL := "https://example.com"
-			blackBoxExporterNamespace := "test-namespace"
+			blackBoxExporter := types.NamespacedName{Name: blackboxexporter.BlackBoxExporterName, Namespace: "test-namespace"}
 			params := map[string][]string{"module": {"http_2xx"}, "target": {routeURL}}
 			namespacedName := types.NamespacedName{Name: "test", Namespace: "test"}
 			clusterID := "test-cluster"
@@ -356,7 +454,7 @@ var _ = Describe("CR Deployment Handling", func() {
 				Name:       "test-owner",
 			}
 
-			result := sm.HyperShiftTemplateForServiceMonitorResource(routeURL, blackBoxExporterNamespace, params, namespacedName, clusterID, owner)
+			result := sm.HyperShiftTemplateForServiceMonitorResource(routeURL, blackBoxExporter, "http_2xx", []servicemonitor.Target{{URL: routeURL}}, namespacedName, clusterID, owner)
 
 			Expect(result.Name).To(Equal("test"))
 			Expect(result.Namespace).To(Equal("test"))
@@ -0,0 +1,99 @@
+package conditions
+
+import (
+	"strings"
+
+	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Object is a monitor reporting its progress in conditions
+type Object interface {
+	metav1.Object
+	GetConditions() []metav1.Condition
+	SetConditions([]metav1.Condition)
+}
+
+// stepConditions are the conditions set by the reconcile steps, Degraded and Ready are derived from them
+var stepConditions = []string{
+	v1alpha1.ConditionRouteResolved,
+	v1alpha1.ConditionServiceMonitorReady,
+	v1alpha1.ConditionPrometheusRuleReady,
+}
+
+// MarkTrue sets the condition to true, see Set
+func MarkTrue(object Object, conditionType, reason string) bool {
+	return Set(object, conditionType, metav1.ConditionTrue, reason, "")
+}
+
+// MarkFalse sets the condition to false with the error as message, see Set
+func MarkFalse(object Object, conditionType, reason string, err error) bool {
+	return Set(object, conditionType, metav1.ConditionFalse, reason, err.Error())
+}
+
+// Set sets the condition for the current generation of the object and derives the Degraded and Ready conditions.
+// It returns whether the conditions changed, so the status has to be updated
+func Set(object Object, conditionType string, status metav1.ConditionStatus, reason, message string) bool {
+	// the conditions are copied, as the object might be a copy sharing them with the caller
+	conditions := append([]metav1.Condition{}, object.GetConditions()...)
+	generation := object.GetGeneration()
+
+	changed := meta.SetStatusCondition(&conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: generation,
+	})
+	for _, condition := range derive(conditions, generation) {
+		if meta.SetStatusCondition(&conditions, condition) {
+			changed = true
+		}
+	}
+
+	if changed {
+		object.SetConditions(conditions)
+	}
+	return changed
+}
+
+// derive returns the Degraded and Ready conditions for the step conditions
+func derive(conditions []metav1.Condition, generation int64) []metav1.Condition {
+	degraded := metav1.Condition{
+		Type:               v1alpha1.ConditionDegraded,
+		Status:             metav1.ConditionFalse,
+		Reason:             v1alpha1.ReasonAsExpected,
+		ObservedGeneration: generation,
+	}
+	ready := metav1.Condition{
+		Type:               v1alpha1.ConditionReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             v1alpha1.ReasonReconciled,
+		ObservedGeneration: generation,
+	}
+
+	pending := []string{}
+	for _, conditionType := range stepConditions {
+		condition := meta.FindStatusCondition(conditions, conditionType)
+		if condition != nil && condition.Status == metav1.ConditionFalse && degraded.Status == metav1.ConditionFalse {
+			degraded.Status = metav1.ConditionTrue
+			degraded.Reason = condition.Reason
+			degraded.Message = conditionType + ": " + condition.Message
+		}
+		// a condition set for a previous generation does not reflect the current spec yet
+		if condition == nil || condition.Status != metav1.ConditionTrue || condition.ObservedGeneration != generation {
+			pending = append(pending, conditionType)
+		}
+	}
+
+	if len(pending) > 0 {
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = v1alpha1.ReasonProgressing
+		if degraded.Status == metav1.ConditionTrue {
+			ready.Reason = v1alpha1.ConditionDegraded
+		}
+		ready.Message = "waiting for " + strings.Join(pending, ", ")
+	}
+	return []metav1.Condition{degraded, ready}
+}
@@ -0,0 +1,102 @@
+package conditions_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	. "github.com/openshift/route-monitor-operator/pkg/util/conditions"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Conditions", func() {
+	var (
+		routeMonitor v1alpha1.RouteMonitor
+	)
+	BeforeEach(func() {
+		routeMonitor = v1alpha1.RouteMonitor{ObjectMeta: metav1.ObjectMeta{Generation: 2}}
+	})
+
+	markAllTrue := func() {
+		MarkTrue(&routeMonitor, v1alpha1.ConditionRouteResolved, v1alpha1.ReasonReconciled)
+		MarkTrue(&routeMonitor, v1alpha1.ConditionServiceMonitorReady, v1alpha1.ReasonReconciled)
+		MarkTrue(&routeMonitor, v1alpha1.ConditionPrometheusRuleReady, v1alpha1.ReasonNotRequired)
+	}
+
+	Describe("Set", func() {
+		It("should set the condition and the observed generation", func() {
+			Expect(MarkTrue(&routeMonitor, v1alpha1.ConditionRouteResolved, v1alpha1.ReasonReconciled)).To(BeTrue())
+			condition := meta.FindStatusCondition(routeMonitor.Status.Conditions, v1alpha1.ConditionRouteResolved)
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.ObservedGeneration).To(Equal(int64(2)))
+			Expect(routeMonitor.Status.ObservedGeneration).To(Equal(int64(2)))
+		})
+		It("should report no change when the condition is already set", func() {
+			MarkTrue(&routeMonitor, v1alpha1.ConditionRouteResolved, v1alpha1.ReasonReconciled)
+			Expect(MarkTrue(&routeMonitor, v1alpha1.ConditionRouteResolved, v1alpha1.ReasonReconciled)).To(BeFalse())
+		})
+		It("should report a change for a new generation", func() {
+			MarkTrue(&routeMonitor, v1alpha1.ConditionRouteResolved, v1alpha1.ReasonReconciled)
+			routeMonitor.Generation = 3
+			Expect(MarkTrue(&routeMonitor, v1alpha1.ConditionRouteResolved, v1alpha1.ReasonReconciled)).To(BeTrue())
+			Expect(routeMonitor.Status.ObservedGeneration).To(Equal(int64(3)))
+		})
+		It("should not modify the conditions of a copy", func() {
+			markAllTrue()
+			routeMonitorCopy := routeMonitor
+			MarkFalse(&routeMonitorCopy, v1alpha1.ConditionRouteResolved, v1alpha1.ReasonReconcileFailed, errors.New("not found"))
+			Expect(meta.IsStatusConditionTrue(routeMonitor.Status.Conditions, v1alpha1.ConditionRouteResolved)).To(BeTrue())
+		})
+	})
+
+	Describe("Ready and Degraded", func() {
+		When("not all conditions are set yet", func() {
+			BeforeEach(func() {
+				MarkTrue(&routeMonitor, v1alpha1.ConditionRouteResolved, v1alpha1.ReasonReconciled)
+			})
+			It("should be progressing", func() {
+				ready := meta.FindStatusCondition(routeMonitor.Status.Conditions, v1alpha1.ConditionReady)
+				Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+				Expect(ready.Reason).To(Equal(v1alpha1.ReasonProgressing))
+				Expect(ready.Message).To(Equal("waiting for ServiceMonitorReady, PrometheusRuleReady"))
+				Expect(meta.IsStatusConditionFalse(routeMonitor.Status.Conditions, v1alpha1.ConditionDegraded)).To(BeTrue())
+			})
+		})
+		When("all conditions are true", func() {
+			BeforeEach(func() {
+				markAllTrue()
+			})
+			It("should be ready", func() {
+				Expect(meta.IsStatusConditionTrue(routeMonitor.Status.Conditions, v1alpha1.ConditionReady)).To(BeTrue())
+				Expect(meta.IsStatusConditionFalse(routeMonitor.Status.Conditions, v1alpha1.ConditionDegraded)).To(BeTrue())
+			})
+			When("the spec changes", func() {
+				BeforeEach(func() {
+					routeMonitor.Generation = 3
+					MarkTrue(&routeMonitor, v1alpha1.ConditionRouteResolved, v1alpha1.ReasonReconciled)
+				})
+				It("should not be ready until all conditions are set for the new generation", func() {
+					Expect(meta.IsStatusConditionTrue(routeMonitor.Status.Conditions, v1alpha1.ConditionReady)).To(BeFalse())
+				})
+			})
+		})
+		When("a condition is false", func() {
+			BeforeEach(func() {
+				markAllTrue()
+				MarkFalse(&routeMonitor, v1alpha1.ConditionServiceMonitorReady, v1alpha1.ReasonInvalidSpec, errors.New("invalid probe"))
+			})
+			It("should be degraded", func() {
+				degraded := meta.FindStatusCondition(routeMonitor.Status.Conditions, v1alpha1.ConditionDegraded)
+				Expect(degraded.Status).To(Equal(metav1.ConditionTrue))
+				Expect(degraded.Reason).To(Equal(v1alpha1.ReasonInvalidSpec))
+				Expect(degraded.Message).To(Equal("ServiceMonitorReady: invalid probe"))
+				ready := meta.FindStatusCondition(routeMonitor.Status.Conditions, v1alpha1.ConditionReady)
+				Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+				Expect(ready.Reason).To(Equal(v1alpha1.ConditionDegraded))
+			})
+		})
+	})
+})
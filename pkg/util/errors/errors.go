@@ -8,6 +8,22 @@ var (
 	ErrNoHost     = errors.New("no Host: extracted RouteURL is empty")
 	ErrInvalidSLO = errors.New("invalid RawSlo: string cannot be parsed " +
 		"or is not in correct range, or type is not supported")
-	ErrInvalidReferenceUpdate = errors.New("invalid Reference Update: currently the reference cannot be changed in flight, " +
-		"please delete the parent resource and create it in the new name")
+	ErrInvalidLatencySLO = errors.New("invalid latency SLO: target percent or threshold cannot be parsed " +
+		"or is not in correct range, or a phase is set for a probe type other than http")
+	ErrInvalidSLOAlerting = errors.New("invalid SLO alerting: burn rate windows cannot be parsed, " +
+		"the short window is not shorter than the long window, or a window covers less than two probe intervals")
+	ErrInvalidCertificateExpiry = errors.New("invalid certificate expiry: the thresholds are not positive " +
+		"or the critical threshold is not less than the warning threshold")
+	ErrInvalidMaintenanceWindow = errors.New("invalid maintenance window: the schedule of a maintenance window " +
+		"cannot be parsed as cron expression")
+	ErrInvalidProbe = errors.New("invalid probe: a body regular expression cannot be compiled, " +
+		"or settings of another probe type are set")
+	ErrInvalidProbeSecret = errors.New("invalid probe Secret: the Secret referenced by spec.probe.auth does not exist " +
+		"or misses a key required by the auth type")
+	ErrNoPrometheusEndpoint = errors.New("no Prometheus endpoint: the probe status cannot be queried, " +
+		"as no endpoint is configured for the ServiceMonitor type of the monitor")
+	ErrBlackboxExporterPoolNotFound = errors.New("blackbox exporter pool not found: the BlackboxExporterPool referenced by " +
+		"spec.blackboxExporterPool does not exist")
+	ErrReferenceMigrationPending = errors.New("reference migration pending: the new resource does not exist yet, " +
+		"the previous resource is kept until it does")
 )
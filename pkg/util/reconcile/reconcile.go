@@ -17,6 +17,14 @@ func (r Result) ShouldStop() bool {
 	return !r.Continue
 }
 
+// RequeueSooner returns the result requeueing after the duration if that is earlier, a zero duration is ignored
+func (r Result) RequeueSooner(after time.Duration) Result {
+	if after > 0 && (r.RequeueAfter == 0 || after < r.RequeueAfter) {
+		r.RequeueAfter = after
+	}
+	return r
+}
+
 func StopOperation() Result {
 	return Result{}
 }
@@ -110,6 +110,20 @@ var _ = Describe("ReconcileWrapper", func() {
 		})
 	})
 
+	Describe("Result.RequeueSooner", func() {
+		It("should requeue after the earlier duration", func() {
+			result := reconcile.Result{Continue: true, RequeueAfter: 5 * time.Minute}
+			Expect(result.RequeueSooner(time.Minute).RequeueAfter).To(Equal(time.Minute))
+			Expect(result.RequeueSooner(time.Hour).RequeueAfter).To(Equal(5 * time.Minute))
+		})
+
+		It("should ignore a zero duration", func() {
+			result := reconcile.Result{Continue: true}
+			Expect(result.RequeueSooner(0).RequeueAfter).To(BeZero())
+			Expect(result.RequeueSooner(time.Minute).RequeueAfter).To(Equal(time.Minute))
+		})
+	})
+
 	Describe("RequeueReconcile", func() {
 		It("should return Result with Requeue true and no error", func() {
 			result, err := reconcile.RequeueReconcile()
@@ -11,10 +11,11 @@ package controllers
 
 import (
 	reflect "reflect"
+	time "time"
 
 	v1beta1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
 	v1alpha1 "github.com/openshift/route-monitor-operator/api/v1alpha1"
-	blackboxexporter "github.com/openshift/route-monitor-operator/pkg/consts/blackboxexporter"
+	servicemonitor "github.com/openshift/route-monitor-operator/pkg/servicemonitor"
 	reconcile "github.com/openshift/route-monitor-operator/pkg/util/reconcile"
 	v1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	v10 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
@@ -22,6 +23,7 @@ import (
 	v11 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
 	client "sigs.k8s.io/controller-runtime/pkg/client"
+	controllerutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 // MockMonitorResourceHandler is a mock of MonitorResourceHandler interface.
@@ -150,12 +152,11 @@ func (mr *MockMonitorResourceHandlerMockRecorder) SetFinalizer(o, finalizerKey a
 }
 
 // SetResourceReference mocks base method.
-func (m *MockMonitorResourceHandler) SetResourceReference(reference *v1alpha1.NamespacedName, target types.NamespacedName) (bool, error) {
+func (m *MockMonitorResourceHandler) SetResourceReference(reference *v1alpha1.NamespacedName, target types.NamespacedName) bool {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SetResourceReference", reference, target)
 	ret0, _ := ret[0].(bool)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	return ret0
 }
 
 // SetResourceReference indicates an expected call of SetResourceReference.
@@ -218,11 +219,12 @@ func (m *MockServiceMonitorHandler) EXPECT() *MockServiceMonitorHandlerMockRecor
 }
 
 // DeleteServiceMonitorDeployment mocks base method.
-func (m *MockServiceMonitorHandler) DeleteServiceMonitorDeployment(serviceMonitorRef v1alpha1.NamespacedName, hcp bool) error {
+func (m *MockServiceMonitorHandler) DeleteServiceMonitorDeployment(serviceMonitorRef v1alpha1.NamespacedName, hcp bool) (bool, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "DeleteServiceMonitorDeployment", serviceMonitorRef, hcp)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // DeleteServiceMonitorDeployment indicates an expected call of DeleteServiceMonitorDeployment.
@@ -232,11 +234,12 @@ func (mr *MockServiceMonitorHandlerMockRecorder) DeleteServiceMonitorDeployment(
 }
 
 // HypershiftUpdateServiceMonitorDeployment mocks base method.
-func (m *MockServiceMonitorHandler) HypershiftUpdateServiceMonitorDeployment(template v10.ServiceMonitor) error {
+func (m *MockServiceMonitorHandler) HypershiftUpdateServiceMonitorDeployment(template v10.ServiceMonitor) (controllerutil.OperationResult, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "HypershiftUpdateServiceMonitorDeployment", template)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(controllerutil.OperationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // HypershiftUpdateServiceMonitorDeployment indicates an expected call of HypershiftUpdateServiceMonitorDeployment.
@@ -245,26 +248,43 @@ func (mr *MockServiceMonitorHandlerMockRecorder) HypershiftUpdateServiceMonitorD
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HypershiftUpdateServiceMonitorDeployment", reflect.TypeOf((*MockServiceMonitorHandler)(nil).HypershiftUpdateServiceMonitorDeployment), template)
 }
 
+// ServiceMonitorExists mocks base method.
+func (m *MockServiceMonitorHandler) ServiceMonitorExists(serviceMonitorRef v1alpha1.NamespacedName, hcp bool) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ServiceMonitorExists", serviceMonitorRef, hcp)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ServiceMonitorExists indicates an expected call of ServiceMonitorExists.
+func (mr *MockServiceMonitorHandlerMockRecorder) ServiceMonitorExists(serviceMonitorRef, hcp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceMonitorExists", reflect.TypeOf((*MockServiceMonitorHandler)(nil).ServiceMonitorExists), serviceMonitorRef, hcp)
+}
+
 // TemplateAndUpdateServiceMonitorDeployment mocks base method.
-func (m *MockServiceMonitorHandler) TemplateAndUpdateServiceMonitorDeployment(url, blackBoxExporterNamespace string, namespacedName types.NamespacedName, clusterID string, hcp, useInsecure bool, owner *v11.OwnerReference) error {
+func (m *MockServiceMonitorHandler) TemplateAndUpdateServiceMonitorDeployment(url string, targets []servicemonitor.Target, blackBoxExporter, namespacedName types.NamespacedName, clusterID string, hcp bool, module string, owner *v11.OwnerReference) (controllerutil.OperationResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "TemplateAndUpdateServiceMonitorDeployment", url, blackBoxExporterNamespace, namespacedName, clusterID, hcp, useInsecure, owner)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "TemplateAndUpdateServiceMonitorDeployment", url, targets, blackBoxExporter, namespacedName, clusterID, hcp, module, owner)
+	ret0, _ := ret[0].(controllerutil.OperationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // TemplateAndUpdateServiceMonitorDeployment indicates an expected call of TemplateAndUpdateServiceMonitorDeployment.
-func (mr *MockServiceMonitorHandlerMockRecorder) TemplateAndUpdateServiceMonitorDeployment(url, blackBoxExporterNamespace, namespacedName, clusterID, hcp, useInsecure, owner any) *gomock.Call {
+func (mr *MockServiceMonitorHandlerMockRecorder) TemplateAndUpdateServiceMonitorDeployment(url, targets, blackBoxExporter, namespacedName, clusterID, hcp, module, owner any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TemplateAndUpdateServiceMonitorDeployment", reflect.TypeOf((*MockServiceMonitorHandler)(nil).TemplateAndUpdateServiceMonitorDeployment), url, blackBoxExporterNamespace, namespacedName, clusterID, hcp, useInsecure, owner)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TemplateAndUpdateServiceMonitorDeployment", reflect.TypeOf((*MockServiceMonitorHandler)(nil).TemplateAndUpdateServiceMonitorDeployment), url, targets, blackBoxExporter, namespacedName, clusterID, hcp, module, owner)
 }
 
 // UpdateServiceMonitorDeployment mocks base method.
-func (m *MockServiceMonitorHandler) UpdateServiceMonitorDeployment(template v1.ServiceMonitor) error {
+func (m *MockServiceMonitorHandler) UpdateServiceMonitorDeployment(template v1.ServiceMonitor) (controllerutil.OperationResult, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UpdateServiceMonitorDeployment", template)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(controllerutil.OperationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // UpdateServiceMonitorDeployment indicates an expected call of UpdateServiceMonitorDeployment.
@@ -297,11 +317,12 @@ func (m *MockPrometheusRuleHandler) EXPECT() *MockPrometheusRuleHandlerMockRecor
 }
 
 // DeletePrometheusRuleDeployment mocks base method.
-func (m *MockPrometheusRuleHandler) DeletePrometheusRuleDeployment(prometheusRuleRef v1alpha1.NamespacedName) error {
+func (m *MockPrometheusRuleHandler) DeletePrometheusRuleDeployment(prometheusRuleRef v1alpha1.NamespacedName) (bool, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "DeletePrometheusRuleDeployment", prometheusRuleRef)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // DeletePrometheusRuleDeployment indicates an expected call of DeletePrometheusRuleDeployment.
@@ -310,12 +331,28 @@ func (mr *MockPrometheusRuleHandlerMockRecorder) DeletePrometheusRuleDeployment(
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePrometheusRuleDeployment", reflect.TypeOf((*MockPrometheusRuleHandler)(nil).DeletePrometheusRuleDeployment), prometheusRuleRef)
 }
 
+// PrometheusRuleExists mocks base method.
+func (m *MockPrometheusRuleHandler) PrometheusRuleExists(prometheusRuleRef v1alpha1.NamespacedName) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PrometheusRuleExists", prometheusRuleRef)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PrometheusRuleExists indicates an expected call of PrometheusRuleExists.
+func (mr *MockPrometheusRuleHandlerMockRecorder) PrometheusRuleExists(prometheusRuleRef any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrometheusRuleExists", reflect.TypeOf((*MockPrometheusRuleHandler)(nil).PrometheusRuleExists), prometheusRuleRef)
+}
+
 // UpdatePrometheusRuleDeployment mocks base method.
-func (m *MockPrometheusRuleHandler) UpdatePrometheusRuleDeployment(template v1.PrometheusRule) error {
+func (m *MockPrometheusRuleHandler) UpdatePrometheusRuleDeployment(template v1.PrometheusRule) (controllerutil.OperationResult, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UpdatePrometheusRuleDeployment", template)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(controllerutil.OperationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // UpdatePrometheusRuleDeployment indicates an expected call of UpdatePrometheusRuleDeployment.
@@ -324,82 +361,92 @@ func (mr *MockPrometheusRuleHandlerMockRecorder) UpdatePrometheusRuleDeployment(
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePrometheusRuleDeployment", reflect.TypeOf((*MockPrometheusRuleHandler)(nil).UpdatePrometheusRuleDeployment), template)
 }
 
-// MockBlackBoxExporterHandler is a mock of BlackBoxExporterHandler interface.
-type MockBlackBoxExporterHandler struct {
+// MockProbeStatusHandler is a mock of ProbeStatusHandler interface.
+type MockProbeStatusHandler struct {
 	ctrl     *gomock.Controller
-	recorder *MockBlackBoxExporterHandlerMockRecorder
+	recorder *MockProbeStatusHandlerMockRecorder
 }
 
-// MockBlackBoxExporterHandlerMockRecorder is the mock recorder for MockBlackBoxExporterHandler.
-type MockBlackBoxExporterHandlerMockRecorder struct {
-	mock *MockBlackBoxExporterHandler
+// MockProbeStatusHandlerMockRecorder is the mock recorder for MockProbeStatusHandler.
+type MockProbeStatusHandlerMockRecorder struct {
+	mock *MockProbeStatusHandler
 }
 
-// NewMockBlackBoxExporterHandler creates a new mock instance.
-func NewMockBlackBoxExporterHandler(ctrl *gomock.Controller) *MockBlackBoxExporterHandler {
-	mock := &MockBlackBoxExporterHandler{ctrl: ctrl}
-	mock.recorder = &MockBlackBoxExporterHandlerMockRecorder{mock}
+// NewMockProbeStatusHandler creates a new mock instance.
+func NewMockProbeStatusHandler(ctrl *gomock.Controller) *MockProbeStatusHandler {
+	mock := &MockProbeStatusHandler{ctrl: ctrl}
+	mock.recorder = &MockProbeStatusHandlerMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockBlackBoxExporterHandler) EXPECT() *MockBlackBoxExporterHandlerMockRecorder {
+func (m *MockProbeStatusHandler) EXPECT() *MockProbeStatusHandlerMockRecorder {
 	return m.recorder
 }
 
-// EnsureBlackBoxExporterResourcesAbsent mocks base method.
-func (m *MockBlackBoxExporterHandler) EnsureBlackBoxExporterResourcesAbsent() error {
+// GetProbeStatus mocks base method.
+func (m *MockProbeStatusHandler) GetProbeStatus(url string, rhobs bool, slo v1alpha1.SloSpec) (v1alpha1.ProbeStatus, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EnsureBlackBoxExporterResourcesAbsent")
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "GetProbeStatus", url, rhobs, slo)
+	ret0, _ := ret[0].(v1alpha1.ProbeStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// EnsureBlackBoxExporterResourcesAbsent indicates an expected call of EnsureBlackBoxExporterResourcesAbsent.
-func (mr *MockBlackBoxExporterHandlerMockRecorder) EnsureBlackBoxExporterResourcesAbsent() *gomock.Call {
+// GetProbeStatus indicates an expected call of GetProbeStatus.
+func (mr *MockProbeStatusHandlerMockRecorder) GetProbeStatus(url, rhobs, slo any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureBlackBoxExporterResourcesAbsent", reflect.TypeOf((*MockBlackBoxExporterHandler)(nil).EnsureBlackBoxExporterResourcesAbsent))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProbeStatus", reflect.TypeOf((*MockProbeStatusHandler)(nil).GetProbeStatus), url, rhobs, slo)
 }
 
-// EnsureBlackBoxExporterResourcesExist mocks base method.
-func (m *MockBlackBoxExporterHandler) EnsureBlackBoxExporterResourcesExist() error {
+// RefreshInterval mocks base method.
+func (m *MockProbeStatusHandler) RefreshInterval() time.Duration {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EnsureBlackBoxExporterResourcesExist")
-	ret0, _ := ret[0].(error)
+	ret := m.ctrl.Call(m, "RefreshInterval")
+	ret0, _ := ret[0].(time.Duration)
 	return ret0
 }
 
-// EnsureBlackBoxExporterResourcesExist indicates an expected call of EnsureBlackBoxExporterResourcesExist.
-func (mr *MockBlackBoxExporterHandlerMockRecorder) EnsureBlackBoxExporterResourcesExist() *gomock.Call {
+// RefreshInterval indicates an expected call of RefreshInterval.
+func (mr *MockProbeStatusHandlerMockRecorder) RefreshInterval() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureBlackBoxExporterResourcesExist", reflect.TypeOf((*MockBlackBoxExporterHandler)(nil).EnsureBlackBoxExporterResourcesExist))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshInterval", reflect.TypeOf((*MockProbeStatusHandler)(nil).RefreshInterval))
 }
 
-// GetBlackBoxExporterNamespace mocks base method.
-func (m *MockBlackBoxExporterHandler) GetBlackBoxExporterNamespace() string {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBlackBoxExporterNamespace")
-	ret0, _ := ret[0].(string)
-	return ret0
+// MockBlackBoxExporterHandler is a mock of BlackBoxExporterHandler interface.
+type MockBlackBoxExporterHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlackBoxExporterHandlerMockRecorder
 }
 
-// GetBlackBoxExporterNamespace indicates an expected call of GetBlackBoxExporterNamespace.
-func (mr *MockBlackBoxExporterHandlerMockRecorder) GetBlackBoxExporterNamespace() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlackBoxExporterNamespace", reflect.TypeOf((*MockBlackBoxExporterHandler)(nil).GetBlackBoxExporterNamespace))
+// MockBlackBoxExporterHandlerMockRecorder is the mock recorder for MockBlackBoxExporterHandler.
+type MockBlackBoxExporterHandlerMockRecorder struct {
+	mock *MockBlackBoxExporterHandler
+}
+
+// NewMockBlackBoxExporterHandler creates a new mock instance.
+func NewMockBlackBoxExporterHandler(ctrl *gomock.Controller) *MockBlackBoxExporterHandler {
+	mock := &MockBlackBoxExporterHandler{ctrl: ctrl}
+	mock.recorder = &MockBlackBoxExporterHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlackBoxExporterHandler) EXPECT() *MockBlackBoxExporterHandlerMockRecorder {
+	return m.recorder
 }
 
-// ShouldDeleteBlackBoxExporterResources mocks base method.
-func (m *MockBlackBoxExporterHandler) ShouldDeleteBlackBoxExporterResources() (blackboxexporter.ShouldDeleteBlackBoxExporter, error) {
+// GetBlackBoxExporterForPool mocks base method.
+func (m *MockBlackBoxExporterHandler) GetBlackBoxExporterForPool(pool string) (types.NamespacedName, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ShouldDeleteBlackBoxExporterResources")
-	ret0, _ := ret[0].(blackboxexporter.ShouldDeleteBlackBoxExporter)
+	ret := m.ctrl.Call(m, "GetBlackBoxExporterForPool", pool)
+	ret0, _ := ret[0].(types.NamespacedName)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ShouldDeleteBlackBoxExporterResources indicates an expected call of ShouldDeleteBlackBoxExporterResources.
-func (mr *MockBlackBoxExporterHandlerMockRecorder) ShouldDeleteBlackBoxExporterResources() *gomock.Call {
+// GetBlackBoxExporterForPool indicates an expected call of GetBlackBoxExporterForPool.
+func (mr *MockBlackBoxExporterHandlerMockRecorder) GetBlackBoxExporterForPool(pool any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShouldDeleteBlackBoxExporterResources", reflect.TypeOf((*MockBlackBoxExporterHandler)(nil).ShouldDeleteBlackBoxExporterResources))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlackBoxExporterForPool", reflect.TypeOf((*MockBlackBoxExporterHandler)(nil).GetBlackBoxExporterForPool), pool)
 }
@@ -0,0 +1,90 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:webhook:path=/mutate-monitoring-openshift-io-v1alpha1-clusterurlmonitor,mutating=true,failurePolicy=fail,sideEffects=None,groups=monitoring.openshift.io,resources=clusterurlmonitors,verbs=create;update,versions=v1alpha1,name=mclusterurlmonitor.monitoring.openshift.io,admissionReviewVersions=v1
+
+// ClusterUrlMonitorDefaulter sets the defaults of the fields the reconciler relies on
+type ClusterUrlMonitorDefaulter struct{}
+
+var _ admission.CustomDefaulter = &ClusterUrlMonitorDefaulter{}
+
+func (d *ClusterUrlMonitorDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	clusterUrlMonitor, ok := obj.(*v1alpha1.ClusterUrlMonitor)
+	if !ok {
+		return fmt.Errorf("expected a ClusterUrlMonitor but got %T", obj)
+	}
+	if clusterUrlMonitor.Spec.DomainRef == "" {
+		clusterUrlMonitor.Spec.DomainRef = v1alpha1.ClusterDomainRefInfra
+	}
+	setCertificateExpiryDefaults(clusterUrlMonitor.Spec.CertificateExpiry)
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-monitoring-openshift-io-v1alpha1-clusterurlmonitor,mutating=false,failurePolicy=fail,sideEffects=None,groups=monitoring.openshift.io,resources=clusterurlmonitors,verbs=create;update,versions=v1alpha1,name=vclusterurlmonitor.monitoring.openshift.io,admissionReviewVersions=v1
+
+// ClusterUrlMonitorValidator rejects the ClusterUrlMonitors the reconciler would report as invalid in the status
+type ClusterUrlMonitorValidator struct{}
+
+var _ admission.CustomValidator = &ClusterUrlMonitorValidator{}
+
+func (v *ClusterUrlMonitorValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	clusterUrlMonitor, ok := obj.(*v1alpha1.ClusterUrlMonitor)
+	if !ok {
+		return nil, fmt.Errorf("expected a ClusterUrlMonitor but got %T", obj)
+	}
+	return nil, toError("ClusterUrlMonitor", clusterUrlMonitor.Name, validateClusterUrlMonitorSpec(clusterUrlMonitor.Spec))
+}
+
+func (v *ClusterUrlMonitorValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldClusterUrlMonitor, ok := oldObj.(*v1alpha1.ClusterUrlMonitor)
+	if !ok {
+		return nil, fmt.Errorf("expected a ClusterUrlMonitor but got %T", oldObj)
+	}
+	clusterUrlMonitor, ok := newObj.(*v1alpha1.ClusterUrlMonitor)
+	if !ok {
+		return nil, fmt.Errorf("expected a ClusterUrlMonitor but got %T", newObj)
+	}
+	// ClusterUrlMonitors created before the webhook might be invalid, they can still be updated by the
+	// reconciler, e.g. to remove the finalizer, as long as the spec is left alone
+	if clusterUrlMonitor.DeletionTimestamp != nil || reflect.DeepEqual(oldClusterUrlMonitor.Spec, clusterUrlMonitor.Spec) {
+		return nil, nil
+	}
+
+	spec := field.NewPath("spec")
+	errs := validateClusterUrlMonitorSpec(clusterUrlMonitor.Spec)
+	// the domain selects the type of the ServiceMonitor, the one of the previous type would be left behind
+	if oldClusterUrlMonitor.Spec.DomainRef != "" && clusterUrlMonitor.Spec.DomainRef != oldClusterUrlMonitor.Spec.DomainRef {
+		errs = append(errs, field.Forbidden(spec.Child("domainRef"), "field is immutable"))
+	}
+	return nil, toError("ClusterUrlMonitor", clusterUrlMonitor.Name, errs)
+}
+
+func (v *ClusterUrlMonitorValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateClusterUrlMonitorSpec(clusterUrlMonitorSpec v1alpha1.ClusterUrlMonitorSpec) field.ErrorList {
+	spec := field.NewPath("spec")
+	errs := parsePort(clusterUrlMonitorSpec.Port, spec.Child("port"))
+	switch clusterUrlMonitorSpec.DomainRef {
+	case "", v1alpha1.ClusterDomainRefInfra, v1alpha1.ClusterDomainRefHCP:
+	default:
+		errs = append(errs, field.NotSupported(spec.Child("domainRef"), clusterUrlMonitorSpec.DomainRef,
+			[]string{string(v1alpha1.ClusterDomainRefInfra), string(v1alpha1.ClusterDomainRefHCP)}))
+	}
+	errs = append(errs, validateSlo(clusterUrlMonitorSpec.Slo, clusterUrlMonitorSpec.Probe, spec.Child("slo"))...)
+	errs = append(errs, validateProbe(clusterUrlMonitorSpec.Probe, clusterUrlMonitorSpec.CertificateExpiry, spec)...)
+	errs = append(errs, validateProbeLocations(clusterUrlMonitorSpec.ProbeLocations, clusterUrlMonitorSpec.Probe, spec)...)
+	errs = append(errs, validateMaintenanceWindows(clusterUrlMonitorSpec.MaintenanceWindows, spec)...)
+	return errs
+}
@@ -0,0 +1,104 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:webhook:path=/mutate-monitoring-openshift-io-v1alpha1-routemonitor,mutating=true,failurePolicy=fail,sideEffects=None,groups=monitoring.openshift.io,resources=routemonitors,verbs=create;update,versions=v1alpha1,name=mroutemonitor.monitoring.openshift.io,admissionReviewVersions=v1
+
+// RouteMonitorDefaulter sets the defaults of the fields the reconciler relies on
+type RouteMonitorDefaulter struct{}
+
+var _ admission.CustomDefaulter = &RouteMonitorDefaulter{}
+
+func (d *RouteMonitorDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	routeMonitor, ok := obj.(*v1alpha1.RouteMonitor)
+	if !ok {
+		return fmt.Errorf("expected a RouteMonitor but got %T", obj)
+	}
+	if routeMonitor.Spec.ServiceMonitorType == "" {
+		routeMonitor.Spec.ServiceMonitorType = v1alpha1.ServiceMonitorTypeCoreOS
+	}
+	if routeMonitor.Spec.Route.IngressSelector != nil && routeMonitor.Spec.Route.IngressSelector.Mode == "" {
+		routeMonitor.Spec.Route.IngressSelector.Mode = v1alpha1.IngressSelectorModeFirst
+	}
+	setCertificateExpiryDefaults(routeMonitor.Spec.CertificateExpiry)
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-monitoring-openshift-io-v1alpha1-routemonitor,mutating=false,failurePolicy=fail,sideEffects=None,groups=monitoring.openshift.io,resources=routemonitors,verbs=create;update,versions=v1alpha1,name=vroutemonitor.monitoring.openshift.io,admissionReviewVersions=v1
+
+// RouteMonitorValidator rejects the RouteMonitors the reconciler would report as invalid in the status
+type RouteMonitorValidator struct{}
+
+var _ admission.CustomValidator = &RouteMonitorValidator{}
+
+func (v *RouteMonitorValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	routeMonitor, ok := obj.(*v1alpha1.RouteMonitor)
+	if !ok {
+		return nil, fmt.Errorf("expected a RouteMonitor but got %T", obj)
+	}
+	return nil, toError("RouteMonitor", routeMonitor.Name, validateRouteMonitorSpec(routeMonitor.Spec))
+}
+
+func (v *RouteMonitorValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldRouteMonitor, ok := oldObj.(*v1alpha1.RouteMonitor)
+	if !ok {
+		return nil, fmt.Errorf("expected a RouteMonitor but got %T", oldObj)
+	}
+	routeMonitor, ok := newObj.(*v1alpha1.RouteMonitor)
+	if !ok {
+		return nil, fmt.Errorf("expected a RouteMonitor but got %T", newObj)
+	}
+	// RouteMonitors created before the webhook might be invalid, they can still be updated by the
+	// reconciler, e.g. to remove the finalizer, as long as the spec is left alone
+	if routeMonitor.DeletionTimestamp != nil || reflect.DeepEqual(oldRouteMonitor.Spec, routeMonitor.Spec) {
+		return nil, nil
+	}
+
+	spec := field.NewPath("spec")
+	errs := validateRouteMonitorSpec(routeMonitor.Spec)
+	// the ServiceMonitor of the previous type would be left behind
+	if oldRouteMonitor.Spec.ServiceMonitorType != "" && routeMonitor.Spec.ServiceMonitorType != oldRouteMonitor.Spec.ServiceMonitorType {
+		errs = append(errs, field.Forbidden(spec.Child("serviceMonitorType"), "field is immutable"))
+	}
+	return nil, toError("RouteMonitor", routeMonitor.Name, errs)
+}
+
+func (v *RouteMonitorValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateRouteMonitorSpec(routeMonitorSpec v1alpha1.RouteMonitorSpec) field.ErrorList {
+	spec := field.NewPath("spec")
+	route := spec.Child("route")
+	errs := field.ErrorList{}
+
+	if routeMonitorSpec.Route.Name == "" {
+		errs = append(errs, field.Required(route.Child("name"), "the monitored Route is required"))
+	}
+	if routeMonitorSpec.Route.Namespace == "" {
+		errs = append(errs, field.Required(route.Child("namespace"), "the namespace of the monitored Route is required"))
+	}
+	if routeMonitorSpec.Route.Port != 0 {
+		errs = append(errs, validatePort(routeMonitorSpec.Route.Port, route.Child("port"))...)
+	}
+	switch routeMonitorSpec.ServiceMonitorType {
+	case "", v1alpha1.ServiceMonitorTypeCoreOS, v1alpha1.ServiceMonitorTypeRHOBS:
+	default:
+		errs = append(errs, field.NotSupported(spec.Child("serviceMonitorType"), routeMonitorSpec.ServiceMonitorType,
+			[]string{v1alpha1.ServiceMonitorTypeCoreOS, v1alpha1.ServiceMonitorTypeRHOBS}))
+	}
+	errs = append(errs, validateSlo(routeMonitorSpec.Slo, routeMonitorSpec.Probe, spec.Child("slo"))...)
+	errs = append(errs, validateProbe(routeMonitorSpec.Probe, routeMonitorSpec.CertificateExpiry, spec)...)
+	errs = append(errs, validateProbeLocations(routeMonitorSpec.ProbeLocations, routeMonitorSpec.Probe, spec)...)
+	errs = append(errs, validateMaintenanceWindows(routeMonitorSpec.MaintenanceWindows, spec)...)
+	return errs
+}
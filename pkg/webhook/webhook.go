@@ -0,0 +1,151 @@
+package webhook
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	"github.com/openshift/route-monitor-operator/pkg/consts/blackboxexporter"
+	"github.com/openshift/route-monitor-operator/pkg/maintenance"
+	customerrors "github.com/openshift/route-monitor-operator/pkg/util/errors"
+	prometheus "github.com/prometheus/common/model"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// SetupConversionWithManager registers the conversion webhook between v1alpha1 and the v1beta1 storage version with
+// the webhook server of the manager. The CRDs convert every request through it, so it is served regardless of
+// whether the defaulting and validating webhooks are enabled
+func SetupConversionWithManager(mgr ctrl.Manager) error {
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.RouteMonitor{}).
+		Complete(); err != nil {
+		return err
+	}
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.ClusterUrlMonitor{}).
+		Complete()
+}
+
+// SetupWithManager registers the defaulting and validating webhooks of the monitors with the webhook server of the manager
+func SetupWithManager(mgr ctrl.Manager) error {
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.RouteMonitor{}).
+		WithDefaulter(&RouteMonitorDefaulter{}).
+		WithValidator(&RouteMonitorValidator{}).
+		Complete(); err != nil {
+		return err
+	}
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.ClusterUrlMonitor{}).
+		WithDefaulter(&ClusterUrlMonitorDefaulter{}).
+		WithValidator(&ClusterUrlMonitorValidator{}).
+		Complete()
+}
+
+// validateSlo rejects the SLOs the reconcilers report as ErrInvalidSLO, ErrInvalidLatencySLO or ErrInvalidSLOAlerting.
+// An empty SLO is valid, as it disables the alerts
+func validateSlo(slo v1alpha1.SloSpec, probe v1alpha1.ProbeSpec, path *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	if slo == (v1alpha1.SloSpec{}) {
+		return errs
+	}
+	if isValid, _ := slo.IsValid(); !isValid {
+		errs = append(errs, field.Invalid(path.Child("targetAvailabilityPercent"), slo.TargetAvailabilityPercent, customerrors.ErrInvalidSLO.Error()))
+	}
+	if slo.Latency != nil {
+		if isValid, _ := slo.Latency.IsValid(); !isValid {
+			errs = append(errs, field.Invalid(path.Child("latency"), *slo.Latency, customerrors.ErrInvalidLatencySLO.Error()))
+		}
+		if !slo.Latency.IsValidFor(probe) {
+			errs = append(errs, field.Invalid(path.Child("latency", "phase"), slo.Latency.Phase, "is only supported by http probes"))
+		}
+	}
+	if slo.Alerting != nil {
+		probeInterval, _ := prometheus.ParseDuration(blackboxexporter.ProbeInterval)
+		if !slo.Alerting.IsValid(time.Duration(probeInterval)) {
+			errs = append(errs, field.Invalid(path.Child("alerting"), *slo.Alerting, customerrors.ErrInvalidSLOAlerting.Error()))
+		}
+	}
+	return errs
+}
+
+// validateProbe rejects the probes and certificate expiry alerts the reconcilers report as invalid
+func validateProbe(probe v1alpha1.ProbeSpec, certificateExpiry *v1alpha1.CertificateExpirySpec, spec *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	if !probe.IsValid() {
+		errs = append(errs, field.Invalid(spec.Child("probe"), probe, customerrors.ErrInvalidProbe.Error()))
+	}
+	if certificateExpiry != nil && !certificateExpiry.IsValid() {
+		errs = append(errs, field.Invalid(spec.Child("certificateExpiry"), *certificateExpiry, customerrors.ErrInvalidCertificateExpiry.Error()))
+	}
+	return errs
+}
+
+// validateProbeLocations rejects remote exporters probing with credentials, as the credentials of a probe are only
+// copied to the blackbox exporter of the monitor
+func validateProbeLocations(locations *v1alpha1.ProbeLocationsSpec, probe v1alpha1.ProbeSpec, spec *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	if locations == nil || probe.Auth == nil {
+		return errs
+	}
+	for i, location := range locations.Locations {
+		if location.Type == v1alpha1.ProbeLocationRemoteExporter {
+			errs = append(errs, field.Forbidden(spec.Child("probeLocations", "locations").Index(i), "remote exporters cannot probe with the credentials of probe.auth"))
+		}
+	}
+	return errs
+}
+
+// validateMaintenanceWindows rejects the schedules the reconcilers report as ErrInvalidMaintenanceWindow
+func validateMaintenanceWindows(windows []v1alpha1.MaintenanceWindow, spec *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	for i, window := range windows {
+		if window.Schedule == "" {
+			continue
+		}
+		if _, err := maintenance.ParseSchedule(window.Schedule); err != nil {
+			errs = append(errs, field.Invalid(spec.Child("maintenanceWindows").Index(i).Child("schedule"), window.Schedule, err.Error()))
+		}
+	}
+	return errs
+}
+
+// validatePort rejects ports outside of the range of TCP ports
+func validatePort(port int64, path *field.Path) field.ErrorList {
+	if port < 1 || port > 65535 {
+		return field.ErrorList{field.Invalid(path, port, "must be between 1 and 65535")}
+	}
+	return nil
+}
+
+// parsePort parses the port of a ClusterUrlMonitor, which is stored as string
+func parsePort(port string, path *field.Path) field.ErrorList {
+	value, err := strconv.ParseInt(port, 10, 64)
+	if err != nil {
+		return field.ErrorList{field.Invalid(path, port, "must be a number between 1 and 65535")}
+	}
+	return validatePort(value, path)
+}
+
+// setCertificateExpiryDefaults sets the thresholds the CRD defaults to, so both are set for the validation
+func setCertificateExpiryDefaults(certificateExpiry *v1alpha1.CertificateExpirySpec) {
+	if certificateExpiry == nil {
+		return
+	}
+	if certificateExpiry.WarningDays == 0 {
+		certificateExpiry.WarningDays = 30
+	}
+	if certificateExpiry.CriticalDays == 0 {
+		certificateExpiry.CriticalDays = 7
+	}
+}
+
+// toError returns the admission error of a monitor, nil if there are no errors
+func toError(kind, name string, errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return k8serrors.NewInvalid(v1alpha1.GroupVersion.WithKind(kind).GroupKind(), name, errs)
+}
@@ -0,0 +1,235 @@
+package webhook_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/route-monitor-operator/api/v1alpha1"
+	. "github.com/openshift/route-monitor-operator/pkg/webhook"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Webhook", func() {
+	ctx := context.Background()
+
+	Describe("RouteMonitor", func() {
+		var (
+			routeMonitor v1alpha1.RouteMonitor
+			defaulter    RouteMonitorDefaulter
+			validator    RouteMonitorValidator
+		)
+		BeforeEach(func() {
+			routeMonitor = v1alpha1.RouteMonitor{
+				ObjectMeta: metav1.ObjectMeta{Name: "monitor", Namespace: "namespace"},
+				Spec: v1alpha1.RouteMonitorSpec{
+					Route: v1alpha1.RouteMonitorRouteSpec{Name: "route", Namespace: "namespace"},
+					Slo:   v1alpha1.SloSpec{TargetAvailabilityPercent: "99.5"},
+				},
+			}
+		})
+
+		Describe("Default", func() {
+			It("sets the ServiceMonitor type and the ingress selector mode", func() {
+				routeMonitor.Spec.Route.IngressSelector = &v1alpha1.RouteIngressSelector{}
+				Expect(defaulter.Default(ctx, &routeMonitor)).To(Succeed())
+				Expect(routeMonitor.Spec.ServiceMonitorType).To(Equal(v1alpha1.ServiceMonitorTypeCoreOS))
+				Expect(routeMonitor.Spec.Route.IngressSelector.Mode).To(Equal(v1alpha1.IngressSelectorModeFirst))
+			})
+			It("sets the thresholds of the certificate expiry", func() {
+				routeMonitor.Spec.CertificateExpiry = &v1alpha1.CertificateExpirySpec{WarningDays: 14}
+				Expect(defaulter.Default(ctx, &routeMonitor)).To(Succeed())
+				Expect(*routeMonitor.Spec.CertificateExpiry).To(Equal(v1alpha1.CertificateExpirySpec{WarningDays: 14, CriticalDays: 7}))
+			})
+		})
+
+		Describe("ValidateCreate", func() {
+			It("accepts a valid RouteMonitor", func() {
+				_, err := validator.ValidateCreate(ctx, &routeMonitor)
+				Expect(err).NotTo(HaveOccurred())
+			})
+			It("accepts a RouteMonitor without SLO", func() {
+				routeMonitor.Spec.Slo = v1alpha1.SloSpec{}
+				_, err := validator.ValidateCreate(ctx, &routeMonitor)
+				Expect(err).NotTo(HaveOccurred())
+			})
+			It("rejects an out of range availability", func() {
+				routeMonitor.Spec.Slo.TargetAvailabilityPercent = "100"
+				_, err := validator.ValidateCreate(ctx, &routeMonitor)
+				Expect(k8serrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring("spec.slo.targetAvailabilityPercent"))
+			})
+			It("rejects a missing Route", func() {
+				routeMonitor.Spec.Route = v1alpha1.RouteMonitorRouteSpec{}
+				_, err := validator.ValidateCreate(ctx, &routeMonitor)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("spec.route.name"))
+				Expect(err.Error()).To(ContainSubstring("spec.route.namespace"))
+			})
+			It("rejects a bad port", func() {
+				routeMonitor.Spec.Route.Port = 70000
+				_, err := validator.ValidateCreate(ctx, &routeMonitor)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("spec.route.port"))
+			})
+			It("rejects an unknown ServiceMonitor type", func() {
+				routeMonitor.Spec.ServiceMonitorType = "monitoring.example.com"
+				_, err := validator.ValidateCreate(ctx, &routeMonitor)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("spec.serviceMonitorType"))
+			})
+			It("rejects an invalid probe", func() {
+				routeMonitor.Spec.Probe.HTTP = &v1alpha1.HTTPProbeSpec{FailIfBodyMatchesRegexp: []string{"("}}
+				_, err := validator.ValidateCreate(ctx, &routeMonitor)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("spec.probe"))
+			})
+			It("only accepts a latency phase for http probes", func() {
+				routeMonitor.Spec.Slo.Latency = &v1alpha1.LatencySloSpec{TargetPercent: "99", Threshold: "800ms", Phase: "connect"}
+				_, err := validator.ValidateCreate(ctx, &routeMonitor)
+				Expect(err).NotTo(HaveOccurred())
+
+				routeMonitor.Spec.Probe = v1alpha1.ProbeSpec{Type: v1alpha1.ProbeTypeTCP, Target: "db.example.com:5432"}
+				_, err = validator.ValidateCreate(ctx, &routeMonitor)
+				Expect(k8serrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring("spec.slo.latency.phase"))
+			})
+			It("rejects a target which does not fit the probe type", func() {
+				for _, probe := range []v1alpha1.ProbeSpec{
+					{Target: "db.example.com:5432"},
+					{Type: v1alpha1.ProbeTypeTCP, Target: "db.example.com"},
+					{Type: v1alpha1.ProbeTypeTCP, Target: "db.example.com:70000"},
+					{Type: v1alpha1.ProbeTypeICMP, Target: "gateway.example.com:22"},
+					{Type: v1alpha1.ProbeTypeDNS, Target: "db_example"},
+				} {
+					routeMonitor.Spec.Probe = probe
+					_, err := validator.ValidateCreate(ctx, &routeMonitor)
+					Expect(err).To(HaveOccurred(), probe.Target)
+					Expect(err.Error()).To(ContainSubstring("spec.probe"))
+				}
+			})
+			It("accepts a target fitting the probe type", func() {
+				for _, probe := range []v1alpha1.ProbeSpec{
+					{Type: v1alpha1.ProbeTypeTCP, Target: "db.example.com:5432"},
+					{Type: v1alpha1.ProbeTypeGRPC, Target: "[fd00::1]:9000"},
+					{Type: v1alpha1.ProbeTypeICMP, Target: "10.0.0.1"},
+					{Type: v1alpha1.ProbeTypeDNS, Target: "db.example.com."},
+				} {
+					routeMonitor.Spec.Probe = probe
+					_, err := validator.ValidateCreate(ctx, &routeMonitor)
+					Expect(err).NotTo(HaveOccurred(), probe.Target)
+				}
+			})
+			It("rejects remote exporters probing with credentials", func() {
+				routeMonitor.Spec.Probe.Auth = &v1alpha1.ProbeAuthSpec{Type: "bearerToken", SecretName: "token"}
+				routeMonitor.Spec.ProbeLocations = &v1alpha1.ProbeLocationsSpec{
+					Locations: []v1alpha1.ProbeLocation{
+						{Name: "cluster", Type: v1alpha1.ProbeLocationInCluster},
+						{Name: "eu-west-1", Type: v1alpha1.ProbeLocationRemoteExporter, URL: "https://blackbox.eu-west-1.example.com"},
+					},
+				}
+				_, err := validator.ValidateCreate(ctx, &routeMonitor)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("spec.probeLocations.locations[1]"))
+				Expect(err.Error()).NotTo(ContainSubstring("spec.probeLocations.locations[0]"))
+			})
+			It("rejects an invalid maintenance schedule", func() {
+				routeMonitor.Spec.MaintenanceWindows = []v1alpha1.MaintenanceWindow{
+					{Name: "nightly", Schedule: "0 2 * * *", Duration: &metav1.Duration{Duration: time.Hour}},
+					{Name: "weekly", Schedule: "0 2 * * SAT", Duration: &metav1.Duration{Duration: time.Hour}},
+				}
+				_, err := validator.ValidateCreate(ctx, &routeMonitor)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("spec.maintenanceWindows[1].schedule"))
+				Expect(err.Error()).NotTo(ContainSubstring("spec.maintenanceWindows[0]"))
+			})
+		})
+
+		Describe("ValidateUpdate", func() {
+			var updated v1alpha1.RouteMonitor
+			BeforeEach(func() {
+				routeMonitor.Spec.ServiceMonitorType = v1alpha1.ServiceMonitorTypeCoreOS
+				updated = *routeMonitor.DeepCopy()
+			})
+			It("rejects a change of the ServiceMonitor type", func() {
+				updated.Spec.ServiceMonitorType = v1alpha1.ServiceMonitorTypeRHOBS
+				_, err := validator.ValidateUpdate(ctx, &routeMonitor, &updated)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("field is immutable"))
+			})
+			It("accepts updates leaving the spec of an invalid RouteMonitor alone", func() {
+				routeMonitor.Spec.Slo.TargetAvailabilityPercent = "invalid"
+				updated = *routeMonitor.DeepCopy()
+				updated.Finalizers = nil
+				_, err := validator.ValidateUpdate(ctx, &routeMonitor, &updated)
+				Expect(err).NotTo(HaveOccurred())
+			})
+			It("rejects an update to an invalid spec", func() {
+				updated.Spec.Slo.TargetAvailabilityPercent = "invalid"
+				_, err := validator.ValidateUpdate(ctx, &routeMonitor, &updated)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("ClusterUrlMonitor", func() {
+		var (
+			clusterUrlMonitor v1alpha1.ClusterUrlMonitor
+			defaulter         ClusterUrlMonitorDefaulter
+			validator         ClusterUrlMonitorValidator
+		)
+		BeforeEach(func() {
+			clusterUrlMonitor = v1alpha1.ClusterUrlMonitor{
+				ObjectMeta: metav1.ObjectMeta{Name: "monitor", Namespace: "namespace"},
+				Spec: v1alpha1.ClusterUrlMonitorSpec{
+					Prefix: "api.",
+					Port:   "6443",
+					Suffix: "/livez",
+					Slo:    v1alpha1.SloSpec{TargetAvailabilityPercent: "99.5"},
+				},
+			}
+		})
+
+		It("defaults the domain to infra", func() {
+			Expect(defaulter.Default(ctx, &clusterUrlMonitor)).To(Succeed())
+			Expect(clusterUrlMonitor.Spec.DomainRef).To(Equal(v1alpha1.ClusterDomainRefInfra))
+		})
+
+		Describe("ValidateCreate", func() {
+			It("accepts a valid ClusterUrlMonitor", func() {
+				_, err := validator.ValidateCreate(ctx, &clusterUrlMonitor)
+				Expect(err).NotTo(HaveOccurred())
+			})
+			It("rejects a bad port", func() {
+				clusterUrlMonitor.Spec.Port = "https"
+				_, err := validator.ValidateCreate(ctx, &clusterUrlMonitor)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("spec.port"))
+			})
+			It("rejects an unknown domain", func() {
+				clusterUrlMonitor.Spec.DomainRef = "apps"
+				_, err := validator.ValidateCreate(ctx, &clusterUrlMonitor)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("spec.domainRef"))
+			})
+			It("rejects an invalid latency objective", func() {
+				clusterUrlMonitor.Spec.Slo.Latency = &v1alpha1.LatencySloSpec{TargetPercent: "99", Threshold: "fast"}
+				_, err := validator.ValidateCreate(ctx, &clusterUrlMonitor)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("spec.slo.latency"))
+			})
+		})
+
+		It("rejects a change of the domain", func() {
+			clusterUrlMonitor.Spec.DomainRef = v1alpha1.ClusterDomainRefInfra
+			updated := clusterUrlMonitor.DeepCopy()
+			updated.Spec.DomainRef = v1alpha1.ClusterDomainRefHCP
+			_, err := validator.ValidateUpdate(ctx, &clusterUrlMonitor, updated)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.domainRef"))
+		})
+	})
+})
@@ -18,6 +18,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -123,6 +124,7 @@ func TestProbeDeletionRetry(t *testing.T) {
 			Client:      fakeClient,
 			Scheme:      scheme,
 			RHOBSConfig: rhobsConfig,
+			Recorder:    &record.FakeRecorder{},
 		}
 
 		// STEP 1: Create HCP normally (without DeletionTimestamp)